@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type upcomingRaidDay struct {
+	RaidDate   string //In format timeLayOutShort
+	RaidTitles []string
+	RaidStart  time.Time //Start of the first raid of the day
+}
+
+const (
+	absenceMaxRaids     = 7  //Raid days that can be picked at once in /absent raids, the dates must fit in the custom ID of the modal
+	absenceMaxRangeDays = 31 //Longest range of days allowed in /absent range
+	absenceMaxRaidDays  = 25 //Discord allows no more options in a select menu
+)
+
+// The raids posted by raid-helper that has not started yet, grouped per day and sorted by the start of the raid
+func GetUpcomingRaidDays(guildID string) []upcomingRaidDay {
+	raidDays := []upcomingRaidDay{}
+	mapOfRaidDayIndexes := make(map[string]int)
+	trackedRaids := []trackRaid{}
+	for _, raid := range ReadWriteRaidHelperCache(guildID) {
+		if raid.RaidStartUnixTime == 0 || time.Unix(raid.RaidStartUnixTime, 0).Before(time.Now()) { //Raids tracked before the start was stored cannot be placed on a date
+			continue
+		}
+		trackedRaids = append(trackedRaids, raid)
+	}
+	sort.Slice(trackedRaids, func(i, j int) bool {
+		return trackedRaids[i].RaidStartUnixTime < trackedRaids[j].RaidStartUnixTime
+	})
+	for _, raid := range trackedRaids {
+		raidStart := time.Unix(raid.RaidStartUnixTime, 0)
+		raidDate := raidStart.In(GetGuildLocation(guildID)).Format(timeLayOutShort)
+		index, ok := mapOfRaidDayIndexes[raidDate]
+		if !ok {
+			raidDays = append(raidDays, upcomingRaidDay{RaidDate: raidDate, RaidStart: raidStart})
+			index = len(raidDays) - 1
+			mapOfRaidDayIndexes[raidDate] = index
+		}
+		if raid.RaidDiscordTitle != "" {
+			raidDays[index].RaidTitles = append(raidDays[index].RaidTitles, raid.RaidDiscordTitle)
+		}
+	}
+	return raidDays
+}
+
+func HandleAbsentRaids(request *slashCommandRequest) {
+	if _, errString := GetRaiderProfile(request.Guild.ServerID, request.UserID); errString != "" {
+		RespondSlashCommandError(request, "absent raids|No raider profile found for you, please contact an officer")
+		return
+	}
+	raidDays := GetUpcomingRaidDays(request.Guild.ServerID)
+	if len(raidDays) == 0 {
+		RespondSlashCommandError(request, "absent raids|No upcoming raids are posted by raid-helper yet, please use /absent range instead")
+		return
+	}
+	raidDays = raidDays[:min(len(raidDays), absenceMaxRaidDays)]
+	selectOptions := []discordgo.SelectMenuOption{}
+	for _, raidDay := range raidDays {
+		label := fmt.Sprintf("%s %s", raidDay.RaidStart.In(GetGuildLocation(request.Guild.ServerID)).Weekday().String(), raidDay.RaidDate)
+		if len(raidDay.RaidTitles) > 0 {
+			label = fmt.Sprintf("%s - %s", label, strings.Join(raidDay.RaidTitles, " & "))
+		}
+		if len(label) > 100 {
+			label = label[:97] + "..."
+		}
+		selectOptions = append(selectOptions, discordgo.SelectMenuOption{
+			Label: label,
+			Value: raidDay.RaidDate,
+		})
+	}
+	minValues := 1
+	err := request.Session.InteractionRespond(request.Event.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Flags: discordgo.MessageFlagsEphemeral,
+			Embeds: []*discordgo.MessageEmbed{
+				{
+					Title:       "Which raids will you miss?",
+					Description: fmt.Sprintf("Pick up to %d raid days, the reason is asked for afterwards. Report at least %d hours before the raid starts, or the absence counts as a late notice", absenceMaxRaids, request.Guild.AttendancePolicy.NoticeHours),
+					Color:       blueColor,
+				},
+			},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.SelectMenu{
+							MenuType:    discordgo.StringSelectMenu,
+							CustomID:    "absent",
+							Placeholder: "Pick the raid days",
+							MinValues:   &minValues,
+							MaxValues:   min(len(selectOptions), absenceMaxRaids),
+							Options:     selectOptions,
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the upcoming raids to user %s using slash command /absent raids, during the function HandleAbsentRaids()", request.UserID), err.Error())
+	}
+}
+
+// Custom ID absent, the raid days picked are passed on to the modal asking for the reason
+func HandleAbsentSelect(request *slashCommandRequest) {
+	raidDates := request.Event.MessageComponentData().Values
+	if len(raidDates) == 0 {
+		RespondSlashCommandError(request, "absent|No raid days were picked, please run /absent raids again")
+		return
+	}
+	deepCopy := *slashCommandAllUsers["absent"].Responses["reason"].Response
+	dataCopy := *deepCopy.Data
+	deepCopy.Data = &dataCopy
+	deepCopy.Data.CustomID = fmt.Sprintf("%s/%s", deepCopy.Data.CustomID, strings.Join(raidDates[:min(len(raidDates), absenceMaxRaids)], ","))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &deepCopy)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the reason form to user %s using slash command /absent raids, during the function HandleAbsentSelect()", request.UserID), err.Error())
+	}
+}
+
+// Custom ID absent_modal/<raid date>,<raid date>..., the modal is sent by the function HandleAbsentSelect()
+func HandleAbsentModal(request *slashCommandRequest) {
+	reason := ""
+	for _, component := range request.Event.ModalSubmitData().Components {
+		if row, ok := component.(*discordgo.ActionsRow); ok {
+			if input, ok := row.Components[0].(*discordgo.TextInput); ok {
+				reason = input.Value
+				break
+			}
+		}
+	}
+	if len(request.CustomIDParts) < 2 {
+		RespondSlashCommandError(request, "absent|The raid days picked are missing, please run /absent raids again")
+		return
+	}
+	RecordAbsences(request, "absent raids", strings.Split(request.CustomIDParts[1], ","), reason)
+}
+
+func HandleAbsentRange(request *slashCommandRequest) {
+	from, err := ParseGuildTime(timeLayOutShort, strings.TrimSpace(request.StringOption("from")))
+	if err != nil {
+		RespondSlashCommandError(request, fmt.Sprintf("absent range|The date %s is not in format dd-mm-yyyy", request.StringOption("from")))
+		return
+	}
+	to, err := ParseGuildTime(timeLayOutShort, strings.TrimSpace(request.StringOption("to")))
+	if err != nil {
+		RespondSlashCommandError(request, fmt.Sprintf("absent range|The date %s is not in format dd-mm-yyyy", request.StringOption("to")))
+		return
+	}
+	today, _ := ParseGuildTime(timeLayOutShort, time.Now().In(GetGuildLocation(request.Guild.ServerID)).Format(timeLayOutShort))
+	switch {
+	case from.Before(today):
+		{
+			RespondSlashCommandError(request, "absent range|Raids that already happened cannot be reported, the first day must be today or later")
+			return
+		}
+	case to.Before(from):
+		{
+			RespondSlashCommandError(request, "absent range|The last day must be the same as or after the first day")
+			return
+		}
+	case to.Sub(from) >= time.Hour*24*absenceMaxRangeDays:
+		{
+			RespondSlashCommandError(request, fmt.Sprintf("absent range|At most %d days can be reported at once, please contact an officer for longer absences", absenceMaxRangeDays))
+			return
+		}
+	}
+	raidDates := []string{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		raidDates = append(raidDates, day.Format(timeLayOutShort))
+	}
+	RecordAbsences(request, "absent range", raidDates, request.StringOption("reason"))
+}
+
+// Stores the absences on the profile of the raider and posts them to the officer channel, a raid day already reported keeps the time it was first reported.
+// The response is deferred first, as reading the profiles and the upcoming raids can take longer than discord waits for a response
+func RecordAbsences(request *slashCommandRequest, commandName string, raidDates []string, reason string) {
+	reason = strings.TrimSpace(strings.ReplaceAll(reason, "|", "/")) //The response format uses | to split title and message
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Reporting absence...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /%s, during the function RecordAbsences()", request.UserID, commandName), err.Error())
+		return
+	}
+	errNoRaiderProfile := fmt.Errorf("no raider profile found for user %s", request.UserID)
+	raider := raiderProfile{}
+	err = UpdateRaiderProfiles(request.Guild.ServerID, func(profiles *raiderProfiles) error {
+		raiderIndex := slices.IndexFunc(profiles.Raiders, func(currentRaider raiderProfile) bool {
+			return currentRaider.ID == request.UserID
+		})
+		if raiderIndex == -1 {
+			return errNoRaiderProfile
+		}
+		currentRaider := &profiles.Raiders[raiderIndex]
+		reportedAt := time.Now()
+		for _, raidDate := range raidDates {
+			if _, err := ParseGuildTime(timeLayOutShort, raidDate); err != nil {
+				WriteWarningLog(fmt.Sprintf("The raid date %s reported by user %s is not in format %s and is skipped, during the function RecordAbsences()", raidDate, request.UserID, timeLayOutShort), "Wrong date format")
+				continue
+			}
+			found := false
+			for x := range currentRaider.Absences {
+				if currentRaider.Absences[x].RaidDate == raidDate {
+					currentRaider.Absences[x].Reason = reason
+					found = true
+				}
+			}
+			if !found {
+				currentRaider.Absences = append(currentRaider.Absences, raiderAbsence{
+					RaidDate:   raidDate,
+					Reason:     reason,
+					ReportedAt: reportedAt,
+				})
+			}
+		}
+		raider = *currentRaider
+		return nil
+	})
+	if err != nil {
+		if err == errNoRaiderProfile {
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("%s|No raider profile found for you, please contact an officer", commandName))
+		} else {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to save the absences of user %s, during the function RecordAbsences()", request.UserID), err.Error())
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("%s|The absence could not be saved, please try again later", commandName))
+		}
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the error of /%s to user %s, during the function RecordAbsences()", commandName, request.UserID), err.Error())
+		}
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("The raider %s has reported %d days of absence, during the function RecordAbsences()", raider.MainCharName, len(raidDates)), "Absence reported", LogField("guildID", request.Guild.ServerID), LogField("userID", request.UserID))
+
+	policy := request.Guild.AttendancePolicy
+	raidLines := []string{}
+	lateNotices := 0
+	for _, raidDay := range GetUpcomingRaidDays(request.Guild.ServerID) {
+		if !slices.Contains(raidDates, raidDay.RaidDate) {
+			continue
+		}
+		line := fmt.Sprintf("%s %s", raidDay.RaidDate, strings.Join(raidDay.RaidTitles, " & "))
+		if !IsAbsenceReportedInTime(request.Guild.ServerID, raider, raidDay.RaidStart, policy) {
+			line += " (late notice)"
+			lateNotices++
+		}
+		raidLines = append(raidLines, line)
+	}
+	period := strings.Join(raidDates, ", ")
+	if len(raidDates) > absenceMaxRaids { //Only a range from /absent range can be this long
+		period = fmt.Sprintf("%s to %s", raidDates[0], raidDates[len(raidDates)-1])
+	}
+
+	message := fmt.Sprintf("Your absence on %s has been sent to the officers", period)
+	if lateNotices > 0 {
+		message += fmt.Sprintf(" - %d of the raids start in less than %d hours, so the absence counts as a late notice", lateNotices, policy.NoticeHours)
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("%s|%s", commandName, message))
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the response to user %s using slash command /%s, during the function RecordAbsences()", request.UserID, commandName), err.Error())
+	}
+
+	officerMessage := fmt.Sprintf("**Away:** %s\n**Reason:** %s", period, reason)
+	if len(raidLines) > 0 {
+		officerMessage += fmt.Sprintf("\n**Raids missed:**\n%s", JoinWithinEmbedLimit(raidLines, "\n"))
+	}
+	_, err = request.Session.ChannelMessageSendEmbed(request.Guild.Channels.Officer, &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("%s will be absent", raider.MainCharName),
+		Description: officerMessage,
+		Color:       blueColor,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to post the absence of raider %s to the officer channel %s, during the function RecordAbsences()", raider.MainCharName, request.Guild.Channels.Officer), err.Error())
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	attendanceAttended  = "attended"
+	attendanceBenched   = "benched"
+	attendanceExcused   = "excused"
+	attendanceUnexcused = "unexcused"
+	attendanceRemove    = "remove" //Only used by /overrideattendance to remove an override again
+)
+
+var (
+	attendanceStatuses = []string{attendanceAttended, attendanceBenched, attendanceExcused, attendanceUnexcused}
+)
+
+func IsRaiderInRaid(raider raiderProfile, raid logAllData) bool {
+	for _, player := range raid.Players {
+		if _, ok := raider.MainSwitch[player.Name]; ok || player.Name == raider.MainCharName {
+			return true
+		}
+	}
+	return false
+}
+
+// An override from an officer always wins, otherwise the log, the bench records and the absences of the raider decide.
+// Returns an empty string when the raid was before the raider joined and is not counted at all
+func GetRaidAttendanceStatus(guildID string, raider raiderProfile, raid logAllData, joinedGuild time.Time, policy attendancePolicy) string {
+	if status, ok := raider.AttendanceOverrides[raid.MetaData.Code]; ok {
+		return status
+	}
+	if IsRaiderInRaid(raider, raid) {
+		return attendanceAttended
+	}
+	raidStart := time.UnixMilli(raid.RaidStartUnixTime)
+	if raidStart.Before(joinedGuild) {
+		return ""
+	}
+	raidDate := raidStart.In(GetGuildLocation(guildID)).Format(timeLayOutShort)
+	for _, benches := range raider.BenchInfo {
+		for _, bench := range benches {
+			if bench.DateString == raidDate {
+				return attendanceBenched
+			}
+		}
+	}
+	if policy.ExcuseAbsences && IsAbsenceReportedInTime(guildID, raider, raidStart, policy) {
+		return attendanceExcused
+	}
+	return attendanceUnexcused
+}
+
+// True when the raider reported an absence for the date of the raid before the notice deadline of the policy
+func IsAbsenceReportedInTime(guildID string, raider raiderProfile, raidStart time.Time, policy attendancePolicy) bool {
+	raidDate := raidStart.In(GetGuildLocation(guildID)).Format(timeLayOutShort)
+	deadline := raidStart.Add(-time.Duration(policy.NoticeHours) * time.Hour)
+	for _, absence := range raider.Absences {
+		if absence.RaidDate == raidDate && !absence.ReportedAt.After(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// Kept short, as it is part of the attendance summary which must fit in a single embed field
+func NewAttendanceBreakdownSummary(raider raiderProfile) string {
+	var summaryWriter strings.Builder
+	summaryWriter.WriteString("/ Breakdown     / Attended / Benched / Excused / Unexcused / Late Notice\n")
+	for _, period := range []struct {
+		key  string
+		name string
+	}{
+		{"oneMonth", "Last Month"},
+		{"twoMonth", "Last 2 Months"},
+		{"threeMonth", "Last 3 Months"},
+		{"guildStart", "Since Start"},
+	} {
+		periodAttendance := raider.AttendanceInfo[period.key]
+		summaryWriter.WriteString(fmt.Sprintf("/ %-13s / %d / %d / %d / %d / %.0f%%\n", period.name, periodAttendance.RaidCount, periodAttendance.Benched, periodAttendance.Excused, periodAttendance.Unexcused, periodAttendance.LateNoticeProcent))
+	}
+	countOfNoShows, countOfUnsigned := 0, 0
+	threeMonthsBack := time.Now().AddDate(0, -3, 0)
+	for _, record := range raider.SignUpHistory {
+		if raidDate, err := ParseGuildTime(timeLayOutShort, record.RaidDate); err != nil || raidDate.Before(threeMonthsBack) {
+			continue
+		}
+		switch record.Outcome {
+		case signUpOutcomeNoShow:
+			{
+				countOfNoShows++
+			}
+		case signUpOutcomeUnsigned:
+			{
+				countOfUnsigned++
+			}
+		}
+	}
+	summaryWriter.WriteString(fmt.Sprintf("/ Sign-ups     / %d no-shows / %d raids without a sign-up (last 3 months)\n", countOfNoShows, countOfUnsigned))
+	return summaryWriter.String()
+}
+
+func GetAttendanceStatusChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, status := range append(slices.Clone(attendanceStatuses), attendanceRemove) {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  status,
+			Value: status,
+		})
+	}
+	return choices
+}
+
+// Accepts both the code of a report and the link to it
+func ParseWarcraftLogsReportCode(value string) string {
+	value = strings.TrimSpace(value)
+	if index := strings.Index(value, "/reports/"); index != -1 {
+		value = value[index+len("/reports/"):]
+	}
+	return strings.Split(strings.Split(value, "#")[0], "?")[0]
+}
+
+func HandleOverrideAttendance(request *slashCommandRequest) {
+	raiderName := FormatRaiderID(strings.TrimPrefix(request.StringOption("playername"), "@"))
+	code := ParseWarcraftLogsReportCode(request.StringOption("log"))
+	status := request.StringOption("status")
+	if !slices.Contains(attendanceStatuses, status) && status != attendanceRemove {
+		RespondSlashCommandError(request, fmt.Sprintf("overrideattendance|The status %s is not known, use one of %s", status, strings.Join(attendanceStatuses, ", ")))
+		return
+	}
+	raids, err := storageCurrent.ReadRaids(request.Guild.ServerID, time.Time{})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raids of server %s, during the function HandleOverrideAttendance()", request.Guild.ServerID), err.Error())
+		RespondSlashCommandError(request, "overrideattendance|The raid cache could not be read, please try again later")
+		return
+	}
+	raidIndex := slices.IndexFunc(raids, func(raid logAllData) bool {
+		return raid.MetaData.Code == code
+	})
+	if raidIndex == -1 {
+		RespondSlashCommandError(request, fmt.Sprintf("overrideattendance|No cached raid has the log %s, use the code or link of the log", code))
+		return
+	}
+	currentRaiders, err := storageCurrent.ReadRaiderProfiles(request.Guild.ServerID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raider profiles of server %s, during the function HandleOverrideAttendance()", request.Guild.ServerID), err.Error())
+		RespondSlashCommandError(request, "overrideattendance|The raider profiles could not be read, please try again later")
+		return
+	}
+	raiderIndex := slices.IndexFunc(currentRaiders.Raiders, func(raider raiderProfile) bool {
+		return raider.ID == raiderName || raider.MainCharName == raiderName
+	})
+	if raiderIndex == -1 {
+		RespondSlashCommandError(request, fmt.Sprintf("overrideattendance|No raider profile found for %s", raiderName))
+		return
+	}
+
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Updating attendance...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /overrideattendance, during the function HandleOverrideAttendance()", request.UserID), err.Error())
+		return
+	}
+	raider := currentRaiders.Raiders[raiderIndex]
+	err = UpdateRaiderProfiles(request.Guild.ServerID, func(profiles *raiderProfiles) error { //The profiles are read again, they might have changed since they were read above
+		raiderIndex := slices.IndexFunc(profiles.Raiders, func(currentRaider raiderProfile) bool {
+			return currentRaider.MainCharName == raider.MainCharName
+		})
+		if raiderIndex == -1 {
+			return fmt.Errorf("the raider profile of %s has been removed in the meantime", raider.MainCharName)
+		}
+		currentRaider := &profiles.Raiders[raiderIndex]
+		if status == attendanceRemove {
+			delete(currentRaider.AttendanceOverrides, code)
+		} else {
+			if currentRaider.AttendanceOverrides == nil {
+				currentRaider.AttendanceOverrides = make(map[string]string)
+			}
+			currentRaider.AttendanceOverrides[code] = status
+		}
+		return nil
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write the attendance override of raider %s, during the function HandleOverrideAttendance()", raider.MainCharName), err.Error())
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, "overrideattendance|The override could not be saved, please try again later")
+	} else {
+		WriteInformationLog(fmt.Sprintf("The attendance of raider %s in the log %s has been set to %s by user %s, during the function HandleOverrideAttendance()", raider.MainCharName, code, status, request.UserID), "Attendance override", LogField("guildID", request.Guild.ServerID), LogField("logCode", code))
+		AddWeeklyRaiderAttendance(request.Guild.ServerID)
+		updatedRaider, _ := GetRaiderProfile(request.Guild.ServerID, raider.MainCharName)
+		message := fmt.Sprintf("%s is now %s in %s", raider.MainCharName, status, raids[raidIndex].RaidTitle)
+		if status == attendanceRemove {
+			message = fmt.Sprintf("The override of %s in %s is removed, the raid is counted from the log again", raider.MainCharName, raids[raidIndex].RaidTitle)
+		}
+		interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("overrideattendance|%s - The attendance of the last 3 months is now %.0f%%", message, updatedRaider.AttendanceInfo["threeMonth"].RaidProcent))
+	}
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the result of /overrideattendance to user %s, during the function HandleOverrideAttendance()", request.UserID), err.Error())
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// Rebuilding the raider profiles from the logs must keep what officers and raiders have recorded on the profiles
+func TestInitializeRaiderProfilesKeepsRecords(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	raidStart := time.Now().AddDate(0, 0, -7)
+	raid := logAllData{
+		RaidTitle:         "Molten Core",
+		RaidStartUnixTime: raidStart.UnixMilli(),
+		MetaData:          logsBase{Code: "fakeRaidMC01"},
+		Players:           []logPlayer{{Name: "Frostbolt", ClassName: "Mage"}, {Name: "Healbot", ClassName: "Priest"}},
+	}
+	if err := storageCurrent.WriteRaids(guildID, []logAllData{raid}); err != nil {
+		t.Fatal(err)
+	}
+	existingProfile := raiderProfile{
+		MainCharName:        "Frostbolt",
+		AttendanceOverrides: map[string]string{"fakeRaidMC01": attendanceExcused},
+		Absences:            []raiderAbsence{{RaidDate: raidStart.Format(timeLayOutShort), Reason: "Holiday", ReportedAt: raidStart.AddDate(0, 0, -2)}},
+		SignUpHistory:       []signUpRecord{{LogCode: "fakeRaidMC01", RaidDate: raidStart.Format(timeLayOutShort), Outcome: signUpOutcomeUnsigned}},
+	}
+	if err := storageCurrent.WriteRaiderProfiles(guildID, raiderProfiles{Raiders: []raiderProfile{existingProfile}}); err != nil {
+		t.Fatal(err)
+	}
+
+	InitializeRaiderProfiles(guildID)
+
+	profiles, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles.Raiders) != 2 {
+		t.Fatalf("expected a raider profile per player in the logs, got %d", len(profiles.Raiders))
+	}
+	for _, raider := range profiles.Raiders {
+		if raider.MainCharName != "Frostbolt" {
+			continue
+		}
+		if raider.AttendanceOverrides["fakeRaidMC01"] != attendanceExcused {
+			t.Errorf("expected the attendance override of Frostbolt to be kept, got %+v", raider.AttendanceOverrides)
+		}
+		if len(raider.Absences) != 1 || raider.Absences[0].Reason != "Holiday" {
+			t.Errorf("expected the absence of Frostbolt to be kept, got %+v", raider.Absences)
+		}
+		if len(raider.SignUpHistory) != 1 || raider.SignUpHistory[0].LogCode != "fakeRaidMC01" {
+			t.Errorf("expected the sign-up history of Frostbolt to be kept, got %+v", raider.SignUpHistory)
+		}
+	}
+}
+
+func SetUpTestAttendancePolicy(t *testing.T, guildID string, policy attendancePolicy) {
+	t.Helper()
+	guildConfigMutex.Lock()
+	defer guildConfigMutex.Unlock()
+	currentGuildConfig := mapOfGuildConfigs[guildID]
+	importedPolicy := currentGuildConfig.AttendancePolicy
+	currentGuildConfig.AttendancePolicy = policy
+	mapOfGuildConfigs[guildID] = currentGuildConfig
+	t.Cleanup(func() {
+		guildConfigMutex.Lock()
+		defer guildConfigMutex.Unlock()
+		currentGuildConfig := mapOfGuildConfigs[guildID]
+		currentGuildConfig.AttendancePolicy = importedPolicy
+		mapOfGuildConfigs[guildID] = currentGuildConfig
+	})
+}
+
+func NewTestRaid(guildID string, code string, raidStart time.Time, playerNames ...string) logAllData {
+	raid := logAllData{
+		RaidTitle:           "Molten Core",
+		RaidNames:           []string{"Molten Core"},
+		RaidStartUnixTime:   raidStart.UnixMilli(),
+		RaidStartTimeString: raidStart.In(GetGuildLocation(guildID)).Format(timeLayout),
+		MetaData:            logsBase{Code: code},
+	}
+	for _, playerName := range playerNames {
+		raid.Players = append(raid.Players, logPlayer{Name: playerName, ClassName: "Priest"})
+	}
+	return raid
+}
+
+func TestGetRaidAttendanceStatus(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	location := GetGuildLocation(guildID)
+	raidStart := time.Date(2025, time.March, 12, 20, 0, 0, 0, location)
+	raidDate := raidStart.Format(timeLayOutShort)
+	raid := NewTestRaid(guildID, "raidA", raidStart, "Frostbolt", "Healbot-alt")
+	joinedGuild := raidStart.AddDate(0, 0, -7)
+	excusingPolicy := attendancePolicy{ExcuseAbsences: true, NoticeHours: 24}
+
+	for _, testCase := range []struct {
+		name        string
+		raider      raiderProfile
+		joinedGuild time.Time
+		policy      attendancePolicy
+		expected    string
+	}{
+		{
+			name:     "in the log",
+			raider:   raiderProfile{MainCharName: "Frostbolt"},
+			expected: attendanceAttended,
+		},
+		{
+			name:     "alt in the log",
+			raider:   raiderProfile{MainCharName: "Healbot", MainSwitch: map[string]bool{"Healbot-alt": true}},
+			expected: attendanceAttended,
+		},
+		{
+			name:     "override wins over the log",
+			raider:   raiderProfile{MainCharName: "Frostbolt", AttendanceOverrides: map[string]string{"raidA": attendanceUnexcused}},
+			expected: attendanceUnexcused,
+		},
+		{
+			name:        "override counts before the raider joined",
+			raider:      raiderProfile{MainCharName: "Shadowbolt", AttendanceOverrides: map[string]string{"raidA": attendanceExcused}},
+			joinedGuild: raidStart.AddDate(0, 0, 7),
+			expected:    attendanceExcused,
+		},
+		{
+			name:        "raid before the raider joined",
+			raider:      raiderProfile{MainCharName: "Shadowbolt"},
+			joinedGuild: raidStart.AddDate(0, 0, 7),
+			expected:    "",
+		},
+		{
+			name:     "benched wins over an absence",
+			raider:   raiderProfile{MainCharName: "Shadowbolt", BenchInfo: map[string][]bench{"week": {{DateString: raidDate}}}, Absences: []raiderAbsence{{RaidDate: raidDate, ReportedAt: raidStart.AddDate(0, 0, -3)}}},
+			policy:   excusingPolicy,
+			expected: attendanceBenched,
+		},
+		{
+			name:     "absence in time",
+			raider:   raiderProfile{MainCharName: "Shadowbolt", Absences: []raiderAbsence{{RaidDate: raidDate, ReportedAt: raidStart.Add(-24 * time.Hour)}}},
+			policy:   excusingPolicy,
+			expected: attendanceExcused,
+		},
+		{
+			name:     "absence after the notice deadline",
+			raider:   raiderProfile{MainCharName: "Shadowbolt", Absences: []raiderAbsence{{RaidDate: raidDate, ReportedAt: raidStart.Add(-23 * time.Hour)}}},
+			policy:   excusingPolicy,
+			expected: attendanceUnexcused,
+		},
+		{
+			name:     "absence without a policy that excuses",
+			raider:   raiderProfile{MainCharName: "Shadowbolt", Absences: []raiderAbsence{{RaidDate: raidDate, ReportedAt: raidStart.AddDate(0, 0, -3)}}},
+			expected: attendanceUnexcused,
+		},
+		{
+			name:     "absence for another raid",
+			raider:   raiderProfile{MainCharName: "Shadowbolt", Absences: []raiderAbsence{{RaidDate: raidStart.AddDate(0, 0, 1).Format(timeLayOutShort), ReportedAt: raidStart.AddDate(0, 0, -3)}}},
+			policy:   excusingPolicy,
+			expected: attendanceUnexcused,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.joinedGuild.IsZero() {
+				testCase.joinedGuild = joinedGuild
+			}
+			if status := GetRaidAttendanceStatus(guildID, testCase.raider, raid, testCase.joinedGuild, testCase.policy); status != testCase.expected {
+				t.Errorf("expected the status %q, got %q", testCase.expected, status)
+			}
+		})
+	}
+}
+
+// Healbot joins in raidB, is benched in raidC, reports an absence 72 hours before raidD and misses raidE without a word
+func TestCalculateAttendance(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	location := GetGuildLocation(guildID)
+	today := time.Now().In(location)
+	raidStart := func(daysAgo int) time.Time {
+		return time.Date(today.Year(), today.Month(), today.Day(), 20, 0, 0, 0, location).AddDate(0, 0, -daysAgo)
+	}
+	raids := []logAllData{
+		NewTestRaid(guildID, "raidA", raidStart(25), "Frostbolt"),
+		NewTestRaid(guildID, "raidB", raidStart(21), "Frostbolt", "Healbot"),
+		NewTestRaid(guildID, "raidC", raidStart(14), "Frostbolt"),
+		NewTestRaid(guildID, "raidD", raidStart(7), "Frostbolt"),
+		NewTestRaid(guildID, "raidE", raidStart(3), "Frostbolt"),
+	}
+	newRaider := func(overrides map[string]string) raiderProfile {
+		return raiderProfile{
+			MainCharName:        "Healbot",
+			BenchInfo:           map[string][]bench{"week": {{DateString: raidStart(14).Format(timeLayOutShort)}}},
+			Absences:            []raiderAbsence{{RaidDate: raidStart(7).Format(timeLayOutShort), ReportedAt: raidStart(7).Add(-72 * time.Hour)}},
+			AttendanceOverrides: overrides,
+		}
+	}
+
+	for _, testCase := range []struct {
+		name      string
+		policy    attendancePolicy
+		overrides map[string]string
+		expected  attendance
+	}{
+		{
+			name:     "zero policy counts the bench and the absence as missed",
+			policy:   attendancePolicy{},
+			expected: attendance{RaidCount: 1, Benched: 1, Unexcused: 2, RaidProcent: 25, LateNoticeProcent: 50},
+		},
+		{
+			name:     "full bench credit and absences in time excused",
+			policy:   attendancePolicy{BenchCredit: 1, ExcuseAbsences: true, NoticeHours: 24},
+			expected: attendance{RaidCount: 1, Benched: 1, Excused: 1, Unexcused: 1, RaidProcent: 66, LateNoticeProcent: 50},
+		},
+		{
+			name:     "absence after the notice deadline",
+			policy:   attendancePolicy{BenchCredit: 0.5, ExcuseAbsences: true, NoticeHours: 96},
+			expected: attendance{RaidCount: 1, Benched: 1, Unexcused: 2, RaidProcent: 37, LateNoticeProcent: 100},
+		},
+		{
+			name:      "override excuses the missed raid",
+			policy:    attendancePolicy{BenchCredit: 0.5, ExcuseAbsences: true, NoticeHours: 24},
+			overrides: map[string]string{"raidE": attendanceExcused},
+			expected:  attendance{RaidCount: 1, Benched: 1, Excused: 2, RaidProcent: 75},
+		},
+		{
+			name:      "override of a raid before the raider joined",
+			policy:    attendancePolicy{ExcuseAbsences: true, NoticeHours: 24},
+			overrides: map[string]string{"raidA": attendanceAttended, "raidC": attendanceAttended},
+			expected:  attendance{RaidCount: 3, Excused: 1, Unexcused: 1, RaidProcent: 75, LateNoticeProcent: 50},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			SetUpTestAttendancePolicy(t, guildID, testCase.policy)
+			raiders := CalculateAttendance(guildID, []raiderProfile{newRaider(testCase.overrides)}, raids)
+			if raiders[0].DateJoinedGuild != raids[1].RaidStartTimeString {
+				t.Errorf("expected the raider to join in the first raid the raider is seen in %s, got %s", raids[1].RaidStartTimeString, raiders[0].DateJoinedGuild)
+			}
+			oneMonth := raiders[0].AttendanceInfo["oneMonth"]
+			testCase.expected.MainRaid = true
+			if oneMonth.RaidCount != testCase.expected.RaidCount || oneMonth.Benched != testCase.expected.Benched || oneMonth.Excused != testCase.expected.Excused || oneMonth.Unexcused != testCase.expected.Unexcused ||
+				oneMonth.RaidProcent != testCase.expected.RaidProcent || oneMonth.LateNoticeProcent != testCase.expected.LateNoticeProcent || !oneMonth.MainRaid {
+				t.Errorf("expected the attendance of the last month to be %+v, got %+v", testCase.expected, oneMonth)
+			}
+			if len(oneMonth.RaidsMissed) != testCase.expected.Unexcused {
+				t.Errorf("expected a missed raid per unexcused raid, got %v", oneMonth.RaidsMissed)
+			}
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type benchCandidate struct {
+	Name              string
+	UserID            string
+	ClassName         string
+	Role              string
+	HasProfile        bool
+	RecentBenches     int
+	AttendanceProcent float64 //-1 when the raider has no attendance yet
+	Points            int     //0 when the performance of the raider has never been calculated
+	OnlyOfClass       bool    //The only raider of the class signed up for the role
+	Score             float64 //The higher the score, the sooner the raider is benched
+}
+
+const (
+	benchRoleTank   = "Tank"
+	benchRoleHealer = "Healer"
+	benchRoleDPS    = "DPS"
+
+	benchRotationWeeks      = 8 //Benches older than this do not count towards the rotation
+	benchRotationMaxBenches = 3 //Raiders benched this often in the window are benched last on the bench weight
+	benchRotationMaxPicks   = 25
+)
+
+var (
+	benchRoles = []string{benchRoleTank, benchRoleHealer, benchRoleDPS}
+
+	benchRotationDefault = benchRotation{
+		TankSlots:         4,
+		HealerSlots:       10,
+		DPSSlots:          26,
+		BenchWeight:       3,
+		AttendanceWeight:  2,
+		PerformanceWeight: 1,
+		CoverageWeight:    2,
+	}
+)
+
+// Guild configs written before the bench rotation existed have no slots or weights, those use the default
+func GetBenchRotation(guildID string) benchRotation {
+	rotation := GetGuildConfig(guildID).BenchRotation
+	if rotation.TankSlots == 0 && rotation.HealerSlots == 0 && rotation.DPSSlots == 0 {
+		rotation.TankSlots, rotation.HealerSlots, rotation.DPSSlots = benchRotationDefault.TankSlots, benchRotationDefault.HealerSlots, benchRotationDefault.DPSSlots
+	}
+	if rotation.BenchWeight == 0 && rotation.AttendanceWeight == 0 && rotation.PerformanceWeight == 0 && rotation.CoverageWeight == 0 {
+		rotation.BenchWeight, rotation.AttendanceWeight, rotation.PerformanceWeight, rotation.CoverageWeight = benchRotationDefault.BenchWeight, benchRotationDefault.AttendanceWeight, benchRotationDefault.PerformanceWeight, benchRotationDefault.CoverageWeight
+	}
+	return rotation
+}
+
+// The role picked in raid-helper wins, as raiders can sign up with another spec than their usual one
+func GetSignUpRole(signUp raidHelperSignUp, raider raiderProfile) string {
+	switch strings.ToLower(signUp.RoleName) {
+	case "tank", "tanks":
+		{
+			return benchRoleTank
+		}
+	case "healer", "healers":
+		{
+			return benchRoleHealer
+		}
+	case "dps", "melee", "ranged":
+		{
+			return benchRoleDPS
+		}
+	}
+	specName := strings.ToLower(signUp.SpecName)
+	for _, tankSpec := range []string{"protection", "guardian"} {
+		if strings.HasPrefix(specName, tankSpec) {
+			return benchRoleTank
+		}
+	}
+	for _, healerSpec := range []string{"holy", "restoration", "discipline"} {
+		if strings.HasPrefix(specName, healerSpec) {
+			return benchRoleHealer
+		}
+	}
+	if specName != "" {
+		return benchRoleDPS
+	}
+	switch strings.ToLower(raider.ClassInfo.ClassType) {
+	case "tank":
+		{
+			return benchRoleTank
+		}
+	case "healer":
+		{
+			return benchRoleHealer
+		}
+	}
+	return benchRoleDPS
+}
+
+// Counts the raid days the raider was benched on within the window, the same bench can be in more than 1 period of BenchInfo
+func CountRecentBenches(raider raiderProfile, now time.Time) int {
+	windowStart := now.AddDate(0, 0, -7*benchRotationWeeks)
+	mapOfBenchDates := make(map[string]bool)
+	for _, benches := range raider.BenchInfo {
+		for _, bench := range benches {
+			benchDate, err := ParseGuildTime(timeLayOutShort, bench.DateString)
+			if err == nil && benchDate.After(windowStart) && !benchDate.After(now) {
+				mapOfBenchDates[bench.DateString] = true
+			}
+		}
+	}
+	return len(mapOfBenchDates)
+}
+
+// Only the accepted sign-ups and the raiders already on the bench in raid-helper can be benched, absent and tentative sign-ups are left out
+func NewBenchCandidates(signUps []raidHelperSignUp, raiders []raiderProfile, now time.Time) []benchCandidate {
+	candidates := []benchCandidate{}
+	for _, signUp := range signUps {
+		signUpType := ClassifyRaidHelperSignUp(signUp.ClassName)
+		if signUpType != signUpAccepted && signUpType != signUpBench {
+			continue
+		}
+		raider := raiderProfile{}
+		hasProfile := false
+		for _, currentRaider := range raiders {
+			if (signUp.UserID != "" && currentRaider.ID == signUp.UserID) || strings.EqualFold(currentRaider.MainCharName, signUp.Name) {
+				raider = currentRaider
+				hasProfile = true
+				break
+			}
+		}
+		candidate := benchCandidate{
+			Name:              signUp.Name,
+			UserID:            signUp.UserID,
+			ClassName:         signUp.ClassName,
+			Role:              GetSignUpRole(signUp, raider),
+			HasProfile:        hasProfile,
+			AttendanceProcent: -1,
+		}
+		if signUpType == signUpBench || strings.EqualFold(signUp.ClassName, "late") {
+			candidate.ClassName = raider.ClassInfo.IngameClass
+		}
+		if hasProfile {
+			candidate.Name = raider.MainCharName
+			candidate.RecentBenches = CountRecentBenches(raider, now)
+			candidate.Points = raider.RaidData.Parses.Points //Stored by the function CalculateRaiderPerformance()
+			if attendanceInfo, ok := raider.AttendanceInfo["threeMonth"]; ok {
+				candidate.AttendanceProcent = attendanceInfo.RaidProcent
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// Scores the candidates of a single role, performance points are compared with the best of the role as the points are relative to the class of the raider
+func ScoreBenchCandidates(candidates []benchCandidate, rotation benchRotation) []benchCandidate {
+	maxPoints := 0
+	mapOfClassCount := make(map[string]int)
+	for _, candidate := range candidates {
+		maxPoints = max(maxPoints, candidate.Points)
+		mapOfClassCount[strings.ToLower(candidate.ClassName)]++
+	}
+	for x, candidate := range candidates {
+		benchScore := 1 - float64(min(candidate.RecentBenches, benchRotationMaxBenches))/benchRotationMaxBenches
+		attendanceScore := 0.5
+		if candidate.AttendanceProcent >= 0 {
+			attendanceScore = 1 - math.Min(candidate.AttendanceProcent, 100)/100
+		}
+		performanceScore := 0.5
+		if candidate.Points > 0 && maxPoints > 0 {
+			performanceScore = 1 - float64(candidate.Points)/float64(maxPoints)
+		}
+		candidates[x].OnlyOfClass = candidate.ClassName != "" && mapOfClassCount[strings.ToLower(candidate.ClassName)] == 1 && len(candidates) > 1
+		candidates[x].Score = benchScore*rotation.BenchWeight + attendanceScore*rotation.AttendanceWeight + performanceScore*rotation.PerformanceWeight
+		if candidates[x].OnlyOfClass {
+			candidates[x].Score -= rotation.CoverageWeight
+		}
+		if !candidate.HasProfile { //Players without a raider profile, e.g. pugs, give way to the raiders
+			candidates[x].Score += rotation.BenchWeight + rotation.AttendanceWeight + rotation.PerformanceWeight
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates
+}
+
+func ExplainBenchPick(candidate benchCandidate) string {
+	if !candidate.HasProfile {
+		return "No raider profile, pugs and unknown players are benched before raiders"
+	}
+	reasons := []string{fmt.Sprintf("Benched %d times in the last %d weeks", candidate.RecentBenches, benchRotationWeeks)}
+	if candidate.AttendanceProcent >= 0 {
+		reasons = append(reasons, fmt.Sprintf("%.0f%% attendance the last 3 months", candidate.AttendanceProcent))
+	} else {
+		reasons = append(reasons, "no attendance yet")
+	}
+	if candidate.Points > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d performance points", candidate.Points))
+	} else {
+		reasons = append(reasons, "no performance calculated")
+	}
+	if candidate.OnlyOfClass {
+		reasons = append(reasons, fmt.Sprintf("the only %s in the role", candidate.ClassName))
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// Returns the picks of every role signed up above its slots, and a line per role telling how full it is
+func RecommendBench(candidates []benchCandidate, rotation benchRotation) ([]benchPick, []string) {
+	mapOfSlots := map[string]int{
+		benchRoleTank:   rotation.TankSlots,
+		benchRoleHealer: rotation.HealerSlots,
+		benchRoleDPS:    rotation.DPSSlots,
+	}
+	picks := []benchPick{}
+	roleLines := []string{}
+	for _, role := range benchRoles {
+		roleCandidates := []benchCandidate{}
+		for _, candidate := range candidates {
+			if candidate.Role == role {
+				roleCandidates = append(roleCandidates, candidate)
+			}
+		}
+		countToBench := len(roleCandidates) - mapOfSlots[role]
+		if countToBench <= 0 {
+			roleLines = append(roleLines, fmt.Sprintf("**%s** %d signed up for %d slots, no one to bench", role, len(roleCandidates), mapOfSlots[role]))
+			continue
+		}
+		roleLines = append(roleLines, fmt.Sprintf("**%s** %d signed up for %d slots, %d to bench", role, len(roleCandidates), mapOfSlots[role], countToBench))
+		for _, candidate := range ScoreBenchCandidates(roleCandidates, rotation)[:countToBench] {
+			picks = append(picks, benchPick{
+				Name:   candidate.Name,
+				UserID: candidate.UserID,
+				Role:   role,
+				Score:  math.Round(candidate.Score*100) / 100,
+				Reason: ExplainBenchPick(candidate),
+			})
+		}
+	}
+	return picks, roleLines
+}
+
+// The next raid-helper event of the server when no message ID is given
+func GetBenchRecommendationEvent(guildID string, messageID string) (raidHelperEventDetails, error) {
+	if messageID == "" {
+		upcomingEvents := RetriveRaidHelperEvent(guildID, time.Now())
+		if upcomingEvents == nil {
+			return raidHelperEventDetails{}, fmt.Errorf("the raid-helper events could not be retrieved, please try again later")
+		}
+		nextStart := int64(0)
+		for ID, event := range upcomingEvents {
+			if nextStart == 0 || event.StartTime < nextStart {
+				messageID, nextStart = ID, event.StartTime
+			}
+		}
+		if messageID == "" {
+			return raidHelperEventDetails{}, fmt.Errorf("no upcoming raids are posted by raid-helper")
+		}
+	}
+	event, err := raidHelperCurrent.GetEvent(messageID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the raid-helper event %s, during the function GetBenchRecommendationEvent()", messageID), err.Error(), LogField("guildID", guildID))
+		return raidHelperEventDetails{}, fmt.Errorf("the raid-helper event %s could not be retrieved, please check the message ID", messageID)
+	}
+	return event, nil
+}
+
+func HandleBenchRecommend(request *slashCommandRequest) {
+	rotation := GetBenchRotation(request.Guild.ServerID)
+	for _, slots := range []struct {
+		option string
+		value  *int
+	}{{"tanks", &rotation.TankSlots}, {"healers", &rotation.HealerSlots}, {"dps", &rotation.DPSSlots}} {
+		if !request.HasOption(slots.option) {
+			continue
+		}
+		if request.IntOption(slots.option) < 0 {
+			RespondSlashCommandError(request, fmt.Sprintf("benchrecommend|The %s slots cannot be negative", slots.option))
+			return
+		}
+		*slots.value = int(request.IntOption(slots.option))
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Weighing the sign-ups...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /benchrecommend, during the function HandleBenchRecommend()", request.UserID), err.Error())
+		return
+	}
+	respondError := func(message string) {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("benchrecommend|%s", message))
+		_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s using slash command /benchrecommend, during the function HandleBenchRecommend()", request.UserID), err.Error())
+		}
+	}
+	event, err := GetBenchRecommendationEvent(request.Guild.ServerID, strings.TrimSpace(request.StringOption("event")))
+	if err != nil {
+		respondError(err.Error())
+		return
+	}
+	now := time.Now()
+	candidates := NewBenchCandidates(event.SignUps, GetRaiderProfiles(request.Guild.ServerID), now)
+	picks, roleLines := RecommendBench(candidates, rotation)
+	if len(picks) > benchRotationMaxPicks {
+		picks = picks[:benchRotationMaxPicks]
+	}
+
+	err = UpdateTrackedRaids(request.Guild.ServerID, func(trackedRaids map[string]trackRaid) error {
+		trackedRaid := trackedRaids[event.ID]
+		if trackedRaid.DiscordMessageID == "" { //The event has not been edited since it was posted, so it is not tracked yet
+			trackedRaid.DiscordMessageID = event.ID
+			trackedRaid.ChannelID = event.ChannelID
+			trackedRaid.RaidDiscordTitle = event.Title
+			trackedRaid.RaidStartUnixTime = event.StartTime
+		}
+		trackedRaid.BenchRecommendation = &benchRecommendation{
+			CreatedBy: request.UserID,
+			CreatedAt: now,
+			Picks:     picks,
+		}
+		trackedRaids[event.ID] = trackedRaid
+		return nil
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to save the bench recommendation for the raid %s, during the function HandleBenchRecommend()", event.Title), err.Error(), LogField("guildID", request.Guild.ServerID))
+		respondError("The recommendation could not be saved, please try again later")
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("User %s got %d bench recommendations for the raid %s, during the function HandleBenchRecommend()", request.UserID, len(picks), event.Title), "Bench recommendation", LogField("guildID", request.Guild.ServerID), LogField("messageID", event.ID))
+
+	pickLines := []string{}
+	selectOptions := []discordgo.SelectMenuOption{}
+	for _, pick := range picks {
+		pickLines = append(pickLines, fmt.Sprintf("**%s** (%s) - %s", pick.Name, pick.Role, pick.Reason))
+		selectOptions = append(selectOptions, discordgo.SelectMenuOption{
+			Label:   fmt.Sprintf("%s (%s)", pick.Name, pick.Role),
+			Value:   pick.Name,
+			Default: true,
+		})
+	}
+	description := strings.Join(roleLines, "\n")
+	if len(picks) == 0 {
+		description += "\n\nEveryone fits in the raid, no one needs to be benched"
+	} else {
+		description += "\n\n" + JoinWithinEmbedLimit(pickLines, "\n") + "\n\nPick the recommendations you accept below, they are recorded but raid-helper is not changed"
+	}
+	embeds := []*discordgo.MessageEmbed{
+		{
+			Title:       fmt.Sprintf("Bench recommendation for %s", event.Title),
+			Description: fmt.Sprintf("Starting %s\n\n%s", FormatDiscordTimestamp(time.Unix(event.StartTime, 0)), description),
+			Color:       blueColor,
+		},
+	}
+	components := []discordgo.MessageComponent{}
+	if len(selectOptions) > 0 {
+		minValues := 0
+		components = append(components, discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					MenuType:    discordgo.StringSelectMenu,
+					CustomID:    fmt.Sprintf("benchrecommend/%s", event.ID),
+					Placeholder: "Pick the recommendations you accept",
+					MinValues:   &minValues,
+					MaxValues:   len(selectOptions),
+					Options:     selectOptions,
+				},
+			},
+		})
+	}
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &embeds,
+		Components: &components,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the bench recommendation to user %s, during the function HandleBenchRecommend()", request.UserID), err.Error())
+	}
+}
+
+// Custom ID benchrecommend/<raid message ID>, the select is sent by the function HandleBenchRecommend()
+func HandleBenchRecommendSelect(request *slashCommandRequest) {
+	if len(request.CustomIDParts) < 2 {
+		RespondSlashCommandError(request, "benchrecommend|The recommendation is not valid anymore, please run /benchrecommend again")
+		return
+	}
+	messageID := request.CustomIDParts[1]
+	acceptedNames := request.Event.MessageComponentData().Values
+	errNoRecommendation := fmt.Errorf("no bench recommendation found for the raid %s", messageID)
+	trackedRaid := trackRaid{}
+	err := UpdateTrackedRaids(request.Guild.ServerID, func(trackedRaids map[string]trackRaid) error {
+		var ok bool
+		trackedRaid, ok = trackedRaids[messageID]
+		if !ok || trackedRaid.BenchRecommendation == nil {
+			return errNoRecommendation
+		}
+		recommendation := trackedRaid.BenchRecommendation
+		recommendation.Accepted = []string{}
+		recommendation.Rejected = []string{}
+		for _, pick := range recommendation.Picks {
+			if slices.Contains(acceptedNames, pick.Name) {
+				recommendation.Accepted = append(recommendation.Accepted, pick.Name)
+			} else {
+				recommendation.Rejected = append(recommendation.Rejected, pick.Name)
+			}
+		}
+		recommendation.ReviewedBy = request.UserID
+		recommendation.ReviewedAt = time.Now()
+		trackedRaids[messageID] = trackedRaid
+		return nil
+	})
+	if err == errNoRecommendation {
+		RespondSlashCommandError(request, "benchrecommend|The recommendation is not valid anymore, please run /benchrecommend again")
+		return
+	} else if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to save the reviewed bench recommendation for the raid %s, during the function HandleBenchRecommendSelect()", messageID), err.Error(), LogField("guildID", request.Guild.ServerID))
+		RespondSlashCommandError(request, "benchrecommend|The review could not be saved, please try again later")
+		return
+	}
+	recommendation := trackedRaid.BenchRecommendation
+	WriteInformationLog(fmt.Sprintf("User %s accepted %d of %d bench recommendations for the raid %s, during the function HandleBenchRecommendSelect()", request.UserID, len(recommendation.Accepted), len(recommendation.Picks), trackedRaid.RaidDiscordTitle), "Bench recommendation reviewed", LogField("guildID", request.Guild.ServerID), LogField("messageID", messageID))
+
+	message := fmt.Sprintf("Recorded %d of %d recommendations as accepted", len(recommendation.Accepted), len(recommendation.Picks))
+	if len(recommendation.Accepted) > 0 {
+		message += fmt.Sprintf(", remember to bench %s in raid-helper", strings.Join(recommendation.Accepted, ", "))
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("benchrecommend|%s", message))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to respond to user %s using the bench recommendation select, during the function HandleBenchRecommendSelect()", request.UserID), err.Error())
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func NewTestBenchCandidate(name string, className string, recentBenches int, attendanceProcent float64, points int) benchCandidate {
+	return benchCandidate{Name: name, ClassName: className, Role: benchRoleDPS, HasProfile: true, RecentBenches: recentBenches, AttendanceProcent: attendanceProcent, Points: points}
+}
+
+func TestScoreBenchCandidates(t *testing.T) {
+	pug := NewTestBenchCandidate("Pugwarrior", "Warrior", 0, -1, 0)
+	pug.HasProfile = false
+	for _, testCase := range []struct {
+		name          string
+		candidates    []benchCandidate
+		expected      []string //Benched first to benched last
+		onlyOfClasses []string
+	}{
+		{
+			name:       "fewer recent benches are benched first",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 2, 90, 100), NewTestBenchCandidate("Fireball", "Mage", 0, 90, 100)},
+			expected:   []string{"Fireball", "Frostbolt"},
+		},
+		{
+			name:       "lower attendance is benched first",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 1, 100, 100), NewTestBenchCandidate("Fireball", "Mage", 1, 50, 100)},
+			expected:   []string{"Fireball", "Frostbolt"},
+		},
+		{
+			name:       "less points compared to the best of the role are benched first",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 1, 90, 200), NewTestBenchCandidate("Fireball", "Mage", 1, 90, 100)},
+			expected:   []string{"Fireball", "Frostbolt"},
+		},
+		{
+			name:       "recent benches weigh more than attendance",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 3, 40, 100), NewTestBenchCandidate("Fireball", "Mage", 0, 100, 100)},
+			expected:   []string{"Fireball", "Frostbolt"},
+		},
+		{
+			name:       "attendance weighs more than points",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 1, 100, 50), NewTestBenchCandidate("Fireball", "Mage", 1, 60, 100)},
+			expected:   []string{"Fireball", "Frostbolt"},
+		},
+		{
+			name:       "no attendance and no points count as the middle",
+			candidates: []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 1, 20, 50), NewTestBenchCandidate("Fireball", "Mage", 1, -1, 0), NewTestBenchCandidate("Arcane", "Mage", 1, 100, 100)},
+			expected:   []string{"Frostbolt", "Fireball", "Arcane"},
+		},
+		{
+			name:          "the only raider of a class is benched last",
+			candidates:    []benchCandidate{NewTestBenchCandidate("Totemic", "Shaman", 0, 50, 50), NewTestBenchCandidate("Frostbolt", "Mage", 0, 50, 50), NewTestBenchCandidate("Fireball", "mage", 0, 50, 50)},
+			expected:      []string{"Fireball", "Frostbolt", "Totemic"},
+			onlyOfClasses: []string{"Totemic"},
+		},
+		{
+			name:       "a single candidate is not the only one of the class",
+			candidates: []benchCandidate{NewTestBenchCandidate("Totemic", "Shaman", 0, 50, 50)},
+			expected:   []string{"Totemic"},
+		},
+		{
+			name:          "pugs are benched before raiders",
+			candidates:    []benchCandidate{NewTestBenchCandidate("Frostbolt", "Mage", 0, 0, 1), NewTestBenchCandidate("Fireball", "Mage", 0, 0, 100), pug},
+			expected:      []string{"Pugwarrior", "Frostbolt", "Fireball"},
+			onlyOfClasses: []string{"Pugwarrior"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			names, onlyOfClasses := []string{}, []string{}
+			candidates := ScoreBenchCandidates(slices.Clone(testCase.candidates), benchRotationDefault)
+			for x, candidate := range candidates {
+				names = append(names, candidate.Name)
+				if candidate.OnlyOfClass {
+					onlyOfClasses = append(onlyOfClasses, candidate.Name)
+				}
+				if x > 0 && candidate.Score > candidates[x-1].Score {
+					t.Errorf("expected the candidates to be sorted by score, %s has %.2f after %.2f", candidate.Name, candidate.Score, candidates[x-1].Score)
+				}
+			}
+			if !slices.Equal(names, testCase.expected) {
+				t.Errorf("expected the order %v, got %v", testCase.expected, names)
+			}
+			if testCase.onlyOfClasses == nil {
+				testCase.onlyOfClasses = []string{}
+			}
+			if !slices.Equal(onlyOfClasses, testCase.onlyOfClasses) {
+				t.Errorf("expected %v to be the only of their class, got %v", testCase.onlyOfClasses, onlyOfClasses)
+			}
+		})
+	}
+}
+
+func TestRecommendBench(t *testing.T) {
+	newCandidate := func(name string, role string, className string, recentBenches int) benchCandidate {
+		candidate := NewTestBenchCandidate(name, className, recentBenches, 80, 100)
+		candidate.Role = role
+		return candidate
+	}
+	pug := newCandidate("Pugwarrior", benchRoleDPS, "Warrior", 0)
+	pug.HasProfile = false
+	candidates := []benchCandidate{
+		newCandidate("Shieldwall", benchRoleTank, "Warrior", 0),
+		newCandidate("Bearform", benchRoleTank, "Druid", 0),
+		newCandidate("Healbot", benchRoleHealer, "Priest", 2),
+		newCandidate("Smite", benchRoleHealer, "Priest", 0),
+		newCandidate("Flash", benchRoleHealer, "Priest", 1),
+		newCandidate("Frostbolt", benchRoleDPS, "Mage", 3),
+		newCandidate("Fireball", benchRoleDPS, "Mage", 0),
+		newCandidate("Backstab", benchRoleDPS, "Rogue", 0),
+		pug,
+	}
+	rotation := benchRotationDefault
+	rotation.TankSlots, rotation.HealerSlots, rotation.DPSSlots = 3, 2, 2
+
+	picks, roleLines := RecommendBench(candidates, rotation)
+
+	pickNames := []string{}
+	for _, pick := range picks {
+		pickNames = append(pickNames, pick.Role+"/"+pick.Name)
+		if pick.Reason == "" || pick.Score <= 0 {
+			t.Errorf("expected %s to have a score and a reason, got %+v", pick.Name, pick)
+		}
+	}
+	if expected := []string{"Healer/Smite", "DPS/Pugwarrior", "DPS/Fireball"}; !slices.Equal(pickNames, expected) {
+		t.Errorf("expected the picks %v, got %v", expected, pickNames)
+	}
+	if !strings.HasPrefix(picks[1].Reason, "No raider profile") {
+		t.Errorf("expected the pug to be explained as a pug, got %q", picks[1].Reason)
+	}
+	expectedLines := []string{
+		"**Tank** 2 signed up for 3 slots, no one to bench",
+		"**Healer** 3 signed up for 2 slots, 1 to bench",
+		"**DPS** 4 signed up for 2 slots, 2 to bench",
+	}
+	if !slices.Equal(roleLines, expectedLines) {
+		t.Errorf("expected the role lines %q, got %q", expectedLines, roleLines)
+	}
+}
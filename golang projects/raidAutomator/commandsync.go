@@ -0,0 +1,274 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type slashCommandChange struct {
+	Action      string //One of the slashCommandChange constants below
+	Name        string
+	CommandID   string   //The ID on Discord, empty when the command is created
+	Differences []string //Only set when the command is edited
+	Template    *discordgo.ApplicationCommand
+}
+
+const (
+	slashCommandCreate = "create"
+	slashCommandEdit   = "edit"
+	slashCommandDelete = "delete"
+
+	syncCommandsCommand = "synccommands" //go run . synccommands [-dry-run]
+
+	//Admin commands are hidden by Discord from members without this permission.
+	//Server admins can still grant them to other roles under Server Settings > Integrations
+	slashCommandAdminPermissions = int64(discordgo.PermissionManageMessages)
+)
+
+// Used by go run . synccommands, returns the exit code
+func RunSyncSlashCommands(session discordSession, args []string) int {
+	flags := flag.NewFlagSet(syncCommandsCommand, flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Prints the changes to the slash commands without sending them to Discord")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if err := SyncSlashCommands(session, NewSlashCommandRegistry(), *dryRun); err != nil {
+		fmt.Println("The slash commands could not be synced:", err)
+		return 1
+	}
+	return 0
+}
+
+// Compares the commands registered on every server with the registry and only sends what changed.
+// Unchanged commands keep their ID, so they do not disappear for users while the bot restarts
+func SyncSlashCommands(session discordSession, registry *slashCommandRegistry, dryRun bool) error {
+	botID := session.BotUserID()
+	templates := GetSlashCommandTemplates(registry)
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		existingCommands, err := session.ApplicationCommands(botID, guildID)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all application commands for bot %s on server %s, during the function SyncSlashCommands()", botID, guildID), err.Error())
+			failedGuilds = append(failedGuilds, guildID)
+			continue
+		}
+		changes := DiffSlashCommands(existingCommands, templates)
+		if dryRun {
+			fmt.Printf("Server %s: %d slash commands registered, %d changes\n", guildID, len(existingCommands), len(changes))
+			for _, change := range changes {
+				fmt.Println(" ", FormatSlashCommandChange(change))
+			}
+			continue
+		}
+		if len(changes) == 0 {
+			WriteInformationLog(fmt.Sprintf("The %d slash commands on server %s are up to date, during the function SyncSlashCommands()", len(templates), guildID), "Sync slash commands", LogField("guildID", guildID))
+			continue
+		}
+		for _, change := range changes {
+			WriteInformationLog(fmt.Sprintf("Slash command change on server %s: %s", guildID, FormatSlashCommandChange(change)), "Sync slash commands", LogField("guildID", guildID), LogField("command", change.Name), LogField("action", change.Action))
+		}
+		_, err = session.ApplicationCommandBulkOverwrite(botID, guildID, templates)
+		if err == nil {
+			WriteInformationLog(fmt.Sprintf("The slash commands on server %s have been overwritten with %d changes, during the function SyncSlashCommands()", guildID, len(changes)), "Sync slash commands", LogField("guildID", guildID))
+			continue
+		}
+		WriteWarningLog(fmt.Sprintf("The bulk overwrite of the slash commands on server %s failed, the %d changes will be sent one at a time, during the function SyncSlashCommands()", guildID, len(changes)), "Sync slash commands one at a time", LogField("guildID", guildID), LogField("error", err.Error()))
+		if err := ApplySlashCommandChanges(session, guildID, changes); err != nil {
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the slash commands could not be synced on the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+// Returns the first error, every change is still tried
+func ApplySlashCommandChanges(session discordSession, guildID string, changes []slashCommandChange) error {
+	botID := session.BotUserID()
+	var firstErr error
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case slashCommandCreate:
+			{
+				_, err = session.ApplicationCommandCreate(botID, guildID, change.Template)
+			}
+		case slashCommandEdit:
+			{
+				_, err = session.ApplicationCommandEdit(botID, guildID, change.CommandID, change.Template)
+			}
+		case slashCommandDelete:
+			{
+				err = session.ApplicationCommandDelete(botID, guildID, change.CommandID)
+			}
+		}
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to %s the slash command %s on server %s, during the function ApplySlashCommandChanges()", change.Action, change.Name, guildID), err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Copies of the templates in the registry, admin commands get the DefaultMemberPermissions so Discord hides them from non-officers
+func GetSlashCommandTemplates(registry *slashCommandRegistry) []*discordgo.ApplicationCommand {
+	templates := []*discordgo.ApplicationCommand{}
+	for _, command := range registry.Commands() {
+		template := *command.Template
+		template.Version = "" //Set by Discord on every change, so it cannot be compared with the templates
+		if template.Type == 0 {
+			template.Type = discordgo.ChatApplicationCommand
+		}
+		if command.RequiresPriviledge {
+			permissions := slashCommandAdminPermissions
+			template.DefaultMemberPermissions = &permissions
+		}
+		templates = append(templates, &template)
+	}
+	return templates
+}
+
+// Sorted by the name of the command, deletes first
+func DiffSlashCommands(existingCommands []*discordgo.ApplicationCommand, templates []*discordgo.ApplicationCommand) []slashCommandChange {
+	changes := []slashCommandChange{}
+	mapOfTemplates := make(map[string]*discordgo.ApplicationCommand)
+	for _, template := range templates {
+		mapOfTemplates[template.Name] = template
+	}
+	mapOfExistingCommands := make(map[string]*discordgo.ApplicationCommand)
+	for _, existingCommand := range existingCommands {
+		mapOfExistingCommands[existingCommand.Name] = existingCommand
+		template, ok := mapOfTemplates[existingCommand.Name]
+		if !ok {
+			changes = append(changes, slashCommandChange{Action: slashCommandDelete, Name: existingCommand.Name, CommandID: existingCommand.ID})
+			continue
+		}
+		if differences := CompareSlashCommands(existingCommand, template); len(differences) > 0 {
+			changes = append(changes, slashCommandChange{Action: slashCommandEdit, Name: template.Name, CommandID: existingCommand.ID, Differences: differences, Template: template})
+		}
+	}
+	for _, template := range templates {
+		if _, ok := mapOfExistingCommands[template.Name]; !ok {
+			changes = append(changes, slashCommandChange{Action: slashCommandCreate, Name: template.Name, Template: template})
+		}
+	}
+	actionOrder := map[string]int{slashCommandDelete: 0, slashCommandEdit: 1, slashCommandCreate: 2}
+	sort.SliceStable(changes, func(x, y int) bool {
+		if changes[x].Action != changes[y].Action {
+			return actionOrder[changes[x].Action] < actionOrder[changes[y].Action]
+		}
+		return changes[x].Name < changes[y].Name
+	})
+	return changes
+}
+
+// Only the fields the bot sets on its templates are compared, the IDs and the version are set by Discord
+func CompareSlashCommands(existingCommand *discordgo.ApplicationCommand, template *discordgo.ApplicationCommand) []string {
+	differences := []string{}
+	existingType := existingCommand.Type
+	if existingType == 0 {
+		existingType = discordgo.ChatApplicationCommand
+	}
+	templateType := template.Type
+	if templateType == 0 {
+		templateType = discordgo.ChatApplicationCommand
+	}
+	differences = AppendSlashCommandDifference(differences, "type", existingType, templateType)
+	differences = AppendSlashCommandDifference(differences, "description", existingCommand.Description, template.Description)
+	differences = AppendSlashCommandDifference(differences, "default member permissions", FormatInt64Pointer(existingCommand.DefaultMemberPermissions), FormatInt64Pointer(template.DefaultMemberPermissions))
+	differences = AppendSlashCommandDifference(differences, "nsfw", existingCommand.NSFW != nil && *existingCommand.NSFW, template.NSFW != nil && *template.NSFW)
+	return append(differences, CompareSlashCommandOptions("", existingCommand.Options, template.Options)...)
+}
+
+// Options are compared in order, as Discord shows them in the order they are given
+func CompareSlashCommandOptions(path string, existingOptions []*discordgo.ApplicationCommandOption, templateOptions []*discordgo.ApplicationCommandOption) []string {
+	differences := []string{}
+	for x := 0; x < len(existingOptions) || x < len(templateOptions); x++ {
+		if x >= len(templateOptions) {
+			differences = append(differences, fmt.Sprintf("option %s%s removed", path, existingOptions[x].Name))
+			continue
+		}
+		if x >= len(existingOptions) {
+			differences = append(differences, fmt.Sprintf("option %s%s added", path, templateOptions[x].Name))
+			continue
+		}
+		existingOption, templateOption := existingOptions[x], templateOptions[x]
+		if existingOption.Name != templateOption.Name {
+			differences = append(differences, fmt.Sprintf("option %d under %q renamed from %q to %q", x+1, strings.TrimSuffix(path, " "), existingOption.Name, templateOption.Name))
+			continue
+		}
+		optionPath := fmt.Sprintf("%s%s ", path, templateOption.Name)
+		fieldName := func(field string) string {
+			return fmt.Sprintf("option %s%s", optionPath, field)
+		}
+		differences = AppendSlashCommandDifference(differences, fieldName("type"), existingOption.Type, templateOption.Type)
+		differences = AppendSlashCommandDifference(differences, fieldName("description"), existingOption.Description, templateOption.Description)
+		differences = AppendSlashCommandDifference(differences, fieldName("required"), existingOption.Required, templateOption.Required)
+		differences = AppendSlashCommandDifference(differences, fieldName("autocomplete"), existingOption.Autocomplete, templateOption.Autocomplete)
+		differences = AppendSlashCommandDifference(differences, fieldName("choices"), FormatSlashCommandChoices(existingOption.Choices), FormatSlashCommandChoices(templateOption.Choices))
+		differences = AppendSlashCommandDifference(differences, fieldName("channel types"), fmt.Sprint(existingOption.ChannelTypes), fmt.Sprint(templateOption.ChannelTypes))
+		differences = AppendSlashCommandDifference(differences, fieldName("min value"), FormatFloat64Pointer(existingOption.MinValue), FormatFloat64Pointer(templateOption.MinValue))
+		differences = AppendSlashCommandDifference(differences, fieldName("max value"), existingOption.MaxValue, templateOption.MaxValue)
+		differences = AppendSlashCommandDifference(differences, fieldName("max length"), existingOption.MaxLength, templateOption.MaxLength)
+		differences = append(differences, CompareSlashCommandOptions(optionPath, existingOption.Options, templateOption.Options)...)
+	}
+	return differences
+}
+
+func AppendSlashCommandDifference[T comparable](differences []string, field string, existingValue T, templateValue T) []string {
+	if existingValue == templateValue {
+		return differences
+	}
+	return append(differences, fmt.Sprintf("%s changed from %v to %v", field, FormatSlashCommandValue(existingValue), FormatSlashCommandValue(templateValue)))
+}
+
+func FormatSlashCommandValue(value any) string {
+	if text, ok := value.(string); ok {
+		return fmt.Sprintf("%q", text)
+	}
+	return fmt.Sprint(value)
+}
+
+// Discord returns the values of the choices as JSON numbers or strings, so they are compared as text
+func FormatSlashCommandChoices(choices []*discordgo.ApplicationCommandOptionChoice) string {
+	formattedChoices := []string{}
+	for _, choice := range choices {
+		formattedChoices = append(formattedChoices, fmt.Sprintf("%s=%v", choice.Name, choice.Value))
+	}
+	return strings.Join(formattedChoices, ", ")
+}
+
+func FormatInt64Pointer(value *int64) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprint(*value)
+}
+
+func FormatFloat64Pointer(value *float64) string {
+	if value == nil {
+		return "none"
+	}
+	return fmt.Sprint(*value)
+}
+
+func FormatSlashCommandChange(change slashCommandChange) string {
+	switch change.Action {
+	case slashCommandCreate:
+		{
+			return fmt.Sprintf("+ create /%s", change.Name)
+		}
+	case slashCommandDelete:
+		{
+			return fmt.Sprintf("- delete /%s", change.Name)
+		}
+	}
+	return fmt.Sprintf("~ edit /%s: %s", change.Name, strings.Join(change.Differences, "; "))
+}
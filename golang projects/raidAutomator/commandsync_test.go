@@ -0,0 +1,162 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestDiffSlashCommands(t *testing.T) {
+	permissions := slashCommandAdminPermissions
+	hi := &discordgo.ApplicationCommand{Name: "hi", Description: "Say hi to the bot", Type: discordgo.ChatApplicationCommand}
+	joke := &discordgo.ApplicationCommand{Name: "joke", Description: "Hear a joke", Type: discordgo.ChatApplicationCommand}
+	admin := &discordgo.ApplicationCommand{Name: "reloadguildconfig", Description: "Reload the guild config", Type: discordgo.ChatApplicationCommand, DefaultMemberPermissions: &permissions}
+	withID := func(command *discordgo.ApplicationCommand, id string) *discordgo.ApplicationCommand {
+		existingCommand := *command
+		existingCommand.ID = id
+		existingCommand.Version = "1"
+		return &existingCommand
+	}
+	withoutPermissions := withID(admin, "3")
+	withoutPermissions.DefaultMemberPermissions = nil
+	editedHi := *hi
+	editedHi.Description = "Say hello to the bot"
+
+	for _, testCase := range []struct {
+		name             string
+		existingCommands []*discordgo.ApplicationCommand
+		templates        []*discordgo.ApplicationCommand
+		expected         []string //Formatted with the function FormatSlashCommandChange()
+	}{
+		{
+			name:             "no change",
+			existingCommands: []*discordgo.ApplicationCommand{withID(hi, "1"), withID(joke, "2"), withID(admin, "3")},
+			templates:        []*discordgo.ApplicationCommand{hi, joke, admin},
+			expected:         []string{},
+		},
+		{
+			name:             "create",
+			existingCommands: []*discordgo.ApplicationCommand{withID(hi, "1")},
+			templates:        []*discordgo.ApplicationCommand{joke, hi},
+			expected:         []string{"+ create /joke"},
+		},
+		{
+			name:             "delete",
+			existingCommands: []*discordgo.ApplicationCommand{withID(hi, "1"), withID(joke, "2")},
+			templates:        []*discordgo.ApplicationCommand{hi},
+			expected:         []string{"- delete /joke"},
+		},
+		{
+			name:             "edit",
+			existingCommands: []*discordgo.ApplicationCommand{withID(hi, "1")},
+			templates:        []*discordgo.ApplicationCommand{&editedHi},
+			expected:         []string{`~ edit /hi: description changed from "Say hi to the bot" to "Say hello to the bot"`},
+		},
+		{
+			name:             "admin command gets its permissions",
+			existingCommands: []*discordgo.ApplicationCommand{withoutPermissions},
+			templates:        []*discordgo.ApplicationCommand{admin},
+			expected:         []string{`~ edit /reloadguildconfig: default member permissions changed from "none" to "8192"`},
+		},
+		{
+			name:             "deletes before edits before creates",
+			existingCommands: []*discordgo.ApplicationCommand{withID(hi, "1"), withID(joke, "2")},
+			templates:        []*discordgo.ApplicationCommand{admin, &editedHi},
+			expected:         []string{"- delete /joke", `~ edit /hi: description changed from "Say hi to the bot" to "Say hello to the bot"`, "+ create /reloadguildconfig"},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			formattedChanges := []string{}
+			for _, change := range DiffSlashCommands(testCase.existingCommands, testCase.templates) {
+				formattedChanges = append(formattedChanges, FormatSlashCommandChange(change))
+				if change.Action != slashCommandCreate && change.CommandID == "" {
+					t.Errorf("expected the change %s to keep the ID of the existing command", change.Name)
+				}
+			}
+			if !slices.Equal(formattedChanges, testCase.expected) {
+				t.Errorf("expected the changes %q, got %q", testCase.expected, formattedChanges)
+			}
+		})
+	}
+}
+
+func TestCompareSlashCommands(t *testing.T) {
+	permissions := slashCommandAdminPermissions
+	template := &discordgo.ApplicationCommand{
+		Name:        "recentlogs",
+		Description: "See the latest log entries",
+		Options: []*discordgo.ApplicationCommandOption{
+			{Name: "level", Description: "Minimum level", Type: discordgo.ApplicationCommandOptionString, Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "Error", Value: "error"}}},
+			{Name: "count", Description: "Entries", Type: discordgo.ApplicationCommandOptionInteger},
+		},
+		DefaultMemberPermissions: &permissions,
+	}
+
+	for _, testCase := range []struct {
+		name     string
+		change   func(existingCommand *discordgo.ApplicationCommand)
+		expected []string
+	}{
+		{
+			name:     "no change",
+			change:   func(existingCommand *discordgo.ApplicationCommand) {},
+			expected: []string{},
+		},
+		{
+			name: "discord sets the type and the version",
+			change: func(existingCommand *discordgo.ApplicationCommand) {
+				existingCommand.Type = discordgo.ChatApplicationCommand
+				existingCommand.Version = "1234"
+			},
+			expected: []string{},
+		},
+		{
+			name: "default member permissions removed on discord",
+			change: func(existingCommand *discordgo.ApplicationCommand) {
+				existingCommand.DefaultMemberPermissions = nil
+			},
+			expected: []string{`default member permissions changed from "none" to "8192"`},
+		},
+		{
+			name: "option removed",
+			change: func(existingCommand *discordgo.ApplicationCommand) {
+				existingCommand.Options = existingCommand.Options[:1]
+			},
+			expected: []string{"option count added"},
+		},
+		{
+			name: "choice changed",
+			change: func(existingCommand *discordgo.ApplicationCommand) {
+				existingCommand.Options[0] = &discordgo.ApplicationCommandOption{Name: "level", Description: "Minimum level", Type: discordgo.ApplicationCommandOptionString, Choices: []*discordgo.ApplicationCommandOptionChoice{{Name: "Warning", Value: "warning"}}}
+			},
+			expected: []string{`option level choices changed from "Warning=warning" to "Error=error"`},
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			existingCommand := *template
+			existingCommand.Options = slices.Clone(template.Options)
+			testCase.change(&existingCommand)
+			differences := CompareSlashCommands(&existingCommand, template)
+			if !slices.Equal(differences, testCase.expected) {
+				t.Errorf("expected the differences %q, got %q", testCase.expected, differences)
+			}
+		})
+	}
+}
+
+// Every admin command of the registry must be hidden from members without the permission
+func TestGetSlashCommandTemplatesAdminPermissions(t *testing.T) {
+	registry := NewSlashCommandRegistry()
+	for _, template := range GetSlashCommandTemplates(registry) {
+		_, isAdminCommand := slashCommandAdminCenter[template.Name]
+		hasPermissions := template.DefaultMemberPermissions != nil && *template.DefaultMemberPermissions == slashCommandAdminPermissions
+		if isAdminCommand && registry.commands[template.Name].Command.RequiresPriviledge != hasPermissions {
+			t.Errorf("expected the default member permissions of /%s to follow RequiresPriviledge, got %s", template.Name, FormatInt64Pointer(template.DefaultMemberPermissions))
+		}
+		if strings.TrimSpace(template.Description) == "" {
+			t.Errorf("expected /%s to have a description", template.Name)
+		}
+	}
+}
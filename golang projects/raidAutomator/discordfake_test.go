@@ -0,0 +1,880 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// An in-memory discordSession for scenario tests. It keeps guilds, members, roles, channels and messages in memory,
+// records everything the bot sends or changes and dispatches injected gateway events to the registered handlers
+type fakeDiscordSession struct {
+	mutex      sync.Mutex
+	botUser    *discordgo.User
+	lastID     int64
+	handlers   []fakeDiscordHandler
+	events     []any //Waiting to be dispatched, see the function Dispatch()
+	inDispatch bool
+
+	channels            map[string]*discordgo.Channel
+	messages            map[string][]*discordgo.Message         //Channel ID to the messages, oldest first
+	members             map[string]map[string]*discordgo.Member //Guild ID to user ID to the member
+	roles               map[string][]*discordgo.Role
+	commands            map[string][]*discordgo.ApplicationCommand //Guild ID to the slash commands
+	interactionMessages map[string]*discordgo.Message              //Interaction ID to the message of its response
+
+	sentMessages    []fakeDiscordMessage
+	roleChanges     []fakeRoleChange
+	createdChannels []*discordgo.Channel
+	deletedChannels []string
+	reactions       []fakeReaction
+}
+
+type fakeDiscordHandler struct {
+	id      int64
+	handler any
+}
+
+// One message the bot sent, edited or deleted, in the order it happened
+type fakeDiscordMessage struct {
+	Action        string
+	ChannelID     string
+	InteractionID string //Only set for interaction responses and followups
+	Message       *discordgo.Message
+}
+
+type fakeRoleChange struct {
+	GuildID string
+	UserID  string
+	RoleID  string
+	Added   bool //False when the role was removed
+}
+
+type fakeReaction struct {
+	ChannelID string
+	MessageID string
+	Emoji     string
+}
+
+const (
+	fakeMessageSent             = "send"
+	fakeMessageEdited           = "edit"
+	fakeMessageDeleted          = "delete"
+	fakeInteractionResponded    = "interaction_response"
+	fakeInteractionEdited       = "interaction_edit"
+	fakeFollowupSent            = "followup"
+	fakeFollowupDeleted         = "followup_delete"
+	fakeDiscordFirstSnowflake   = int64(900000000000000000) //Far above real IDs, so fake and seeded IDs never collide
+	fakeDiscordMaxMessagesFetch = 100
+)
+
+var _ discordSession = (*fakeDiscordSession)(nil)
+
+func NewFakeDiscordSession(botUserID string) *fakeDiscordSession {
+	return &fakeDiscordSession{
+		botUser:             &discordgo.User{ID: botUserID, Username: botName, Bot: true},
+		channels:            make(map[string]*discordgo.Channel),
+		messages:            make(map[string][]*discordgo.Message),
+		members:             make(map[string]map[string]*discordgo.Member),
+		roles:               make(map[string][]*discordgo.Role),
+		commands:            make(map[string][]*discordgo.ApplicationCommand),
+		interactionMessages: make(map[string]*discordgo.Message),
+	}
+}
+
+// Seeds a channel, e.g. the bot channel of the guild config, without recording it as created by the bot
+func (session *fakeDiscordSession) AddChannel(channel *discordgo.Channel) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if channel.ID == "" {
+		channel.ID = session.NewID()
+	}
+	session.channels[channel.ID] = channel
+}
+
+func (session *fakeDiscordSession) AddGuildMember(guildID string, member *discordgo.Member) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.AddMember(guildID, member)
+}
+
+func (session *fakeDiscordSession) AddGuildRole(guildID string, role *discordgo.Role) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if role.ID == "" {
+		role.ID = session.NewID()
+	}
+	session.roles[guildID] = append(session.roles[guildID], role)
+}
+
+// Dispatches a slash command from the user and returns the interaction, so its response can be looked up afterwards
+func (session *fakeDiscordSession) InjectSlashCommand(guildID string, channelID string, userID string, commandName string, options ...*discordgo.ApplicationCommandInteractionDataOption) *discordgo.Interaction {
+	session.mutex.Lock()
+	member := session.members[guildID][userID]
+	session.mutex.Unlock()
+	if member == nil {
+		member = &discordgo.Member{GuildID: guildID, User: &discordgo.User{ID: userID}}
+	}
+	interaction := &discordgo.Interaction{
+		Type:      discordgo.InteractionApplicationCommand,
+		GuildID:   guildID,
+		ChannelID: channelID,
+		Member:    member,
+		Data: discordgo.ApplicationCommandInteractionData{
+			Name:        commandName,
+			CommandType: discordgo.ChatApplicationCommand,
+			Options:     options,
+		},
+	}
+	session.InjectInteractionCreate(interaction)
+	return interaction
+}
+
+func (session *fakeDiscordSession) InjectInteractionCreate(interaction *discordgo.Interaction) {
+	session.mutex.Lock()
+	if interaction.ID == "" {
+		interaction.ID = session.NewID()
+	}
+	if interaction.Token == "" {
+		interaction.Token = "fake-token-" + interaction.ID
+	}
+	if interaction.AppID == "" {
+		interaction.AppID = session.botUser.ID
+	}
+	session.mutex.Unlock()
+	session.Dispatch(&discordgo.InteractionCreate{Interaction: interaction})
+}
+
+// Stores the message in its channel as a user would have sent it, before the handlers are called
+func (session *fakeDiscordSession) InjectMessageCreate(message *discordgo.Message) {
+	session.mutex.Lock()
+	session.StoreMessage(message)
+	session.mutex.Unlock()
+	session.Dispatch(&discordgo.MessageCreate{Message: message})
+}
+
+// The stored message with the same ID is replaced and given to the handlers as BeforeUpdate
+func (session *fakeDiscordSession) InjectMessageUpdate(message *discordgo.Message) {
+	session.mutex.Lock()
+	var beforeUpdate *discordgo.Message
+	for x, storedMessage := range session.messages[message.ChannelID] {
+		if storedMessage.ID == message.ID {
+			beforeUpdate = storedMessage
+			session.messages[message.ChannelID][x] = message
+		}
+	}
+	if beforeUpdate == nil {
+		session.StoreMessage(message)
+	}
+	session.mutex.Unlock()
+	session.Dispatch(&discordgo.MessageUpdate{Message: message, BeforeUpdate: beforeUpdate})
+}
+
+// The member is added to the guild before the handlers are called, like Discord does
+func (session *fakeDiscordSession) InjectGuildMemberAdd(guildID string, member *discordgo.Member) {
+	session.mutex.Lock()
+	session.AddMember(guildID, member)
+	session.mutex.Unlock()
+	session.Dispatch(&discordgo.GuildMemberAdd{Member: member})
+}
+
+// Calls the handlers of the event and of every event raised while they run, e.g. the MessageCreate of a message the
+// bot sends, before returning. Events raised while another call is dispatching are left to that call
+func (session *fakeDiscordSession) Dispatch(event any) {
+	session.mutex.Lock()
+	session.events = append(session.events, event)
+	if session.inDispatch {
+		session.mutex.Unlock()
+		return
+	}
+	session.inDispatch = true
+	for len(session.events) > 0 {
+		nextEvent := session.events[0]
+		session.events = session.events[1:]
+		handlers := append([]fakeDiscordHandler{}, session.handlers...)
+		session.mutex.Unlock()
+		for _, handler := range handlers {
+			CallFakeDiscordHandler(session, handler.handler, nextEvent)
+		}
+		session.mutex.Lock()
+	}
+	session.inDispatch = false
+	session.mutex.Unlock()
+}
+
+func CallFakeDiscordHandler(session discordSession, handler any, event any) {
+	switch typedEvent := event.(type) {
+	case *discordgo.InteractionCreate:
+		{
+			if typedHandler, ok := handler.(interactionCreateHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	case *discordgo.MessageCreate:
+		{
+			if typedHandler, ok := handler.(messageCreateHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	case *discordgo.MessageUpdate:
+		{
+			if typedHandler, ok := handler.(messageUpdateHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	case *discordgo.MessageDelete:
+		{
+			if typedHandler, ok := handler.(messageDeleteHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	case *discordgo.MessageReactionAdd:
+		{
+			if typedHandler, ok := handler.(messageReactionAddHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	case *discordgo.GuildMemberAdd:
+		{
+			if typedHandler, ok := handler.(guildMemberAddHandler); ok {
+				typedHandler(session, typedEvent)
+			}
+		}
+	}
+}
+
+func (session *fakeDiscordSession) SentMessages() []fakeDiscordMessage {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]fakeDiscordMessage{}, session.sentMessages...)
+}
+
+// The messages currently in the channel, oldest first
+func (session *fakeDiscordSession) ChannelHistory(channelID string) []*discordgo.Message {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]*discordgo.Message{}, session.messages[channelID]...)
+}
+
+// The response to the interaction as it looks after every edit, nil when the bot never responded
+func (session *fakeDiscordSession) InteractionMessage(interactionID string) *discordgo.Message {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return session.interactionMessages[interactionID]
+}
+
+func (session *fakeDiscordSession) RoleChanges() []fakeRoleChange {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]fakeRoleChange{}, session.roleChanges...)
+}
+
+func (session *fakeDiscordSession) CreatedChannels() []*discordgo.Channel {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]*discordgo.Channel{}, session.createdChannels...)
+}
+
+func (session *fakeDiscordSession) DeletedChannels() []string {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]string{}, session.deletedChannels...)
+}
+
+func (session *fakeDiscordSession) Reactions() []fakeReaction {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]fakeReaction{}, session.reactions...)
+}
+
+func (session *fakeDiscordSession) AddHandler(handler any) func() {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.lastID++
+	id := session.lastID
+	session.handlers = append(session.handlers, fakeDiscordHandler{id: id, handler: handler})
+	return func() {
+		session.mutex.Lock()
+		defer session.mutex.Unlock()
+		for x, registered := range session.handlers {
+			if registered.id == id {
+				session.handlers = append(session.handlers[:x], session.handlers[x+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (session *fakeDiscordSession) BotUserID() string {
+	return session.botUser.ID
+}
+
+func (session *fakeDiscordSession) StateChannel(channelID string) (*discordgo.Channel, error) {
+	return session.Channel(channelID)
+}
+
+func (session *fakeDiscordSession) Close() error {
+	return nil
+}
+
+func (session *fakeDiscordSession) InteractionRespond(interaction *discordgo.Interaction, response *discordgo.InteractionResponse, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if _, found := session.interactionMessages[interaction.ID]; found {
+		return NewFakeDiscordError(400, "Interaction has already been acknowledged.", 40060)
+	}
+	message := &discordgo.Message{ID: session.NewID(), ChannelID: interaction.ChannelID, GuildID: interaction.GuildID, Author: session.botUser, Timestamp: time.Now()}
+	if response.Data != nil {
+		message.Content = response.Data.Content
+		message.Embeds = response.Data.Embeds
+		message.Components = response.Data.Components
+		message.Flags = response.Data.Flags
+	}
+	session.interactionMessages[interaction.ID] = message
+	session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeInteractionResponded, ChannelID: interaction.ChannelID, InteractionID: interaction.ID, Message: message})
+	return nil
+}
+
+func (session *fakeDiscordSession) InteractionResponseEdit(interaction *discordgo.Interaction, edit *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	message, found := session.interactionMessages[interaction.ID]
+	if !found {
+		return nil, NewFakeDiscordError(404, "Unknown Webhook", 10015)
+	}
+	edited := *message
+	if edit.Content != nil {
+		edited.Content = *edit.Content
+	}
+	if edit.Embeds != nil {
+		edited.Embeds = *edit.Embeds
+	}
+	if edit.Components != nil {
+		edited.Components = *edit.Components
+	}
+	session.interactionMessages[interaction.ID] = &edited
+	session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeInteractionEdited, ChannelID: interaction.ChannelID, InteractionID: interaction.ID, Message: &edited})
+	return &edited, nil
+}
+
+func (session *fakeDiscordSession) FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	message := &discordgo.Message{
+		ID:         session.NewID(),
+		ChannelID:  interaction.ChannelID,
+		GuildID:    interaction.GuildID,
+		Author:     session.botUser,
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+		Flags:      data.Flags,
+		Timestamp:  time.Now(),
+	}
+	session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeFollowupSent, ChannelID: interaction.ChannelID, InteractionID: interaction.ID, Message: message})
+	return message, nil
+}
+
+func (session *fakeDiscordSession) FollowupMessageDelete(interaction *discordgo.Interaction, messageID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeFollowupDeleted, ChannelID: interaction.ChannelID, InteractionID: interaction.ID, Message: &discordgo.Message{ID: messageID}})
+	return nil
+}
+
+func (session *fakeDiscordSession) Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	channel, found := session.channels[channelID]
+	if !found {
+		return nil, NewFakeDiscordError(404, "Unknown Channel", 10003)
+	}
+	return channel, nil
+}
+
+func (session *fakeDiscordSession) ChannelMessage(channelID string, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for _, message := range session.messages[channelID] {
+		if message.ID == messageID {
+			return message, nil
+		}
+	}
+	return nil, NewFakeDiscordError(404, "Unknown Message", 10008)
+}
+
+// Newest first like Discord, IDs of the fake are compared as numbers
+func (session *fakeDiscordSession) ChannelMessages(channelID string, limit int, beforeID string, afterID string, aroundID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if _, found := session.channels[channelID]; !found {
+		return nil, NewFakeDiscordError(404, "Unknown Channel", 10003)
+	}
+	if limit <= 0 || limit > fakeDiscordMaxMessagesFetch {
+		limit = fakeDiscordMaxMessagesFetch
+	}
+	messages := []*discordgo.Message{}
+	stored := session.messages[channelID]
+	for x := len(stored) - 1; x >= 0 && len(messages) < limit; x-- {
+		if beforeID != "" && CompareSnowflakes(stored[x].ID, beforeID) >= 0 {
+			continue
+		}
+		if afterID != "" && CompareSnowflakes(stored[x].ID, afterID) <= 0 {
+			continue
+		}
+		messages = append(messages, stored[x])
+	}
+	return messages, nil
+}
+
+func (session *fakeDiscordSession) ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Content: content})
+}
+
+func (session *fakeDiscordSession) ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}})
+}
+
+func (session *fakeDiscordSession) ChannelMessageSendEmbeds(channelID string, embeds []*discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	return session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{Embeds: embeds})
+}
+
+// Discord sends the bot a MessageCreate for its own messages as well, the onboarding flow depends on it
+func (session *fakeDiscordSession) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	session.mutex.Lock()
+	channel, found := session.channels[channelID]
+	if !found {
+		session.mutex.Unlock()
+		return nil, NewFakeDiscordError(404, "Unknown Channel", 10003)
+	}
+	message := &discordgo.Message{
+		ChannelID:  channelID,
+		GuildID:    channel.GuildID,
+		Author:     session.botUser,
+		Content:    data.Content,
+		Embeds:     data.Embeds,
+		Components: data.Components,
+		Flags:      data.Flags,
+	}
+	session.StoreMessage(message)
+	session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeMessageSent, ChannelID: channelID, Message: message})
+	session.mutex.Unlock()
+	session.Dispatch(&discordgo.MessageCreate{Message: message})
+	return message, nil
+}
+
+func (session *fakeDiscordSession) ChannelMessageEditComplex(edit *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for x, message := range session.messages[edit.Channel] {
+		if message.ID != edit.ID {
+			continue
+		}
+		edited := *message
+		if edit.Content != nil {
+			edited.Content = *edit.Content
+		}
+		if edit.Embeds != nil {
+			edited.Embeds = *edit.Embeds
+		}
+		if edit.Components != nil {
+			edited.Components = *edit.Components
+		}
+		now := time.Now()
+		edited.EditedTimestamp = &now
+		session.messages[edit.Channel][x] = &edited
+		session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeMessageEdited, ChannelID: edit.Channel, Message: &edited})
+		return &edited, nil
+	}
+	return nil, NewFakeDiscordError(404, "Unknown Message", 10008)
+}
+
+func (session *fakeDiscordSession) ChannelMessageDelete(channelID string, messageID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return session.DeleteMessage(channelID, messageID)
+}
+
+func (session *fakeDiscordSession) ChannelMessagesBulkDelete(channelID string, messageIDs []string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for _, messageID := range messageIDs {
+		if err := session.DeleteMessage(channelID, messageID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (session *fakeDiscordSession) ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	channel, found := session.channels[channelID]
+	if !found {
+		return nil, NewFakeDiscordError(404, "Unknown Channel", 10003)
+	}
+	delete(session.channels, channelID)
+	delete(session.messages, channelID)
+	session.deletedChannels = append(session.deletedChannels, channelID)
+	return channel, nil
+}
+
+func (session *fakeDiscordSession) MessageReactionAdd(channelID string, messageID string, emojiID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	session.reactions = append(session.reactions, fakeReaction{ChannelID: channelID, MessageID: messageID, Emoji: emojiID})
+	return nil
+}
+
+func (session *fakeDiscordSession) ThreadStart(channelID string, name string, channelType discordgo.ChannelType, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	return session.ThreadStartComplex(channelID, &discordgo.ThreadStart{Name: name, Type: channelType, AutoArchiveDuration: archiveDuration})
+}
+
+func (session *fakeDiscordSession) ThreadStartComplex(channelID string, data *discordgo.ThreadStart, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	parent, found := session.channels[channelID]
+	if !found {
+		return nil, NewFakeDiscordError(404, "Unknown Channel", 10003)
+	}
+	thread := &discordgo.Channel{
+		ID:       session.NewID(),
+		GuildID:  parent.GuildID,
+		ParentID: channelID,
+		Name:     data.Name,
+		Type:     data.Type,
+		ThreadMetadata: &discordgo.ThreadMetadata{
+			AutoArchiveDuration: data.AutoArchiveDuration,
+		},
+	}
+	session.channels[thread.ID] = thread
+	session.createdChannels = append(session.createdChannels, thread)
+	return thread, nil
+}
+
+// The same DM channel is returned for every call with the same user, like Discord does
+func (session *fakeDiscordSession) UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for _, channel := range session.channels {
+		if channel.Type == discordgo.ChannelTypeDM && len(channel.Recipients) > 0 && channel.Recipients[0].ID == recipientID {
+			return channel, nil
+		}
+	}
+	channel := &discordgo.Channel{
+		ID:         session.NewID(),
+		Type:       discordgo.ChannelTypeDM,
+		Recipients: []*discordgo.User{{ID: recipientID}},
+	}
+	session.channels[channel.ID] = channel
+	session.createdChannels = append(session.createdChannels, channel)
+	return channel, nil
+}
+
+func (session *fakeDiscordSession) GuildMember(guildID string, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	member, found := session.members[guildID][userID]
+	if !found {
+		return nil, NewFakeDiscordError(404, "Unknown Member", 10007)
+	}
+	return member, nil
+}
+
+// Sorted by user ID, which is the order Discord pages the members in
+func (session *fakeDiscordSession) GuildMembers(guildID string, after string, limit int, options ...discordgo.RequestOption) ([]*discordgo.Member, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	userIDs := []string{}
+	for userID := range session.members[guildID] {
+		if after == "" || CompareSnowflakes(userID, after) > 0 {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	sort.Slice(userIDs, func(x, y int) bool { return CompareSnowflakes(userIDs[x], userIDs[y]) < 0 })
+	if limit > 0 && len(userIDs) > limit {
+		userIDs = userIDs[:limit]
+	}
+	members := []*discordgo.Member{}
+	for _, userID := range userIDs {
+		members = append(members, session.members[guildID][userID])
+	}
+	return members, nil
+}
+
+func (session *fakeDiscordSession) GuildMemberRoleAdd(guildID string, userID string, roleID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	member, found := session.members[guildID][userID]
+	if !found {
+		return NewFakeDiscordError(404, "Unknown Member", 10007)
+	}
+	for _, memberRoleID := range member.Roles {
+		if memberRoleID == roleID {
+			return nil
+		}
+	}
+	member.Roles = append(member.Roles, roleID)
+	session.roleChanges = append(session.roleChanges, fakeRoleChange{GuildID: guildID, UserID: userID, RoleID: roleID, Added: true})
+	return nil
+}
+
+func (session *fakeDiscordSession) GuildMemberRoleRemove(guildID string, userID string, roleID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	member, found := session.members[guildID][userID]
+	if !found {
+		return NewFakeDiscordError(404, "Unknown Member", 10007)
+	}
+	for x, memberRoleID := range member.Roles {
+		if memberRoleID == roleID {
+			member.Roles = append(member.Roles[:x], member.Roles[x+1:]...)
+			session.roleChanges = append(session.roleChanges, fakeRoleChange{GuildID: guildID, UserID: userID, RoleID: roleID, Added: false})
+			return nil
+		}
+	}
+	return nil
+}
+
+func (session *fakeDiscordSession) GuildMemberNickname(guildID string, userID string, nickname string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	member, found := session.members[guildID][userID]
+	if !found {
+		return NewFakeDiscordError(404, "Unknown Member", 10007)
+	}
+	member.Nick = nickname
+	return nil
+}
+
+func (session *fakeDiscordSession) GuildMemberDelete(guildID string, userID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	if _, found := session.members[guildID][userID]; !found {
+		return NewFakeDiscordError(404, "Unknown Member", 10007)
+	}
+	delete(session.members[guildID], userID)
+	return nil
+}
+
+func (session *fakeDiscordSession) GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]*discordgo.Role{}, session.roles[guildID]...), nil
+}
+
+func (session *fakeDiscordSession) GuildRoleCreate(guildID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	role := &discordgo.Role{ID: session.NewID(), Name: data.Name}
+	if data.Color != nil {
+		role.Color = *data.Color
+	}
+	if data.Permissions != nil {
+		role.Permissions = *data.Permissions
+	}
+	session.roles[guildID] = append(session.roles[guildID], role)
+	return role, nil
+}
+
+func (session *fakeDiscordSession) GuildRoleDelete(guildID string, roleID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for x, role := range session.roles[guildID] {
+		if role.ID == roleID {
+			session.roles[guildID] = append(session.roles[guildID][:x], session.roles[guildID][x+1:]...)
+			for _, member := range session.members[guildID] {
+				for y, memberRoleID := range member.Roles {
+					if memberRoleID == roleID {
+						member.Roles = append(member.Roles[:y], member.Roles[y+1:]...)
+						break
+					}
+				}
+			}
+			return nil
+		}
+	}
+	return NewFakeDiscordError(404, "Unknown Role", 10011)
+}
+
+// Threads are left out like Discord does, see the function GuildThreadsActive()
+func (session *fakeDiscordSession) GuildChannels(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	channels := []*discordgo.Channel{}
+	for _, channel := range session.channels {
+		if channel.GuildID == guildID && !channel.IsThread() {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Slice(channels, func(x, y int) bool { return CompareSnowflakes(channels[x].ID, channels[y].ID) < 0 })
+	return channels, nil
+}
+
+func (session *fakeDiscordSession) GuildChannelCreateComplex(guildID string, data discordgo.GuildChannelCreateData, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	channel := &discordgo.Channel{
+		ID:                   session.NewID(),
+		GuildID:              guildID,
+		Name:                 data.Name,
+		Type:                 data.Type,
+		Topic:                data.Topic,
+		ParentID:             data.ParentID,
+		Position:             data.Position,
+		NSFW:                 data.NSFW,
+		PermissionOverwrites: data.PermissionOverwrites,
+	}
+	session.channels[channel.ID] = channel
+	session.createdChannels = append(session.createdChannels, channel)
+	return channel, nil
+}
+
+func (session *fakeDiscordSession) GuildThreadsActive(guildID string, options ...discordgo.RequestOption) (*discordgo.ThreadsList, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	threads := &discordgo.ThreadsList{}
+	for _, channel := range session.channels {
+		if channel.GuildID == guildID && channel.IsThread() && (channel.ThreadMetadata == nil || !channel.ThreadMetadata.Archived) {
+			threads.Threads = append(threads.Threads, channel)
+		}
+	}
+	return threads, nil
+}
+
+func (session *fakeDiscordSession) ApplicationCommands(appID string, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	return append([]*discordgo.ApplicationCommand{}, session.commands[guildID]...), nil
+}
+
+// A command with the same name is replaced, like Discord does
+func (session *fakeDiscordSession) ApplicationCommandCreate(appID string, guildID string, command *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	created := *command
+	created.ApplicationID = appID
+	created.GuildID = guildID
+	for x, existing := range session.commands[guildID] {
+		if existing.Name == command.Name {
+			created.ID = existing.ID
+			session.commands[guildID][x] = &created
+			return &created, nil
+		}
+	}
+	created.ID = session.NewID()
+	session.commands[guildID] = append(session.commands[guildID], &created)
+	return &created, nil
+}
+
+func (session *fakeDiscordSession) ApplicationCommandEdit(appID string, guildID string, commandID string, command *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for x, existing := range session.commands[guildID] {
+		if existing.ID == commandID {
+			edited := *command
+			edited.ID, edited.ApplicationID, edited.GuildID = commandID, appID, guildID
+			session.commands[guildID][x] = &edited
+			return &edited, nil
+		}
+	}
+	return nil, NewFakeDiscordError(404, "Unknown application command", 10063)
+}
+
+func (session *fakeDiscordSession) ApplicationCommandDelete(appID string, guildID string, commandID string, options ...discordgo.RequestOption) error {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	for x, existing := range session.commands[guildID] {
+		if existing.ID == commandID {
+			session.commands[guildID] = append(session.commands[guildID][:x], session.commands[guildID][x+1:]...)
+			return nil
+		}
+	}
+	return NewFakeDiscordError(404, "Unknown application command", 10063)
+}
+
+// Commands with the same name as a registered one keep its ID, like Discord does
+func (session *fakeDiscordSession) ApplicationCommandBulkOverwrite(appID string, guildID string, commands []*discordgo.ApplicationCommand, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error) {
+	session.mutex.Lock()
+	defer session.mutex.Unlock()
+	existingIDs := make(map[string]string)
+	for _, existing := range session.commands[guildID] {
+		existingIDs[existing.Name] = existing.ID
+	}
+	overwritten := []*discordgo.ApplicationCommand{}
+	for _, command := range commands {
+		created := *command
+		created.ApplicationID, created.GuildID = appID, guildID
+		if created.ID = existingIDs[command.Name]; created.ID == "" {
+			created.ID = session.NewID()
+		}
+		overwritten = append(overwritten, &created)
+	}
+	session.commands[guildID] = overwritten
+	return append([]*discordgo.ApplicationCommand{}, overwritten...), nil
+}
+
+// The functions below expect the mutex to be held by the caller
+
+func (session *fakeDiscordSession) NewID() string {
+	session.lastID++
+	return strconv.FormatInt(fakeDiscordFirstSnowflake+session.lastID, 10)
+}
+
+func (session *fakeDiscordSession) StoreMessage(message *discordgo.Message) {
+	if message.ID == "" {
+		message.ID = session.NewID()
+	}
+	if message.Timestamp.IsZero() {
+		message.Timestamp = time.Now()
+	}
+	if channel, found := session.channels[message.ChannelID]; found && message.GuildID == "" {
+		message.GuildID = channel.GuildID
+	}
+	session.messages[message.ChannelID] = append(session.messages[message.ChannelID], message)
+}
+
+func (session *fakeDiscordSession) DeleteMessage(channelID string, messageID string) error {
+	for x, message := range session.messages[channelID] {
+		if message.ID == messageID {
+			session.messages[channelID] = append(session.messages[channelID][:x], session.messages[channelID][x+1:]...)
+			session.sentMessages = append(session.sentMessages, fakeDiscordMessage{Action: fakeMessageDeleted, ChannelID: channelID, Message: message})
+			return nil
+		}
+	}
+	return NewFakeDiscordError(404, "Unknown Message", 10008)
+}
+
+func (session *fakeDiscordSession) AddMember(guildID string, member *discordgo.Member) {
+	if session.members[guildID] == nil {
+		session.members[guildID] = make(map[string]*discordgo.Member)
+	}
+	member.GuildID = guildID
+	if member.JoinedAt.IsZero() {
+		member.JoinedAt = time.Now()
+	}
+	session.members[guildID][member.User.ID] = member
+}
+
+// Snowflakes are numbers, so a shorter ID is always the smaller one
+func CompareSnowflakes(first string, second string) int {
+	if len(first) != len(second) {
+		return len(first) - len(second)
+	}
+	if first < second {
+		return -1
+	} else if first > second {
+		return 1
+	}
+	return 0
+}
+
+// Formatted like the errors of discordgo, so code matching on the text behaves the same against the fake
+func NewFakeDiscordError(statusCode int, message string, code int) error {
+	return fmt.Errorf("HTTP %d %s, {\"message\": \"%s\", \"code\": %d}", statusCode, http.StatusText(statusCode), message, code)
+}
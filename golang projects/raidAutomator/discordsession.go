@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// The methods of *discordgo.Session the bot uses. Handlers are given this interface instead of *discordgo.Session,
+// so every feature can run against the fakeDiscordSession as well as against Discord
+type discordSession interface {
+	AddHandler(handler any) func() //Takes one of the handler types below, e.g. func(discordSession, *discordgo.MessageCreate)
+	BotUserID() string
+	StateChannel(channelID string) (*discordgo.Channel, error) //From the gateway cache, without a request to Discord
+	Close() error
+
+	InteractionRespond(interaction *discordgo.Interaction, response *discordgo.InteractionResponse, options ...discordgo.RequestOption) error
+	InteractionResponseEdit(interaction *discordgo.Interaction, edit *discordgo.WebhookEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageCreate(interaction *discordgo.Interaction, wait bool, data *discordgo.WebhookParams, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	FollowupMessageDelete(interaction *discordgo.Interaction, messageID string, options ...discordgo.RequestOption) error
+
+	Channel(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelMessage(channelID string, messageID string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessages(channelID string, limit int, beforeID string, afterID string, aroundID string, options ...discordgo.RequestOption) ([]*discordgo.Message, error)
+	ChannelMessageSend(channelID string, content string, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendEmbed(channelID string, embed *discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendEmbeds(channelID string, embeds []*discordgo.MessageEmbed, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageEditComplex(edit *discordgo.MessageEdit, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	ChannelMessageDelete(channelID string, messageID string, options ...discordgo.RequestOption) error
+	ChannelMessagesBulkDelete(channelID string, messageIDs []string, options ...discordgo.RequestOption) error
+	ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	MessageReactionAdd(channelID string, messageID string, emojiID string, options ...discordgo.RequestOption) error
+	ThreadStart(channelID string, name string, channelType discordgo.ChannelType, archiveDuration int, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ThreadStartComplex(channelID string, data *discordgo.ThreadStart, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+
+	GuildMember(guildID string, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+	GuildMembers(guildID string, after string, limit int, options ...discordgo.RequestOption) ([]*discordgo.Member, error)
+	GuildMemberRoleAdd(guildID string, userID string, roleID string, options ...discordgo.RequestOption) error
+	GuildMemberRoleRemove(guildID string, userID string, roleID string, options ...discordgo.RequestOption) error
+	GuildMemberNickname(guildID string, userID string, nickname string, options ...discordgo.RequestOption) error
+	GuildMemberDelete(guildID string, userID string, options ...discordgo.RequestOption) error
+	GuildRoles(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Role, error)
+	GuildRoleCreate(guildID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error)
+	GuildRoleDelete(guildID string, roleID string, options ...discordgo.RequestOption) error
+	GuildChannels(guildID string, options ...discordgo.RequestOption) ([]*discordgo.Channel, error)
+	GuildChannelCreateComplex(guildID string, data discordgo.GuildChannelCreateData, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	GuildThreadsActive(guildID string, options ...discordgo.RequestOption) (*discordgo.ThreadsList, error)
+
+	ApplicationCommands(appID string, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandCreate(appID string, guildID string, command *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+	ApplicationCommandEdit(appID string, guildID string, commandID string, command *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+	ApplicationCommandDelete(appID string, guildID string, commandID string, options ...discordgo.RequestOption) error
+	ApplicationCommandBulkOverwrite(appID string, guildID string, commands []*discordgo.ApplicationCommand, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+}
+
+// The handler types accepted by the function AddHandler() of a discordSession
+type (
+	interactionCreateHandler  = func(discordSession, *discordgo.InteractionCreate)
+	messageCreateHandler      = func(discordSession, *discordgo.MessageCreate)
+	messageUpdateHandler      = func(discordSession, *discordgo.MessageUpdate)
+	messageDeleteHandler      = func(discordSession, *discordgo.MessageDelete)
+	messageReactionAddHandler = func(discordSession, *discordgo.MessageReactionAdd)
+	guildMemberAddHandler     = func(discordSession, *discordgo.GuildMemberAdd)
+)
+
+// Every method other than the ones below is promoted from the embedded *discordgo.Session
+type discordgoSession struct {
+	*discordgo.Session
+}
+
+func NewDiscordgoSession(session *discordgo.Session) *discordgoSession {
+	return &discordgoSession{Session: session}
+}
+
+// Wraps the handler so it is given the discordSession instead of the *discordgo.Session it is called with
+func (session *discordgoSession) AddHandler(handler any) func() {
+	switch typedHandler := handler.(type) {
+	case interactionCreateHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.InteractionCreate) { typedHandler(session, event) })
+		}
+	case messageCreateHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.MessageCreate) { typedHandler(session, event) })
+		}
+	case messageUpdateHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.MessageUpdate) { typedHandler(session, event) })
+		}
+	case messageDeleteHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.MessageDelete) { typedHandler(session, event) })
+		}
+	case messageReactionAddHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.MessageReactionAdd) { typedHandler(session, event) })
+		}
+	case guildMemberAddHandler:
+		{
+			return session.Session.AddHandler(func(_ *discordgo.Session, event *discordgo.GuildMemberAdd) { typedHandler(session, event) })
+		}
+	}
+	WriteErrorLog(fmt.Sprintf("The handler of type %T is not supported by the discord session and will never be called, during the function AddHandler()", handler), "Unsupported discord handler")
+	return func() {}
+}
+
+func (session *discordgoSession) BotUserID() string {
+	if session.State == nil || session.State.User == nil {
+		return ""
+	}
+	return session.State.User.ID
+}
+
+func (session *discordgoSession) StateChannel(channelID string) (*discordgo.Channel, error) {
+	return session.State.Channel(channelID)
+}
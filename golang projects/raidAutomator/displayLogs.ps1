@@ -25,7 +25,8 @@ while($true){
     $fileSizeAfter = (Get-Content -Path $logToRead).Length
     if ($fileSizeAfter -gt $fileSizeNow) {
         $entriesInCurrentScope = @()
-        $logContent = Get-Content -Path $logToRead -Raw | ConvertFrom-Json -Depth 50
+        # The logs are written as JSON lines, one entry per line
+        $logContent = @(Get-Content -Path $logToRead | ? {$_.Trim()} | % {$_ | ConvertFrom-Json -Depth 50})
         for($x = $logContent.Length -1; $x -ge 0; $x--){
             if([DateTime]::ParseExact(($logContent[$x].time_stamp), $format, [System.Globalization.CultureInfo]::InvariantCulture) -gt $dateNowBefore){
                 $entriesInCurrentScope += $logContent[$x]
@@ -46,7 +47,9 @@ while($true){
         ────────────────────────────────────────
         $type
         $details
+        Level     : $($log.level)
         Message   : $($log.message)
+        Fields    : $(($log.fields.PSObject.Properties | % {"$($_.Name)=$($_.Value)"}) -join ", ")
         Timestamp : $($log.time_stamp)
         ────────────────────────────────────────
 "@
@@ -55,5 +58,4 @@ while($true){
 }
     }
        
-       #$logContent = Get-Content -Path $logToRead | ConvertFrom-Json -Depth 50
 }
\ No newline at end of file
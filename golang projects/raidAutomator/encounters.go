@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type bossSummary struct {
+	BossName  string
+	Kills     int
+	Wipes     int
+	KillTimes []time.Duration //Oldest raid first
+}
+
+const (
+	bossSummaryKillTimes = 5 //Kill times shown per boss in the trend of /raidsummary boss
+)
+
+// The skipped bosses of the raid catalog are left out of the encounters, the live feed and /raidsummary boss
+func IsBossSkipped(bossName string) bool {
+	for _, raid := range GetRaidCatalog() {
+		if slices.ContainsFunc(raid.SkippedBosses, func(skippedBoss string) bool {
+			return strings.EqualFold(strings.TrimSpace(skippedBoss), bossName)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// Builds one record per boss from the fights of the report, the damage, healing and deaths per player are read from the encounterTables of the report, see the function GetReport()
+func UnwrapWarcraftLogEncounters(report map[string]any, players []logPlayer) []logEncounter {
+	encounters := []logEncounter{}
+	mapOfEncounterIndexes := make(map[int64]int) //Encounter ID to the index in encounters
+	mapOfSkippedEncounters := make(map[int64]bool)
+	sliceOfFights, _ := report["fights"].([]any)
+	for _, sliceOfFight := range sliceOfFights {
+		mapOfFight, ok := sliceOfFight.(map[string]any)
+		if !ok {
+			continue
+		}
+		encounterID, _ := mapOfFight["encounterID"].(float64)
+		if encounterID == 0 || mapOfSkippedEncounters[int64(encounterID)] { //Dont need to see trash
+			continue
+		}
+		index, ok := mapOfEncounterIndexes[int64(encounterID)]
+		if !ok {
+			bossName := GetEncounterName(int64(encounterID))
+			if IsBossSkipped(bossName) {
+				mapOfSkippedEncounters[int64(encounterID)] = true
+				continue
+			}
+			encounters = append(encounters, logEncounter{
+				EncounterID: int64(encounterID),
+				BossName:    bossName,
+			})
+			index = len(encounters) - 1
+			mapOfEncounterIndexes[int64(encounterID)] = index
+		}
+		startTime, _ := mapOfFight["startTime"].(float64)
+		endTime, _ := mapOfFight["endTime"].(float64)
+		duration := time.Duration(endTime-startTime) * time.Millisecond
+		if kill, _ := mapOfFight["kill"].(bool); kill {
+			encounters[index].Kill = true
+			encounters[index].Duration = duration
+		} else {
+			encounters[index].Wipes++
+			if !encounters[index].Kill {
+				encounters[index].Duration = duration
+			}
+		}
+	}
+
+	mapOfPlayerNames := make(map[int]string) //Internal log ID to the name of the player, pets are left out
+	for _, player := range players {
+		mapOfPlayerNames[player.InternalLogID] = player.Name
+	}
+	mapOfStats := make([]map[string]*logEncounterPlayer, len(encounters))
+	for x := range mapOfStats {
+		mapOfStats[x] = make(map[string]*logEncounterPlayer)
+	}
+	mapOfEncounterTables, _ := report["encounterTables"].(map[string]any)
+	for encounterID, index := range mapOfEncounterIndexes {
+		mapOfTables, _ := mapOfEncounterTables[strconv.FormatInt(encounterID, 10)].(map[string]any)
+		for _, tableSource := range []struct {
+			name string
+			add  func(stats *logEncounterPlayer, total int64)
+		}{
+			{"damageDone", func(stats *logEncounterPlayer, total int64) { stats.DamageDone += total }},
+			{"healingDone", func(stats *logEncounterPlayer, total int64) { stats.HealingDone += total }},
+			{"deaths", func(stats *logEncounterPlayer, total int64) { stats.Deaths++ }}, //One entry per death
+		} {
+			mapOfTable, _ := mapOfTables[tableSource.name].(map[string]any)
+			mapOfData, _ := mapOfTable["data"].(map[string]any)
+			sliceOfEntries, _ := mapOfData["entries"].([]any)
+			for _, sliceOfEntry := range sliceOfEntries {
+				mapOfEntry, ok := sliceOfEntry.(map[string]any)
+				if !ok {
+					continue
+				}
+				actorID, _ := mapOfEntry["id"].(float64)
+				playerName, ok := mapOfPlayerNames[int(actorID)]
+				if !ok {
+					continue
+				}
+				if mapOfStats[index][playerName] == nil {
+					mapOfStats[index][playerName] = &logEncounterPlayer{Name: playerName}
+				}
+				total, _ := mapOfEntry["total"].(float64)
+				tableSource.add(mapOfStats[index][playerName], int64(total))
+			}
+		}
+	}
+	for x := range encounters {
+		for _, player := range players { //Keeps the order of the players in the raid
+			if stats, ok := mapOfStats[x][player.Name]; ok {
+				encounters[x].Players = append(encounters[x].Players, *stats)
+			}
+		}
+	}
+	return encounters
+}
+
+// Merges the encounters of the raids per boss, returns the bosses in the order they were first seen and how many raids were cached before encounters were stored
+func NewBossSummaries(raids []logAllData) ([]bossSummary, int) {
+	raids = append([]logAllData{}, raids...)
+	sort.SliceStable(raids, func(i, j int) bool {
+		return raids[i].RaidStartUnixTime < raids[j].RaidStartUnixTime
+	})
+	summaries := []bossSummary{}
+	mapOfSummaryIndexes := make(map[string]int)
+	countOfRaidsWithoutEncounters := 0
+	for _, raid := range raids {
+		if raid.Encounters == nil {
+			countOfRaidsWithoutEncounters++
+			continue
+		}
+		for _, encounter := range raid.Encounters {
+			if IsBossSkipped(encounter.BossName) { //The raid might be cached before the boss was skipped in the raid catalog
+				continue
+			}
+			index, ok := mapOfSummaryIndexes[encounter.BossName]
+			if !ok {
+				summaries = append(summaries, bossSummary{BossName: encounter.BossName})
+				index = len(summaries) - 1
+				mapOfSummaryIndexes[encounter.BossName] = index
+			}
+			summaries[index].Wipes += encounter.Wipes
+			if encounter.Kill {
+				summaries[index].Kills++
+				summaries[index].KillTimes = append(summaries[index].KillTimes, encounter.Duration)
+			}
+		}
+	}
+	return summaries, countOfRaidsWithoutEncounters
+}
+
+func FormatBossSummary(summary bossSummary) string {
+	message := fmt.Sprintf("**%s** - %d kills and %d wipes", summary.BossName, summary.Kills, summary.Wipes)
+	if len(summary.KillTimes) == 0 {
+		return message
+	}
+	fastest := summary.KillTimes[0]
+	var total time.Duration
+	for _, killTime := range summary.KillTimes {
+		total += killTime
+		fastest = min(fastest, killTime)
+	}
+	message += fmt.Sprintf("\nFastest kill %s, average kill %s", FormatKillTime(fastest), FormatKillTime(total/time.Duration(len(summary.KillTimes))))
+	trend := []string{}
+	for _, killTime := range summary.KillTimes[max(0, len(summary.KillTimes)-bossSummaryKillTimes):] {
+		trend = append(trend, FormatKillTime(killTime))
+	}
+	message += fmt.Sprintf("\nLast kills: %s", strings.Join(trend, " → "))
+	if len(summary.KillTimes) > 1 {
+		difference := summary.KillTimes[len(summary.KillTimes)-1] - summary.KillTimes[len(summary.KillTimes)-2]
+		if difference <= 0 {
+			message += fmt.Sprintf(" (%s faster)", FormatKillTime(-difference))
+		} else {
+			message += fmt.Sprintf(" (%s slower)", FormatKillTime(difference))
+		}
+	}
+	return message
+}
+
+// Lists every cached raid with the boss, newest raid first, the dates are in the time zone of the server
+func FormatBossHistory(guildID string, raids []logAllData, bossName string) []string {
+	raids = append([]logAllData{}, raids...)
+	sort.SliceStable(raids, func(i, j int) bool {
+		return raids[i].RaidStartUnixTime > raids[j].RaidStartUnixTime
+	})
+	lines := []string{}
+	for _, raid := range raids {
+		for _, encounter := range raid.Encounters {
+			if encounter.BossName != bossName {
+				continue
+			}
+			raidDate := time.UnixMilli(raid.RaidStartUnixTime).In(GetGuildLocation(guildID)).Format("January 2, 2006")
+			line := fmt.Sprintf("[%s](https://fresh.warcraftlogs.com/reports/%s) - ", raidDate, raid.MetaData.Code)
+			if encounter.Kill {
+				line += fmt.Sprintf("killed in %s after %d wipes", FormatKillTime(encounter.Duration), encounter.Wipes)
+			} else {
+				line += fmt.Sprintf("not killed, %d wipes", encounter.Wipes)
+			}
+			deaths := 0
+			for _, player := range encounter.Players {
+				deaths += player.Deaths
+			}
+			lines = append(lines, fmt.Sprintf("%s, %d deaths", line, deaths))
+		}
+	}
+	return lines
+}
+
+func FormatKillTime(duration time.Duration) string {
+	return duration.Round(time.Second).String()
+}
+
+// Joins the parts until the description of an embed is full, the parts left out are counted in the last line
+func JoinWithinEmbedLimit(parts []string, separator string) string {
+	maxLength := 3500 //Leaves room for the lines added around the parts below the limit of 4096
+	message := ""
+	for x, part := range parts {
+		if len(message)+len(separator)+len(part) > maxLength {
+			return message + fmt.Sprintf("%s...and %d more", separator, len(parts)-x)
+		}
+		if x > 0 {
+			message += separator
+		}
+		message += part
+	}
+	return message
+}
+
+func HandleRaidSummaryBoss(request *slashCommandRequest) {
+	raids, err := ReadRaidDataCache(request.Guild.ServerID, time.Time{}, !request.BoolOption("includesmallraids"))
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raid cache of server %s, during the function HandleRaidSummaryBoss()", request.Guild.ServerID), err.Error())
+		RespondSlashCommandError(request, "raidsummary boss|No raids could be read from the raid cache, please run /resetraidcache")
+		return
+	}
+	summaries, countOfRaidsWithoutEncounters := NewBossSummaries(raids)
+	if len(summaries) == 0 {
+		RespondSlashCommandError(request, fmt.Sprintf("raidsummary boss|None of the %d cached raids have boss data yet, please run /resetraidcache with full set to true", len(raids)))
+		return
+	}
+	title := fmt.Sprintf("Bosses across %d raids", len(raids)-countOfRaidsWithoutEncounters)
+	message := ""
+	if request.HasOption("name") {
+		bossName := ""
+		for _, summary := range summaries {
+			if strings.EqualFold(summary.BossName, strings.TrimSpace(request.StringOption("name"))) {
+				bossName = summary.BossName
+				break
+			}
+		}
+		if bossName == "" {
+			bossNames := []string{}
+			for _, summary := range summaries {
+				bossNames = append(bossNames, summary.BossName)
+			}
+			RespondSlashCommandError(request, fmt.Sprintf("raidsummary boss|The boss %s is not found in any cached raid, the bosses found are: %s", request.StringOption("name"), strings.Join(bossNames, ", ")))
+			return
+		}
+		for _, summary := range summaries {
+			if summary.BossName == bossName {
+				message = FormatBossSummary(summary) + "\n\n" + JoinWithinEmbedLimit(FormatBossHistory(request.Guild.ServerID, raids, bossName), "\n")
+				break
+			}
+		}
+		title = bossName
+	} else {
+		parts := []string{}
+		for _, summary := range summaries {
+			parts = append(parts, FormatBossSummary(summary))
+		}
+		message = JoinWithinEmbedLimit(parts, "\n\n")
+	}
+	if countOfRaidsWithoutEncounters > 0 {
+		message = fmt.Sprintf("%d raids were cached before boss data was stored, run /resetraidcache with full set to true to include them\n\n%s", countOfRaidsWithoutEncounters, message)
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("%s|%s", title, message))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the boss summary to user %s using the slash command /raidsummary boss, during the function HandleRaidSummaryBoss()", request.UserID), err.Error())
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type customEventTemplate struct {
+	Name       string `json:"name"`       //Picked in /createraidevent, e.g. "mc"
+	ServerID   string `json:"serverId"`   //The server of the guild config the template belongs to, empty for the primary server
+	Raid       string `json:"raid"`       //Name, short name or alias in the raid catalog, the date of the event follows the resets of the raid
+	ChannelID  string `json:"channelId"`  //Channel the event is posted in, /createraidevent may pick another channel
+	Weekday    string `json:"weekday"`    //The raid is on the first weekday on or after the reset, e.g. "sunday", empty for the day of the reset
+	AutoCreate bool   `json:"autoCreate"` //Created by the scheduled job createraidevents once the event of the last reset has passed
+	raidHelperEvent
+}
+
+var (
+	customEventTemplatesDefault = []customEventTemplate{
+		{
+			Name:    "mc",
+			Raid:    "mc",
+			Weekday: "wednesday",
+			raidHelperEvent: raidHelperEvent{
+				LeaderID:    "346353264461217795",
+				TemplateID:  "2",
+				Time:        "19:30",
+				Title:       "Molten Core",
+				Description: "Remember to help guildies - SIGN UP",
+				Softres: &raidHelperSoftres{
+					Faction:              "Alliance",
+					ResLimitPerCharacter: 1,
+					DiscordProtection:    true,
+					BotBehaviour:         "dm",
+				},
+			},
+		},
+	}
+)
+
+// Only the templates of the server are returned. Read every time it is used, so the templates can be changed without a restart. The default templates are written to disc when no file exists yet
+func ReadCustomEventTemplates(guildID string) ([]customEventTemplate, error) {
+	templates := []customEventTemplate{}
+	if templatesBytes := CheckForExistingCache(customEventTemplatesPath); len(templatesBytes) == 0 {
+		templates = customEventTemplatesDefault
+		marshal, err := json.MarshalIndent(templates, "", " ")
+		if err != nil {
+			return nil, fmt.Errorf("the default event templates could not be marshaled: %s", err.Error())
+		}
+		if err := os.WriteFile(customEventTemplatesPath, marshal, 0644); err != nil {
+			return nil, fmt.Errorf("the default event templates could not be written to path %s: %s", customEventTemplatesPath, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("No event templates found on disc - The default event templates has been written to path %s, during the function ReadCustomEventTemplates()", customEventTemplatesPath), "No config found")
+	} else if err := json.Unmarshal(templatesBytes, &templates); err != nil {
+		return nil, fmt.Errorf("the event templates on path %s could not be unmarshaled: %s", customEventTemplatesPath, err.Error())
+	}
+	if err := ValidateCustomEventTemplates(templates); err != nil {
+		return nil, err
+	}
+	serverID := GetGuildConfig(guildID).ServerID
+	guildTemplates := []customEventTemplate{}
+	for _, template := range templates {
+		if GetGuildConfig(template.ServerID).ServerID == serverID {
+			guildTemplates = append(guildTemplates, template)
+		}
+	}
+	return guildTemplates, nil
+}
+
+func ValidateCustomEventTemplates(templates []customEventTemplate) error {
+	problems := []string{}
+	mapOfNames := make(map[string]bool) //Server and name, the same name can be used on every server
+	guildIDs := GetGuildIDs()
+	for x, template := range templates {
+		name := strings.ToLower(template.Name)
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("template number %d must have a name", x+1))
+			continue
+		}
+		serverID := template.ServerID
+		if serverID == "" {
+			serverID = guildIDs[0]
+		} else if !slices.Contains(guildIDs, serverID) {
+			problems = append(problems, fmt.Sprintf("%s has the server %s which is not part of the guild config on path %s", template.Name, template.ServerID, guildConfigPath))
+		}
+		if mapOfNames[serverID+"/"+name] {
+			problems = append(problems, fmt.Sprintf("the name %s is used by more than 1 template of server %s", template.Name, serverID))
+		}
+		mapOfNames[serverID+"/"+name] = true
+		if _, ok := GetRaidInstance(template.Raid); !ok {
+			problems = append(problems, fmt.Sprintf("%s has the raid %s which is not in the raid catalog on path %s", template.Name, template.Raid, raidCatalogPath))
+		}
+		if template.LeaderID == "" || template.Title == "" {
+			problems = append(problems, fmt.Sprintf("%s must have both a leaderId and a title", template.Name))
+		}
+		if _, err := time.Parse("15:04", template.Time); err != nil {
+			problems = append(problems, fmt.Sprintf("%s must have a time in format HH:MM, got %s", template.Name, template.Time))
+		}
+		if template.AutoCreate && template.ChannelID == "" {
+			problems = append(problems, fmt.Sprintf("%s must have a channelId to use autoCreate", template.Name))
+		}
+		if _, ok := ParseWeekday(template.Weekday); template.Weekday != "" && !ok {
+			problems = append(problems, fmt.Sprintf("%s has the weekday %s which is not known, use e.g. wednesday", template.Name, template.Weekday))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the event templates on path %s are invalid: %s", customEventTemplatesPath, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func GetCustomEventTemplate(templates []customEventTemplate, name string) (customEventTemplate, bool) {
+	for _, template := range templates {
+		if strings.EqualFold(template.Name, strings.TrimSpace(name)) {
+			return template, true
+		}
+	}
+	return customEventTemplate{}, false
+}
+
+// The first start of the raid after the current time. The resets of secondary raids are read from the raid cache of the function DetermineNextSecondaryRaid(), main raids reset on the resetWeekday of the raid catalog
+func ResolveNextRaidStart(guildID string, template customEventTemplate, currentTime time.Time) (time.Time, error) {
+	raid, ok := GetRaidInstance(template.Raid)
+	if !ok {
+		return time.Time{}, fmt.Errorf("the raid %s is not in the raid catalog", template.Raid)
+	}
+	location := GetGuildLocation(guildID)
+	nextReset := GetNextRaidReset(raid, currentTime.In(location))
+	for _, cachedRaid := range ReadWriteRaidCache(guildID, []commingRaid{}) {
+		if cachedReset, err := ParseGuildTime(timeLayout, cachedRaid.NextReset); RaidNameLongHandConversion(cachedRaid.Name) == raid.Name && err == nil {
+			nextReset = cachedReset
+		}
+	}
+	raidTime, err := time.Parse("15:04", template.Time)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("the time %s must be in format HH:MM", template.Time)
+	}
+	nextReset = nextReset.In(location)
+	resetDay := time.Date(nextReset.Year(), nextReset.Month(), nextReset.Day(), 0, 0, 0, 0, location).AddDate(0, 0, -raid.ResetDays) //Starts in the current reset, as the raid might still be ahead
+	for x := 0; x < 100; x++ {
+		raidDay := resetDay
+		if template.Weekday != "" {
+			weekday, ok := ParseWeekday(template.Weekday)
+			if !ok {
+				return time.Time{}, fmt.Errorf("the weekday %s is not known, use e.g. wednesday", template.Weekday)
+			}
+			raidDay = raidDay.AddDate(0, 0, (int(weekday)-int(raidDay.Weekday())+7)%7)
+		}
+		raidStart := time.Date(raidDay.Year(), raidDay.Month(), raidDay.Day(), raidTime.Hour(), raidTime.Minute(), 0, 0, location)
+		if raidStart.After(currentTime) {
+			return raidStart, nil
+		}
+		resetDay = resetDay.AddDate(0, 0, raid.ResetDays)
+	}
+	return time.Time{}, fmt.Errorf("no start of the raid %s could be found after %s", raid.Name, currentTime.Format(timeLayout))
+}
+
+// Posts the event to raid-helper and tracks it in the raid-helper cache, the soft-reserve sheet uses the short name of the raid when the template has no instance
+func CreateRaidHelperEvent(guildID string, template customEventTemplate, channelID string, raidStart time.Time) (trackRaid, error) {
+	guild := GetGuildConfig(guildID)
+	event := template.raidHelperEvent
+	event.Date = raidStart.In(GetGuildLocation(guildID)).Format(timeLayOutShort)
+	event.Time = raidStart.In(GetGuildLocation(guildID)).Format("15:04")
+	if event.Softres != nil {
+		softres := *event.Softres
+		if softres.Instance == "" {
+			raid, _ := GetRaidInstance(template.Raid)
+			softres.Instance = raid.ShortName
+		}
+		event.Softres = &softres
+	}
+	if _, ok := FindTemplateRaidHelperEvent(guildID, template.Name, raidStart); ok {
+		return trackRaid{}, fmt.Errorf("the event of template %s on %s is already created", template.Name, event.Date)
+	}
+	messageID, err := raidHelperCurrent.CreateEvent(guild.ServerID, channelID, event)
+	if err != nil {
+		return trackRaid{}, err
+	}
+	newTrackedRaid := trackRaid{
+		DiscordMessageID:  messageID,
+		ChannelID:         channelID,
+		RaidDiscordTitle:  event.Title,
+		RaidStartUnixTime: raidStart.Unix(),
+		TemplateName:      template.Name,
+	}
+	err = UpdateTrackedRaids(guildID, func(trackedRaids map[string]trackRaid) error {
+		trackedRaids[messageID] = newTrackedRaid
+		return nil
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to track the raid-helper event %s in the raid-helper cache, during the function CreateRaidHelperEvent()", messageID), err.Error(), LogField("guildID", guildID))
+	}
+	WriteInformationLog(fmt.Sprintf("The raid-helper event %s has been created from template %s for %s, during the function CreateRaidHelperEvent()", messageID, template.Name, raidStart.Format(timeLayout)), "Raid-helper event created", LogField("guildID", guildID), LogField("channelID", channelID))
+	return newTrackedRaid, nil
+}
+
+func FindTemplateRaidHelperEvent(guildID string, templateName string, raidStart time.Time) (trackRaid, bool) {
+	for _, trackedRaid := range ReadWriteRaidHelperCache(guildID) {
+		if trackedRaid.TemplateName == templateName && trackedRaid.RaidStartUnixTime == raidStart.Unix() {
+			return trackedRaid, true
+		}
+	}
+	return trackRaid{}, false
+}
+
+// Creates the next event of every template with autoCreate, events already created are skipped
+func CreateScheduledRaidEvents(guildID string) error {
+	templates, err := ReadCustomEventTemplates(guildID)
+	if err != nil {
+		return err
+	}
+	failedTemplates := []string{}
+	for _, template := range templates {
+		if !template.AutoCreate {
+			continue
+		}
+		raidStart, err := ResolveNextRaidStart(guildID, template, time.Now())
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to resolve the next date of the event template %s, during the function CreateScheduledRaidEvents()", template.Name), err.Error())
+			failedTemplates = append(failedTemplates, template.Name)
+			continue
+		}
+		if _, ok := FindTemplateRaidHelperEvent(guildID, template.Name, raidStart); ok {
+			continue
+		}
+		if _, err := CreateRaidHelperEvent(guildID, template, template.ChannelID, raidStart); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to create the raid-helper event of template %s, during the function CreateScheduledRaidEvents()", template.Name), err.Error())
+			failedTemplates = append(failedTemplates, template.Name)
+		}
+	}
+	if len(failedTemplates) > 0 {
+		return fmt.Errorf("the events of the templates %s could not be created", strings.Join(failedTemplates, ", "))
+	}
+	return nil
+}
+
+func HandleCreateRaidEvent(request *slashCommandRequest) {
+	templates, err := ReadCustomEventTemplates(request.Guild.ServerID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the event templates of server %s, during the function HandleCreateRaidEvent()", request.Guild.ServerID), err.Error())
+		RespondSlashCommandError(request, fmt.Sprintf("createraidevent|%s", err.Error()))
+		return
+	}
+	template, ok := GetCustomEventTemplate(templates, request.StringOption("template"))
+	if !ok {
+		templateNames := []string{}
+		for _, template := range templates {
+			templateNames = append(templateNames, template.Name)
+		}
+		RespondSlashCommandError(request, fmt.Sprintf("createraidevent|No event template is named %s, the templates are: %s", request.StringOption("template"), strings.Join(templateNames, ", ")))
+		return
+	}
+	channelID := template.ChannelID
+	if option, ok := request.Options["channel"]; ok {
+		channelID = option.ChannelValue(nil).ID
+	}
+	if channelID == "" {
+		RespondSlashCommandError(request, fmt.Sprintf("createraidevent|The template %s has no channelId, please pick a channel", template.Name))
+		return
+	}
+	var raidStart time.Time
+	if request.HasOption("date") {
+		raidDay, err := time.ParseInLocation(timeLayOutShort, strings.TrimSpace(request.StringOption("date")), GetGuildLocation(request.Guild.ServerID))
+		if err != nil {
+			RespondSlashCommandError(request, fmt.Sprintf("createraidevent|The date %s is not in format dd-mm-yyyy", request.StringOption("date")))
+			return
+		}
+		raidTime, _ := time.Parse("15:04", template.Time) //Checked by the function ValidateCustomEventTemplates()
+		raidStart = time.Date(raidDay.Year(), raidDay.Month(), raidDay.Day(), raidTime.Hour(), raidTime.Minute(), 0, 0, raidDay.Location())
+		if raidStart.Before(time.Now()) {
+			RespondSlashCommandError(request, fmt.Sprintf("createraidevent|The raid on %s would already have started", request.StringOption("date")))
+			return
+		}
+	} else if raidStart, err = ResolveNextRaidStart(request.Guild.ServerID, template, time.Now()); err != nil {
+		RespondSlashCommandError(request, fmt.Sprintf("createraidevent|%s", err.Error()))
+		return
+	}
+
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Creating the raid-helper event...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /createraidevent, during the function HandleCreateRaidEvent()", request.UserID), err.Error())
+		return
+	}
+	trackedRaid, err := CreateRaidHelperEvent(request.Guild.ServerID, template, channelID, raidStart)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to create the raid-helper event of template %s for user %s, during the function HandleCreateRaidEvent()", template.Name, request.UserID), err.Error())
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("createraidevent|%s", strings.ReplaceAll(err.Error(), "|", "/")))
+	} else {
+		interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("createraidevent|%s is posted in <#%s> for %s", trackedRaid.RaidDiscordTitle, channelID, FormatDiscordTimestamp(raidStart)))
+	}
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the result of /createraidevent to user %s, during the function HandleCreateRaidEvent()", request.UserID), err.Error())
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+// The default guild config uses Europe/Paris, a monday morning is followed by the reset of the raid catalog
+func TestResolveNextRaidStartUsesResetWeekday(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	location := GetGuildLocation(guildID)
+	currentTime := time.Date(2025, time.January, 6, 9, 0, 0, 0, location)
+	template := customEventTemplate{Name: "mc", Raid: "mc"}
+	template.Time = "20:00"
+	importedCatalog := GetRaidCatalog()
+	t.Cleanup(func() {
+		raidCatalogMutex.Lock()
+		raidCatalogCurrent = importedCatalog
+		raidCatalogMutex.Unlock()
+	})
+
+	for _, testCase := range []struct {
+		resetWeekday string
+		expected     time.Time
+	}{
+		{"", time.Date(2025, time.January, 9, 20, 0, 0, 0, location)}, //Raid catalogs without a reset weekday keep resetting thursday
+		{"wednesday", time.Date(2025, time.January, 8, 20, 0, 0, 0, location)},
+		{"tuesday", time.Date(2025, time.January, 7, 20, 0, 0, 0, location)},
+	} {
+		raidCatalog := []raidInstance{{Name: "Molten Core", ShortName: "mc", ZoneID: 1000, ResetDays: 7, ResetWeekday: testCase.resetWeekday, Main: true}}
+		if err := ValidateRaidCatalog(raidCatalog); err != nil {
+			t.Fatal(err)
+		}
+		raidCatalogMutex.Lock()
+		raidCatalogCurrent = raidCatalog
+		raidCatalogMutex.Unlock()
+
+		raidStart, err := ResolveNextRaidStart(guildID, template, currentTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !raidStart.Equal(testCase.expected) {
+			t.Errorf("expected the raid to start %s with the reset weekday %q, got %s", testCase.expected, testCase.resetWeekday, raidStart)
+		}
+	}
+}
+
+func TestValidateRaidCatalogResetWeekday(t *testing.T) {
+	for _, raid := range []raidInstance{
+		{Name: "Molten Core", ShortName: "mc", ResetDays: 7, ResetWeekday: "someday"},
+		{Name: "Zul'Gurub", ShortName: "zg", ResetDays: 3, ResetWeekday: "wednesday"},
+	} {
+		if err := ValidateRaidCatalog([]raidInstance{raid}); err == nil {
+			t.Errorf("expected %s with the reset weekday %s every %d days to be invalid", raid.Name, raid.ResetWeekday, raid.ResetDays)
+		}
+	}
+}
+
+// Adds a second server to the guild config, the primary server is kept first
+func SetUpTestSecondGuild(t *testing.T, serverID string) {
+	t.Helper()
+	secondGuild := GetGuildConfig("")
+	secondGuild.ServerID = serverID
+	marshal, err := json.Marshal([]guildConfig{GetGuildConfig(""), secondGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(guildConfigPath, marshal, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportGuildConfig(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.Remove(guildConfigPath)
+		ImportGuildConfig()
+	})
+}
+
+func TestReadCustomEventTemplatesPerServer(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	primaryID := GetGuildConfig("").ServerID
+	secondID := "800000000000000001"
+	SetUpTestSecondGuild(t, secondID)
+	newTemplate := func(name string, serverID string) customEventTemplate {
+		template := customEventTemplate{Name: name, Raid: "mc", ServerID: serverID}
+		template.LeaderID, template.Title, template.Time = "346353264461217795", "Molten Core", "19:30"
+		return template
+	}
+	writeTemplates := func(templates ...customEventTemplate) {
+		marshal, err := json.Marshal(templates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(customEventTemplatesPath, marshal, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeTemplates(newTemplate("mc", ""), newTemplate("bwl", primaryID), newTemplate("mc", secondID))
+	for guildID, expectedNames := range map[string][]string{primaryID: {"mc", "bwl"}, secondID: {"mc"}} {
+		templates, err := ReadCustomEventTemplates(guildID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		templateNames := []string{}
+		for _, template := range templates {
+			templateNames = append(templateNames, template.Name)
+		}
+		if !slices.Equal(templateNames, expectedNames) {
+			t.Errorf("expected server %s to have the templates %v, got %v", guildID, expectedNames, templateNames)
+		}
+	}
+
+	for _, templates := range [][]customEventTemplate{
+		{newTemplate("mc", ""), newTemplate("MC", primaryID)},
+		{newTemplate("mc", "800000000000000002")},
+	} {
+		writeTemplates(templates...)
+		if _, err := ReadCustomEventTemplates(primaryID); err == nil {
+			t.Errorf("expected the templates %+v to be invalid", templates)
+		}
+	}
+}
+
+// The rotation of the secondary raids is kept per server, so the next raid start follows the server of the template
+func TestResolveNextRaidStartPerServer(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	primaryID := GetGuildConfig("").ServerID
+	secondID := "800000000000000001"
+	SetUpTestSecondGuild(t, secondID)
+	location := GetGuildLocation(primaryID)
+	currentTime := time.Date(2025, time.January, 6, 9, 0, 0, 0, location)
+	template := customEventTemplate{Name: "ony", Raid: "ony"}
+	template.Time = "20:00"
+	nextReset := time.Date(2025, time.January, 8, 9, 0, 0, 0, location)
+	ReadWriteRaidCache(secondID, []commingRaid{{Name: "ony", NextReset: nextReset.Format(timeLayout), ResetLength: 5}})
+
+	primaryStart, err := ResolveNextRaidStart(primaryID, template, currentTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondStart, err := ResolveNextRaidStart(secondID, template, currentTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2025, time.January, 8, 20, 0, 0, 0, location); !secondStart.Equal(expected) {
+		t.Errorf("expected the raid of server %s to follow its own rotation and start %s, got %s", secondID, expected, secondStart)
+	}
+	if primaryStart.Equal(secondStart) {
+		t.Errorf("expected the raid of server %s to ignore the rotation of server %s, both start %s", primaryID, secondID, primaryStart)
+	}
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type goldenCheck struct {
+	Name string
+	Run  func() (any, error)
+}
+
+const (
+	goldenPath       = "testdata/warcraftlogs/golden/" //Relative to the folder of go.mod
+	goldenRaidCode   = "fakeRaidMC01"
+	goldenRaiderName = "Frostbolt"
+)
+
+var updateGolden = flag.Bool("update", false, "Rewrites the golden files with the current output instead of comparing")
+
+// Runs the warcraftlogs analytics against the offline stand-in and compares the output with the golden files.
+// go test -run TestGolden -update rewrites the golden files
+func TestGolden(t *testing.T) {
+	goldenDirectory, err := filepath.Abs(goldenPath)
+	if err != nil {
+		t.Fatalf("the golden folder could not be found: %s", err.Error())
+	}
+	SetUpTestWorkDirectory(t)
+	SetUpFakeWarcraftLogs(t)
+
+	for _, check := range GetGoldenChecks() {
+		t.Run(check.Name, func(t *testing.T) {
+			output, err := check.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			outputBytes, err := json.MarshalIndent(output, "", " ")
+			if err != nil {
+				t.Fatalf("the output could not be marshaled: %s", err.Error())
+			}
+			outputBytes = append(outputBytes, '\n')
+			goldenFile := filepath.Join(goldenDirectory, check.Name+".json")
+			if *updateGolden {
+				if err := os.WriteFile(goldenFile, outputBytes, 0644); err != nil {
+					t.Fatalf("the golden file could not be written: %s", err.Error())
+				}
+				return
+			}
+			goldenBytes, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("the golden file could not be read, run with -update to create it: %s", err.Error())
+			}
+			if !bytes.Equal(goldenBytes, outputBytes) {
+				t.Error(GetFirstDifference(goldenBytes, outputBytes))
+			}
+		})
+	}
+}
+
+// Rate limits and outages are retried, so a failing first try still gives the same raid
+func TestGoldenRetry(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	fake := SetUpFakeWarcraftLogs(t)
+
+	expectedRaid, _, err := GetGoldenRaid()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.FailNext(http.StatusTooManyRequests, http.StatusServiceUnavailable)
+	raid, _, err := GetGoldenRaid()
+	if err != nil {
+		t.Fatalf("the raid could not be fetched after a failing first try: %s", err.Error())
+	}
+	expectedBytes, _ := json.Marshal(expectedRaid)
+	raidBytes, _ := json.Marshal(raid)
+	if !bytes.Equal(expectedBytes, raidBytes) {
+		t.Error("the raid fetched after a failing first try differs from the raid fetched on the first try")
+	}
+}
+
+// The bot writes its caches and logs to the working directory, so every test runs in an empty one
+func SetUpTestWorkDirectory(t *testing.T) {
+	t.Helper()
+	oldDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("the work folder for the test could not be used: %s", err.Error())
+	}
+	t.Cleanup(func() {
+		os.Chdir(oldDirectory)
+	})
+	if err := os.MkdirAll(baseCachePath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ImportGuildConfig(); err != nil {
+		t.Fatalf("the default guild config could not be imported: %s", err.Error())
+	}
+	if err := ImportRaidCatalog(); err != nil {
+		t.Fatalf("the default raid catalog could not be imported: %s", err.Error())
+	}
+	if err := ImportStorageConfig(); err != nil {
+		t.Fatalf("the default storage could not be set up: %s", err.Error())
+	}
+}
+
+// Points warcraftLogsCurrent at a fresh fake for the rest of the test
+func SetUpFakeWarcraftLogs(t *testing.T) *fakeWarcraftLogsServer {
+	t.Helper()
+	fake := NewFakeWarcraftLogsServer()
+	t.Cleanup(fake.Close)
+	oldClient := warcraftLogsCurrent
+	warcraftLogsCurrent = NewWarcraftLogsClient("fake-client", "fake-secret")
+	warcraftLogsCurrent.SetBaseURL(fake.URL)
+	t.Cleanup(func() {
+		warcraftLogsCurrent = oldClient
+	})
+	return fake
+}
+
+func GetGoldenChecks() []goldenCheck {
+	return []goldenCheck{
+		{
+			Name: "unwrap_full_warcraft_log_raid",
+			Run: func() (any, error) {
+				raid, actorIDs, err := GetGoldenRaid()
+				return map[string]any{"raid": raid, "actorIDs": actorIDs}, err
+			},
+		},
+		{
+			Name: "unwrap_warcraft_log_raider_ranking",
+			Run: func() (any, error) {
+				raid, _, err := GetGoldenRaid()
+				if err != nil {
+					return nil, err
+				}
+				lastRaid := logPlayer{}
+				for _, player := range raid.Players {
+					if player.Name == goldenRaiderName {
+						lastRaid = player
+					}
+				}
+				rankings, err := warcraftLogsCurrent.GetCharacterRankings(goldenRaiderName)
+				if err != nil {
+					return nil, err
+				}
+				raiderData := UnwrapWarcraftLogRaiderRanking(map[string]any{"ranking": rankings}, raiderProfile{MainCharName: goldenRaiderName}, lastRaid)
+				raiderData.TimeOfData = "" //Set to the time of the run
+				return raiderData, nil
+			},
+		},
+		{
+			Name: "get_all_warcraft_logs_raid_data",
+			Run: func() (any, error) {
+				return GetAllWarcraftLogsRaidData("", false, false, ""), nil
+			},
+		},
+	}
+}
+
+func GetGoldenRaid() (logAllData, []int, error) {
+	fightIDs, err := warcraftLogsCurrent.GetReportFightIDs(goldenRaidCode)
+	if err != nil {
+		return logAllData{}, nil, err
+	}
+	report, err := warcraftLogsCurrent.GetReport(goldenRaidCode, fightIDs)
+	if err != nil {
+		return logAllData{}, nil, err
+	}
+	raid, actorIDs := UnwrapFullWarcraftLogRaid(map[string]any{"logs": report})
+	return raid, actorIDs, nil
+}
+
+func GetFirstDifference(expected []byte, actual []byte) string {
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(string(actual), "\n")
+	for x := 0; x < len(expectedLines) && x < len(actualLines); x++ {
+		if expectedLines[x] != actualLines[x] {
+			return fmt.Sprintf("line %d is %q but the golden file has %q", x+1, strings.TrimSpace(actualLines[x]), strings.TrimSpace(expectedLines[x]))
+		}
+	}
+	return fmt.Sprintf("the output has %d lines but the golden file has %d", len(actualLines), len(expectedLines))
+}
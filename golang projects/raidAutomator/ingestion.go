@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type ingestedReport struct {
+	Base     logsBase
+	Raid     logAllData
+	Verified bool //False when the report is not a raid the bot keeps, it is still marked as ingested
+	Err      error
+}
+
+const (
+	warcraftLogsIngestionWorkers = 4 //Reports fetched at the same time, the client already waits for the rate limit of the API
+)
+
+var (
+	ingestionMutex sync.Mutex //Held for a whole ingestion, so two ingestions never race on the watermarks
+)
+
+// Lists the reports of the guild newest first and stops at the first page where every report is stored with the same end time,
+// unless a report that failed before has not been listed yet. Returns the new, still growing or failed reports and how many
+// reports were listed. Failed reports that are no longer listed by warcraftlogs are removed from the watermarks
+func ListChangedWarcraftLogsReports(guildID string, watermarks map[string]reportWatermark) ([]logsBase, int, error) {
+	warcraftLogsGuildID := GetGuildConfig(guildID).WarcraftLogsGuildID
+	changedReports := []logsBase{}
+	countOfListed := 0
+	mapOfFailedReports := make(map[string]bool) //Not listed yet during this run
+	for code, watermark := range watermarks {
+		if watermark.Failed {
+			mapOfFailedReports[code] = true
+		}
+	}
+	for x := 1; ; x++ {
+		reports, err := warcraftLogsCurrent.GetGuildReports(warcraftLogsGuildID, x)
+		if err != nil {
+			return changedReports, countOfListed, fmt.Errorf("page %d of the guild logs could not be retrieved: %s", x, err.Error())
+		}
+		if len(reports) == 0 {
+			break
+		}
+		countOfListed += len(reports)
+		countOfChanged := 0
+		for _, report := range reports {
+			delete(mapOfFailedReports, report.Code)
+			if watermark, ok := watermarks[report.Code]; ok && !watermark.Failed && watermark.EndTime.Equal(report.endTime) {
+				continue
+			}
+			changedReports = append(changedReports, report)
+			countOfChanged++
+		}
+		if countOfChanged == 0 && len(watermarks) > 0 && len(mapOfFailedReports) == 0 {
+			WriteDebugLog(fmt.Sprintf("Page %d of the guild logs has no new or changed reports, the older pages are skipped", x), "Listing warcraftlogs reports", LogField("guildID", guildID))
+			break
+		}
+	}
+	for code := range mapOfFailedReports {
+		WriteWarningLog(fmt.Sprintf("The failed log %s is no longer listed for the guild and will not be retried, during the function ListChangedWarcraftLogsReports()", code), "Failed log removed", LogField("guildID", guildID), LogField("logCode", code))
+		delete(watermarks, code)
+	}
+	return changedReports, countOfListed, nil
+}
+
+// Fetches the reports with a bounded pool of workers, the result keeps the order of the reports.
+// progress is called after every report and may be nil
+func IngestWarcraftLogsReports(reports []logsBase, progress func(done int, total int)) []ingestedReport {
+	results := make([]ingestedReport, len(reports))
+	indexes := make(chan int)
+	var waitGroup sync.WaitGroup
+	var progressMutex sync.Mutex
+	countOfDone := 0
+	for worker := 0; worker < min(warcraftLogsIngestionWorkers, len(reports)); worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range indexes {
+				results[index] = IngestWarcraftLogsReport(reports[index])
+				progressMutex.Lock()
+				countOfDone++
+				if progress != nil {
+					progress(countOfDone, len(reports))
+				}
+				progressMutex.Unlock()
+			}
+		}()
+	}
+	for index := range reports {
+		indexes <- index
+	}
+	close(indexes)
+	waitGroup.Wait()
+	return results
+}
+
+func IngestWarcraftLogsReport(report logsBase) ingestedReport {
+	result := ingestedReport{Base: report}
+	WriteInformationLog("Retrieving warcraftlogs data for query with name: 'allFightIDsForRaid' during function IngestWarcraftLogsReport()", "Getting Warcraft logs data", LogField("logCode", report.Code))
+	fightIDs, err := warcraftLogsCurrent.GetReportFightIDs(report.Code)
+	if err != nil {
+		result.Err = fmt.Errorf("the fight IDs of the log %s could not be retrieved: %s", report.Code, err.Error())
+		return result
+	}
+	WriteInformationLog("Retrieving warcraftlogs data for query with name: 'logsByOwnerAndCode' during function IngestWarcraftLogsReport()", "Getting Warcraft logs data", LogField("logCode", report.Code))
+	fullReport, err := warcraftLogsCurrent.GetReport(report.Code, fightIDs)
+	if err != nil {
+		result.Err = fmt.Errorf("the log %s could not be retrieved: %s", report.Code, err.Error())
+		return result
+	}
+	log := map[string]any{"logs": fullReport}
+	if VerifyWarcraftLogData(log) {
+		result.Raid, _ = UnwrapFullWarcraftLogRaid(log)
+		result.Verified = true
+	}
+	return result
+}
+
+// The next ingestion fetches every report again and replaces the raid cache of the guild
+func ResetReportWatermarks(guildID string) error {
+	ingestionMutex.Lock()
+	defer ingestionMutex.Unlock()
+	return storageCurrent.WriteReportWatermarks(guildID, map[string]reportWatermark{})
+}
+
+// Marks the reports fetched without errors as ingested, a failed report is marked as failed and fetched again by the next ingestion
+func UpdateReportWatermarks(watermarks map[string]reportWatermark, results []ingestedReport) {
+	for _, result := range results {
+		if result.Err != nil {
+			watermark := watermarks[result.Base.Code]
+			watermark.Failed = true
+			watermarks[result.Base.Code] = watermark
+			continue
+		}
+		watermarks[result.Base.Code] = reportWatermark{
+			EndTime:    result.Base.endTime,
+			IngestedAt: time.Now(),
+		}
+	}
+}
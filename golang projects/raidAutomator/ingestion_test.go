@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	fakeGuildLogsPageNewest = `{"data":{"reportData":{"reports":{"data":[
+		{"code":"fakeRaidBWL2","title":"bwl-Main raid 19.10.2025","startTime":1760896800000,"endTime":1760904000000,"owner":{"name":"Zyrtec"}}]}}}}`
+	fakeGuildLogsPageOlder = `{"data":{"reportData":{"reports":{"data":[
+		{"code":"fakeRaidMC01","title":"mc+ony-Main raid 12.10.2025","startTime":1760292000000,"endTime":1760301000000,"owner":{"name":"Throyn1986"}}]}}}}`
+	fakeMissingReport = `{"data":{"reportData":{"report":null}}}`
+)
+
+// A report that failed on an older page must be fetched again, even when the newest page has not changed since
+func TestIngestionRetriesFailedReportOnOlderPage(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	fake := SetUpFakeWarcraftLogs(t)
+	guildID := GetGuildConfig("").ServerID
+	fake.SetFixture("guild_logs_page_1.json", []byte(fakeGuildLogsPageNewest))
+	fake.SetFixture("guild_logs_page_2.json", []byte(fakeGuildLogsPageOlder))
+	fake.SetFixture("fights_fakeRaidMC01.json", []byte(fakeMissingReport))
+
+	GetAllWarcraftLogsRaidData(guildID, false, false, "")
+	watermarks, err := storageCurrent.ReadReportWatermarks(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !watermarks["fakeRaidMC01"].Failed || watermarks["fakeRaidBWL2"].Failed {
+		t.Fatalf("expected only fakeRaidMC01 to be marked as failed, got %+v", watermarks)
+	}
+
+	recordedFights, err := recordedWarcraftLogsFixtures.ReadFile("testdata/warcraftlogs/fixtures/fights_fakeRaidMC01.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.SetFixture("fights_fakeRaidMC01.json", recordedFights)
+	raids := GetAllWarcraftLogsRaidData(guildID, false, false, "")
+
+	found := false
+	for _, raid := range raids {
+		if raid.MetaData.Code == "fakeRaidMC01" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected fakeRaidMC01 to be fetched again from the older page")
+	}
+	watermarks, err = storageCurrent.ReadReportWatermarks(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if watermarks["fakeRaidMC01"].Failed || watermarks["fakeRaidMC01"].EndTime.IsZero() {
+		t.Errorf("expected fakeRaidMC01 to be marked as ingested, got %+v", watermarks["fakeRaidMC01"])
+	}
+}
+
+// A failed report that warcraftlogs no longer lists is dropped, so the listing does not walk every page forever
+func TestListChangedReportsDropsUnlistedFailedReport(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	fake := SetUpFakeWarcraftLogs(t)
+	guildID := GetGuildConfig("").ServerID
+	fake.SetFixture("guild_logs_page_1.json", []byte(fakeGuildLogsPageNewest))
+	watermarks := map[string]reportWatermark{
+		"fakeRaidBWL2": {EndTime: time.UnixMilli(1760904000000), IngestedAt: time.Now()},
+		"deletedLog":   {Failed: true},
+	}
+
+	changedReports, _, err := ListChangedWarcraftLogsReports(guildID, watermarks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changedReports) != 0 {
+		t.Errorf("expected no changed reports, got %d", len(changedReports))
+	}
+	if _, ok := watermarks["deletedLog"]; ok {
+		t.Error("expected the failed log that is no longer listed to be removed from the watermarks")
+	}
+}
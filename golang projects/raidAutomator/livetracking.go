@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type liveRaid struct {
+	GuildID      string
+	Code         string
+	ChannelID    string
+	StartTime    time.Time
+	EndTime      int64     //End time of the report on the last poll, unix milliseconds
+	LastChange   time.Time //When the end time of the report last grew
+	PostedFights map[int64]bool
+	Kills        int
+	Wipes        int
+}
+
+const (
+	liveRaidPollInterval  = time.Minute
+	liveRaidFinalizeAfter = 15 * time.Minute //The log is final once its end time has not grown for this long
+	liveRaidMaxDuration   = 8 * time.Hour    //Stops polling a log that never stops growing, e.g. when the logger forgot to stop
+)
+
+var (
+	liveRaidMutex   sync.Mutex
+	mapOfLiveRaids  = make(map[string]bool) //Log code to true while it is being tracked
+	mapOfEncounters sync.Map                //Encounter ID to the name of the boss, filled by the function GetEncounterName()
+)
+
+// Starts tracking the log in the background, returns false when the log is already being tracked
+func StartLiveRaidTracking(session discordSession, guildID string, code string) bool {
+	liveRaidMutex.Lock()
+	defer liveRaidMutex.Unlock()
+	if mapOfLiveRaids[code] {
+		return false
+	}
+	mapOfLiveRaids[code] = true
+	go func() {
+		defer func() {
+			liveRaidMutex.Lock()
+			delete(mapOfLiveRaids, code)
+			liveRaidMutex.Unlock()
+		}()
+		TrackLiveRaid(session, NewLiveRaid(guildID, code, time.Now()), liveRaidPollInterval)
+	}()
+	return true
+}
+
+func NewLiveRaid(guildID string, code string, now time.Time) *liveRaid {
+	guild := GetGuildConfig(guildID)
+	channelID := guild.Channels.LiveRaid
+	if channelID == "" {
+		channelID = guild.Channels.Log
+	}
+	return &liveRaid{
+		GuildID:      guild.ServerID,
+		Code:         code,
+		ChannelID:    channelID,
+		StartTime:    now,
+		LastChange:   now,
+		PostedFights: make(map[int64]bool),
+	}
+}
+
+// Polls the log until it stops growing and then stores the final raid
+func TrackLiveRaid(session discordSession, raid *liveRaid, pollInterval time.Duration) {
+	WriteInformationLog(fmt.Sprintf("Started live tracking of the log %s for server %s, during the function TrackLiveRaid()", raid.Code, raid.GuildID), "Live raid tracking", LogField("guildID", raid.GuildID), LogField("logCode", raid.Code))
+	for {
+		if raid.Poll(session, time.Now()) {
+			break
+		}
+		time.Sleep(pollInterval)
+	}
+	FinalizeLiveRaid(session, raid)
+}
+
+// Posts every boss fight not posted yet, returns true once the log has stopped growing
+func (raid *liveRaid) Poll(session discordSession, now time.Time) bool {
+	if now.Sub(raid.StartTime) >= liveRaidMaxDuration {
+		WriteWarningLog(fmt.Sprintf("The log %s has been tracked for %s and is finalized while it might still grow, during the function Poll()", raid.Code, liveRaidMaxDuration), "Live raid tracking", LogField("logCode", raid.Code))
+		return true
+	}
+	report, err := warcraftLogsCurrent.GetReportFights(raid.Code)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to poll the log %s, it is polled again later, during the function Poll()", raid.Code), err.Error(), LogField("logCode", raid.Code))
+		return false
+	}
+	if report.EndTime != raid.EndTime {
+		raid.EndTime = report.EndTime
+		raid.LastChange = now
+	}
+	for _, fight := range report.Fights {
+		if fight.EncounterID == 0 || fight.Kill == nil || raid.PostedFights[fight.ID] {
+			continue
+		}
+		if IsBossSkipped(GetEncounterName(fight.EncounterID)) {
+			raid.PostedFights[fight.ID] = true
+			continue
+		}
+		if err := raid.PostFight(session, fight); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to post fight %d of the log %s, it is posted on the next poll, during the function Poll()", fight.ID, raid.Code), err.Error(), LogField("logCode", raid.Code))
+			continue
+		}
+		raid.PostedFights[fight.ID] = true
+		if *fight.Kill {
+			raid.Kills++
+		} else {
+			raid.Wipes++
+		}
+	}
+	return now.Sub(raid.LastChange) >= liveRaidFinalizeAfter
+}
+
+// Retrieves the log for this fight only, so the players table holds the damage, healing and deaths of the fight
+func (raid *liveRaid) PostFight(session discordSession, fight warcraftLogsFight) error {
+	report, err := warcraftLogsCurrent.GetReport(raid.Code, []int64{fight.ID})
+	if err != nil {
+		return err
+	}
+	fightData, _ := UnwrapFullWarcraftLogRaid(map[string]any{"logs": report})
+	_, err = session.ChannelMessageSendEmbed(raid.ChannelID, NewLiveRaidFightEmbed(raid.Code, GetEncounterName(fight.EncounterID), fight, fightData))
+	return err
+}
+
+func NewLiveRaidFightEmbed(code string, encounterName string, fight warcraftLogsFight, fightData logAllData) *discordgo.MessageEmbed {
+	duration := time.Duration(fight.EndTime-fight.StartTime) * time.Millisecond
+	embed := &discordgo.MessageEmbed{
+		URL: fmt.Sprintf("https://fresh.warcraftlogs.com/reports/%s#fight=%d", code, fight.ID),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "Time",
+				Value:  duration.Round(time.Second).String(),
+				Inline: true,
+			},
+			{
+				Name:   "Deaths",
+				Value:  fmt.Sprintf("%d", fightData.TotalDeaths),
+				Inline: true,
+			},
+		},
+	}
+	if fight.Kill != nil && *fight.Kill {
+		embed.Title = fmt.Sprintf("%s killed %s", encounterName, crackedBuiltin)
+		embed.Color = greenColor
+	} else {
+		embed.Title = fmt.Sprintf("Wipe on %s at %.1f%%", encounterName, fight.FightPercentage)
+		embed.Color = redColor
+	}
+	seconds := duration.Seconds()
+	if seconds <= 0 {
+		return embed
+	}
+	for _, top := range []struct {
+		name   string
+		amount func(player logPlayer) int64
+	}{
+		{"Top DPS", func(player logPlayer) int64 { return player.DamageDone }},
+		{"Top HPS", func(player logPlayer) int64 { return player.HealingDone }},
+	} {
+		players := append([]logPlayer{}, fightData.Players...)
+		sort.SliceStable(players, func(i, j int) bool {
+			return top.amount(players[i]) > top.amount(players[j])
+		})
+		lines := []string{}
+		for _, player := range players {
+			if len(lines) == 3 || top.amount(player) == 0 {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("%s %.0f", player.Name, float64(top.amount(player))/seconds))
+		}
+		if len(lines) > 0 {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:  top.name,
+				Value: strings.Join(lines, "\n"),
+			})
+		}
+	}
+	return embed
+}
+
+// Falls back to the ID of the encounter when warcraftlogs does not know it
+func GetEncounterName(encounterID int64) string {
+	if name, ok := mapOfEncounters.Load(encounterID); ok {
+		return name.(string)
+	}
+	encounter, err := warcraftLogsCurrent.GetEncounter(encounterID)
+	if err != nil || encounter.Name == "" {
+		return fmt.Sprintf("Encounter %d", encounterID)
+	}
+	mapOfEncounters.Store(encounterID, encounter.Name)
+	return encounter.Name
+}
+
+// Stores the final log through the normal ingestion and posts how the night went
+func FinalizeLiveRaid(session discordSession, raid *liveRaid) {
+	raids := GetAllWarcraftLogsRaidData(raid.GuildID, false, true, raid.Code)
+	WriteInformationLog(fmt.Sprintf("The log %s is finalized with %d kills and %d wipes, the server now has %d raids stored, during the function FinalizeLiveRaid()", raid.Code, raid.Kills, raid.Wipes, len(raids)), "Live raid tracking", LogField("guildID", raid.GuildID), LogField("logCode", raid.Code))
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Raid finished %s", crackedBuiltin),
+		URL:         fmt.Sprintf("https://fresh.warcraftlogs.com/reports/%s", raid.Code),
+		Color:       blueColor,
+		Description: fmt.Sprintf("%d kills and %d wipes - The log is now part of every raid command", raid.Kills, raid.Wipes),
+	}
+	if _, err := session.ChannelMessageSendEmbed(raid.ChannelID, embed); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to post the summary of the log %s, during the function FinalizeLiveRaid()", raid.Code), err.Error(), LogField("logCode", raid.Code))
+	}
+}
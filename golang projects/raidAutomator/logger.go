@@ -0,0 +1,283 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type logLevel int
+
+type logEntry struct { //Keeps the fields of the old information and error logs, so displayLogs.ps1 can still read every line
+	Level     string            `json:"level"`
+	Action    string            `json:"action,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Message   string            `json:"message"`
+	TimeStamp string            `json:"time_stamp"`
+	Fields    map[string]string `json:"fields,omitempty"` //Context of the entry, e.g. command, userID or logCode
+}
+
+type logField struct {
+	Key   string
+	Value string
+}
+
+type rotatingLogger struct {
+	mutex        sync.Mutex
+	path         string
+	file         *os.File
+	size         int64
+	openedDate   string
+	maxSizeBytes int64
+	maxBackups   int
+}
+
+const (
+	logLevelDebug logLevel = iota
+	logLevelInformation
+	logLevelWarning
+	logLevelError
+
+	logMaxSizeBytes  = 10 * 1024 * 1024 //A log file is rotated when it would grow past this size, or when the date changes
+	logMaxBackups    = 14               //Rotated files per log, the oldest ones are deleted
+	logBufferEntries = 500              //Latest entries kept in memory across both logs
+
+	recentLogsDefaultCount = 10   //Entries shown by /recentlogs without a count
+	recentLogsMaxLength    = 4000 //Below the 4096 characters of an embed description, the oldest entries are left out above it
+)
+
+var (
+	logLevelMinimum = logLevelInformation
+	mapOfLogLevels  = map[logLevel]string{
+		logLevelDebug:       "debug",
+		logLevelInformation: "information",
+		logLevelWarning:     "warning",
+		logLevelError:       "error",
+	}
+
+	informationLogger = &rotatingLogger{path: informationLogPath, maxSizeBytes: logMaxSizeBytes, maxBackups: logMaxBackups}
+	errorLogger       = &rotatingLogger{path: errorLogPath, maxSizeBytes: logMaxSizeBytes, maxBackups: logMaxBackups}
+
+	logBuffer      = make([]logEntry, 0, logBufferEntries)
+	logBufferMutex sync.Mutex
+)
+
+func LogField(key string, value any) logField {
+	return logField{Key: key, Value: fmt.Sprint(value)}
+}
+
+func WriteInformationLog(message string, action string, fields ...logField) {
+	WriteLog(logLevelInformation, message, action, "", fields...)
+}
+
+func WriteWarningLog(message string, action string, fields ...logField) {
+	WriteLog(logLevelWarning, message, action, "", fields...)
+}
+
+func WriteDebugLog(message string, action string, fields ...logField) {
+	WriteLog(logLevelDebug, message, action, "", fields...)
+}
+
+func WriteErrorLog(message string, errorMessage string, fields ...logField) {
+	WriteLog(logLevelError, message, "", errorMessage, fields...)
+}
+
+func WriteLog(level logLevel, message string, action string, errorMessage string, fields ...logField) {
+	if level < logLevelMinimum {
+		return
+	}
+	entry := logEntry{
+		Level:     mapOfLogLevels[level],
+		Action:    action,
+		Error:     errorMessage,
+		Message:   message,
+		TimeStamp: GetTimeString(),
+	}
+	if len(fields) > 0 {
+		entry.Fields = make(map[string]string)
+		for _, field := range fields {
+			entry.Fields[field.Key] = field.Value
+		}
+	}
+
+	logBufferMutex.Lock()
+	if len(logBuffer) == logBufferEntries {
+		logBuffer = append(logBuffer[:0], logBuffer[1:]...)
+	}
+	logBuffer = append(logBuffer, entry)
+	logBufferMutex.Unlock()
+
+	logger := informationLogger
+	if level == logLevelError {
+		logger = errorLogger
+	}
+	if err := WriteLogEntry(logger, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "An error occured while trying to write to the log %s, the entry is only kept in memory: %s\n", logger.path, err.Error())
+	}
+}
+
+// Returns the latest entries in memory, oldest first, optionally only the ones at or above the level given
+func GetRecentLogs(count int, minimumLevel ...logLevel) []logEntry {
+	logBufferMutex.Lock()
+	defer logBufferMutex.Unlock()
+	returnEntries := []logEntry{}
+	for x := len(logBuffer) - 1; x >= 0 && len(returnEntries) < count; x-- {
+		if len(minimumLevel) > 0 && GetLogLevel(logBuffer[x].Level) < minimumLevel[0] {
+			continue
+		}
+		returnEntries = append(returnEntries, logBuffer[x])
+	}
+	slices.Reverse(returnEntries)
+	return returnEntries
+}
+
+func GetLogLevel(levelName string) logLevel {
+	for level, name := range mapOfLogLevels {
+		if name == strings.ToLower(levelName) {
+			return level
+		}
+	}
+	return logLevelInformation
+}
+
+func HandleRecentLogs(request *slashCommandRequest) {
+	count := recentLogsDefaultCount
+	if request.HasOption("count") {
+		count = int(request.IntOption("count"))
+	}
+	if count < 1 || count > logBufferEntries {
+		RespondSlashCommandError(request, fmt.Sprintf("recentlogs|The count must be between 1 and %d", logBufferEntries))
+		return
+	}
+	minimumLevel := logLevelDebug
+	if request.HasOption("level") {
+		minimumLevel = GetLogLevel(request.StringOption("level"))
+	}
+	message := FormatRecentLogs(GetRecentLogs(count, minimumLevel))
+	if message == "" {
+		message = fmt.Sprintf("No %s entries has been logged since the bot started", mapOfLogLevels[minimumLevel])
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Recent logs|%s", message))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the recent logs to user %s using the slash command /recentlogs, during the function HandleRecentLogs()", request.UserID), err.Error())
+	}
+}
+
+// One line per entry, oldest first. The newest entries are kept when the lines do not fit in an embed
+func FormatRecentLogs(entries []logEntry) string {
+	lines := []string{}
+	length := 0
+	for x := len(entries) - 1; x >= 0; x-- {
+		entry := entries[x]
+		line := fmt.Sprintf("`%s` **%s** %s", entry.TimeStamp, entry.Level, entry.Message)
+		if entry.Error != "" {
+			line += fmt.Sprintf(" - Error: %s", entry.Error)
+		}
+		line = strings.ReplaceAll(line, "|", "/") //The response of the command is split on |
+		if length+len(line)+1 > recentLogsMaxLength {
+			break
+		}
+		lines = append(lines, line)
+		length += len(line) + 1
+	}
+	slices.Reverse(lines)
+	return strings.Join(lines, "\n")
+}
+
+func WriteLogEntry(logger *rotatingLogger, entry logEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+	today := time.Now().Local().Format(timeLayOutShort)
+	if logger.file != nil && (logger.size+int64(len(line)) > logger.maxSizeBytes || logger.openedDate != today) {
+		if err := RotateLog(logger); err != nil {
+			return err
+		}
+	}
+	if logger.file == nil {
+		if err := OpenLog(logger); err != nil {
+			return err
+		}
+	}
+	written, err := logger.file.Write(line)
+	logger.size += int64(written)
+	if _, skipShipping := entry.Fields[logFieldSkipShipping]; err == nil && !skipShipping {
+		EnqueueLogLine(logger.path, line)
+	}
+	return err
+}
+
+func OpenLog(logger *rotatingLogger) error {
+	file, err := os.OpenFile(logger.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	logger.file = file
+	logger.size = info.Size()
+	logger.openedDate = info.ModTime().Local().Format(timeLayOutShort)
+	if info.Size() == 0 {
+		logger.openedDate = time.Now().Local().Format(timeLayOutShort)
+	}
+	return nil
+}
+
+// Moves the current file to <name>_<time>.json and removes the oldest rotated files above maxBackups
+func RotateLog(logger *rotatingLogger) error {
+	if logger.file != nil {
+		logger.file.Close()
+		logger.file = nil
+	}
+	if info, err := os.Stat(logger.path); err != nil || info.Size() == 0 {
+		return nil
+	}
+	extension := filepath.Ext(logger.path)
+	base := strings.TrimSuffix(logger.path, extension)
+	rotatedPath := fmt.Sprintf("%s_%s%s", base, time.Now().Local().Format("2006-01-02T150405.000000"), extension)
+	if err := os.Rename(logger.path, rotatedPath); err != nil {
+		return err
+	}
+
+	rotatedPaths, err := filepath.Glob(base + "_*" + extension)
+	if err != nil {
+		return err
+	}
+	sort.Strings(rotatedPaths) //The time format sorts oldest first
+	for len(rotatedPaths) > logger.maxBackups {
+		os.Remove(rotatedPaths[0])
+		rotatedPaths = rotatedPaths[1:]
+	}
+	return nil
+}
+
+// Checks that both logs can be written to, the file from the last run is rotated so every start-up begins on an empty log
+func InitializeLoggers() error {
+	for _, logger := range []*rotatingLogger{informationLogger, errorLogger} {
+		logger.mutex.Lock()
+		err := RotateLog(logger)
+		if err == nil {
+			err = OpenLog(logger)
+		}
+		logger.mutex.Unlock()
+		if err != nil {
+			return fmt.Errorf("the log on path %s could not be opened: %s", logger.path, err.Error())
+		}
+	}
+	return nil
+}
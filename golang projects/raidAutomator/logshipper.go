@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+)
+
+type logSink interface {
+	Append(blobName string, data []byte) error
+	Name() string
+}
+
+type logShippingConfig struct {
+	StorageAccountURI    string `json:"storageAccountURI"` //Leave empty to ship the logs to localDirectory instead, e.g. when running offline
+	ContainerName        string `json:"containerName"`
+	LocalDirectory       string `json:"localDirectory"`
+	FlushIntervalSeconds int    `json:"flushIntervalSeconds"`
+	BatchSize            int    `json:"batchSize"`  //Lines, a full batch is shipped without waiting for the interval
+	MaxRetries           int    `json:"maxRetries"` //Per flush, a batch that still fails is kept and tried again on the next flush
+}
+
+type appendBlobSink struct {
+	client        *azblob.Client
+	containerName string
+}
+
+type localDirectorySink struct {
+	path string
+}
+
+type shippedLogLine struct {
+	blobName string
+	line     []byte
+}
+
+type logShipper struct {
+	sink    logSink
+	config  logShippingConfig
+	queue   chan shippedLogLine
+	stop    chan struct{}
+	done    chan struct{}
+	pending map[string][]byte //Blob name to the lines not shipped yet, only touched by the function RunLogShipper()
+	dropped atomic.Int64
+}
+
+const (
+	logShipperQueueSize       = 5000
+	logShipperMaxPendingBytes = 16 * 1024 * 1024 //Per blob, the oldest lines are dropped when the sink has been down for long
+	appendBlockMaxBytes       = 4 * 1024 * 1024  //Limit of a single append block in Azure storage
+	logShipperCloseTimeout    = 30 * time.Second
+	logFieldSkipShipping      = "skipShipping" //Entries with this field are only written to the local log, so a sink that is down does not feed itself
+)
+
+var (
+	logShippingPath          = baseCachePath + "config_log_shipping.json"
+	logShipperCurrent        atomic.Pointer[logShipper] //Set by the function StartLogShipper() during start-up, nil means nothing is shipped
+	logShippingConfigDefault = logShippingConfig{
+		StorageAccountURI:    "",
+		ContainerName:        azureContainerName,
+		LocalDirectory:       baseCachePath + "shipped_logs/",
+		FlushIntervalSeconds: 30,
+		BatchSize:            500,
+		MaxRetries:           3,
+	}
+)
+
+func ImportLogShippingConfig() (logShippingConfig, error) {
+	currentLogShippingConfig := logShippingConfigDefault
+	if logShippingConfigBytes := CheckForExistingCache(logShippingPath); len(logShippingConfigBytes) == 0 {
+		marshal, err := json.MarshalIndent(logShippingConfigDefault, "", " ")
+		if err != nil {
+			return currentLogShippingConfig, fmt.Errorf("the default log shipping config could not be marshaled: %s", err.Error())
+		}
+		err = os.WriteFile(logShippingPath, marshal, 0644)
+		if err != nil {
+			return currentLogShippingConfig, fmt.Errorf("the default log shipping config could not be written to path %s: %s", logShippingPath, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("No log shipping config found on disc - The default log shipping config has been written to path %s, during the function ImportLogShippingConfig()", logShippingPath), "No config found")
+	} else if err := json.Unmarshal(logShippingConfigBytes, &currentLogShippingConfig); err != nil {
+		return currentLogShippingConfig, fmt.Errorf("the log shipping config on path %s could not be unmarshaled: %s", logShippingPath, err.Error())
+	}
+
+	if currentLogShippingConfig.ContainerName == "" {
+		currentLogShippingConfig.ContainerName = logShippingConfigDefault.ContainerName
+	}
+	if currentLogShippingConfig.LocalDirectory == "" {
+		currentLogShippingConfig.LocalDirectory = logShippingConfigDefault.LocalDirectory
+	}
+	if currentLogShippingConfig.FlushIntervalSeconds <= 0 {
+		currentLogShippingConfig.FlushIntervalSeconds = logShippingConfigDefault.FlushIntervalSeconds
+	}
+	if currentLogShippingConfig.BatchSize <= 0 {
+		currentLogShippingConfig.BatchSize = logShippingConfigDefault.BatchSize
+	}
+	if currentLogShippingConfig.MaxRetries < 0 {
+		currentLogShippingConfig.MaxRetries = 0
+	}
+	return currentLogShippingConfig, nil
+}
+
+// Picks the append blob sink when a storage account is configured, otherwise the local directory
+func StartLogShipper() error {
+	currentLogShippingConfig, err := ImportLogShippingConfig()
+	if err != nil {
+		return err
+	}
+
+	var sink logSink
+	if currentLogShippingConfig.StorageAccountURI != "" {
+		storageClient := StorageAccountClient(currentLogShippingConfig.StorageAccountURI)
+		if storageClient == nil {
+			return fmt.Errorf("no storage client could be created for the storage account %s, see the error log", currentLogShippingConfig.StorageAccountURI)
+		}
+		sink = &appendBlobSink{client: storageClient, containerName: currentLogShippingConfig.ContainerName}
+	} else {
+		if err := os.MkdirAll(currentLogShippingConfig.LocalDirectory, 0755); err != nil {
+			return fmt.Errorf("the local log directory %s could not be created: %s", currentLogShippingConfig.LocalDirectory, err.Error())
+		}
+		sink = &localDirectorySink{path: currentLogShippingConfig.LocalDirectory}
+	}
+
+	shipper := &logShipper{
+		sink:    sink,
+		config:  currentLogShippingConfig,
+		queue:   make(chan shippedLogLine, logShipperQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		pending: make(map[string][]byte),
+	}
+	logShipperCurrent.Store(shipper)
+	go RunLogShipper(shipper)
+	WriteInformationLog(fmt.Sprintf("The logs will be shipped to %s every %d seconds, during the function StartLogShipper()", sink.Name(), currentLogShippingConfig.FlushIntervalSeconds), "Start log shipper")
+	return nil
+}
+
+// Called by the logger for every line written, never blocks - the line is dropped when the queue is full
+func EnqueueLogLine(logPath string, line []byte) {
+	shipper := logShipperCurrent.Load()
+	if shipper == nil {
+		return
+	}
+	select {
+	case <-shipper.stop:
+		return
+	default:
+	}
+	select {
+	case shipper.queue <- shippedLogLine{blobName: GetLogBlobName(logPath, time.Now()), line: line}:
+	default:
+		shipper.dropped.Add(1)
+	}
+}
+
+// One blob per log per day, e.g. error_log_2025-01-31.json
+func GetLogBlobName(logPath string, timeOfLine time.Time) string {
+	extension := filepath.Ext(logPath)
+	base := strings.TrimSuffix(filepath.Base(logPath), extension)
+	return fmt.Sprintf("%s_%s%s", base, timeOfLine.Local().Format("2006-01-02"), extension)
+}
+
+func RunLogShipper(shipper *logShipper) {
+	defer close(shipper.done)
+	ticker := time.NewTicker(time.Duration(shipper.config.FlushIntervalSeconds) * time.Second)
+	defer ticker.Stop()
+	linesInBatch := 0
+	for {
+		select {
+		case line := <-shipper.queue:
+			{
+				shipper.pending[line.blobName] = append(shipper.pending[line.blobName], line.line...)
+				linesInBatch++
+				if linesInBatch >= shipper.config.BatchSize {
+					FlushLogShipper(shipper)
+					linesInBatch = 0
+				}
+			}
+		case <-ticker.C:
+			{
+				FlushLogShipper(shipper)
+				linesInBatch = 0
+			}
+		case <-shipper.stop:
+			{
+				for len(shipper.queue) > 0 {
+					line := <-shipper.queue
+					shipper.pending[line.blobName] = append(shipper.pending[line.blobName], line.line...)
+				}
+				FlushLogShipper(shipper)
+				return
+			}
+		}
+	}
+}
+
+func FlushLogShipper(shipper *logShipper) {
+	if dropped := shipper.dropped.Swap(0); dropped > 0 {
+		WriteErrorLog(fmt.Sprintf("%d log lines were dropped because the log shipper queue was full, during the function FlushLogShipper()", dropped), "Log shipper queue full", LogField(logFieldSkipShipping, true))
+	}
+	for blobName, data := range shipper.pending {
+		for len(data) > 0 {
+			chunk := GetLogChunk(data, appendBlockMaxBytes)
+			if err := ShipLogChunk(shipper, blobName, chunk); err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to ship %d bytes of logs to %s/%s, the lines will be tried again on the next flush, during the function FlushLogShipper()", len(data), shipper.sink.Name(), blobName), err.Error(), LogField(logFieldSkipShipping, true))
+				break
+			}
+			data = data[len(chunk):]
+		}
+		if len(data) == 0 {
+			delete(shipper.pending, blobName)
+			continue
+		}
+		if len(data) > logShipperMaxPendingBytes {
+			data = data[len(data)-logShipperMaxPendingBytes:]
+			if newLine := bytes.IndexByte(data, '\n'); newLine >= 0 {
+				data = data[newLine+1:]
+			}
+		}
+		shipper.pending[blobName] = data
+	}
+}
+
+// Cuts at the last whole line that fits, so a retried chunk never splits a JSON line
+func GetLogChunk(data []byte, maxBytes int) []byte {
+	if len(data) <= maxBytes {
+		return data
+	}
+	if lastNewLine := bytes.LastIndexByte(data[:maxBytes], '\n'); lastNewLine >= 0 {
+		return data[:lastNewLine+1]
+	}
+	return data[:maxBytes]
+}
+
+func ShipLogChunk(shipper *logShipper, blobName string, chunk []byte) error {
+	var err error
+	for attempt := 0; attempt <= shipper.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(1<<(attempt-1)) * time.Second)
+		}
+		if err = shipper.sink.Append(blobName, chunk); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Ships what is left in the queue, called when the program stops
+func CloseLogShipper() {
+	shipper := logShipperCurrent.Load()
+	if shipper == nil {
+		return
+	}
+	close(shipper.stop)
+	select {
+	case <-shipper.done:
+	case <-time.After(logShipperCloseTimeout):
+		fmt.Fprintf(os.Stderr, "The log shipper did not finish within %s, the last log lines might not have been shipped\n", logShipperCloseTimeout)
+	}
+}
+
+func (sink *appendBlobSink) Append(blobName string, data []byte) error {
+	return StorageAccountAppendBlob(blobName, sink.containerName, data, sink.client, context.Background())
+}
+
+func (sink *appendBlobSink) Name() string {
+	return fmt.Sprintf("%s%s", sink.client.URL(), sink.containerName)
+}
+
+func (sink *localDirectorySink) Append(blobName string, data []byte) error {
+	logFile, err := os.OpenFile(filepath.Join(sink.path, blobName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = logFile.Write(data)
+	if closeErr := logFile.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (sink *localDirectorySink) Name() string {
+	return sink.path
+}
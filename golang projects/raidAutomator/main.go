@@ -14,7 +14,6 @@ import (
 	"os/signal"
 	"reflect"
 	"regexp"
-	"runtime"
 	"slices"
 	"sort"
 	"strconv"
@@ -24,26 +23,15 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
-	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/errors"
 
 	"github.com/bwmarrin/discordgo"
 )
 
-type informationLog struct {
-	Action    string `json:"action"`
-	Message   string `json:"message"`
-	TimeStamp string `json:"time_stamp"`
-}
-
-type errorLog struct {
-	Error     string `json:"error"`
-	Message   string `json:"message"`
-	TimeStamp string `json:"time_stamp"`
-}
-
 type config struct {
 	ServerID            string           `json:"serverID"`
 	ChannelID           string           `json:"channelID"`
@@ -53,6 +41,81 @@ type config struct {
 	Announce            map[string]topic `json:"announce"` //Define map of thread-name of slash commands you want to announce
 }
 
+type guildConfig struct {
+	ServerID            string            `json:"serverID"`
+	WarcraftLogsGuildID int               `json:"warcraftLogsGuildID"`
+	Channels            guildChannels     `json:"channels"`
+	ClassChannels       map[string]string `json:"classChannels"` //Key = lower case in-game class name, e.g. "druid"
+	Categories          guildCategories   `json:"categories"`
+	Roles               guildRoles        `json:"roles"`
+	ClassRoles          map[string]string `json:"classRoles"` //Key = lower case in-game class name, e.g. "druid"
+	Officers            []guildOfficer    `json:"officers"`
+	Loggers             map[string]string `json:"loggers"` //Key = Warcraftlogs user name, value = discord ID
+	TimeZone            string            `json:"timeZone"` //IANA name, e.g. "Europe/Paris", used for every time the bot parses, schedules or shows
+	AttendancePolicy    attendancePolicy  `json:"attendancePolicy"`
+	SignUpNags          signUpNagConfig   `json:"signUpNags"`
+	BenchRotation       benchRotation     `json:"benchRotation"`
+}
+
+type signUpNagConfig struct {
+	LeadHours []int `json:"leadHours"` //Hours before a raid starts the raiders missing from the sign-ups get a DM, e.g. [48, 24], empty turns the nags off
+}
+
+type benchRotation struct { //Used by /benchrecommend, a guild config without it uses benchRotationDefault
+	TankSlots         int     `json:"tankSlots"`
+	HealerSlots       int     `json:"healerSlots"`
+	DPSSlots          int     `json:"dpsSlots"`
+	BenchWeight       float64 `json:"benchWeight"`       //Raiders benched the least in the last weeks are benched first
+	AttendanceWeight  float64 `json:"attendanceWeight"`  //Raiders with a lower attendance are benched first
+	PerformanceWeight float64 `json:"performanceWeight"` //Raiders with less performance points compared to the rest of their role are benched first
+	CoverageWeight    float64 `json:"coverageWeight"`    //Protects the only raider of a class within a role, e.g. the only shaman healer
+}
+
+type attendancePolicy struct { //The zero value counts raids as before the policy existed, only being in the log counts
+	BenchCredit    float64 `json:"benchCredit"`    //Share of a raid credited to a benched raider, 0 counts the bench as missed and 1 as attended
+	ExcuseAbsences bool    `json:"excuseAbsences"` //Absences reported before the notice deadline leave the raid out of the attendance
+	NoticeHours    int     `json:"noticeHours"`    //Hours before the raid starts an absence must be reported to be excused
+}
+
+type guildChannels struct {
+	Info        string `json:"info"`
+	Feedback    string `json:"feedback"`
+	Log         string `json:"log"`
+	General     string `json:"general"`
+	GearCheck   string `json:"gearCheck"`
+	Voting      string `json:"voting"`
+	SignUp      string `json:"signUp"`
+	SignUpPug   string `json:"signUpPug"`
+	SignUpNaxx  string `json:"signUpNaxx"`
+	Welcome     string `json:"welcome"`
+	Bot         string `json:"bot"`
+	ServerRules string `json:"serverRules"`
+	Officer     string `json:"officer"`
+	LiveRaid    string `json:"liveRaid,omitempty"` //Kill and wipe feed of the raid being logged, the log channel is used when empty
+}
+
+type guildCategories struct {
+	Bot        string `json:"bot"`
+	Assistance string `json:"assistance"`
+}
+
+type guildRoles struct {
+	Temp        string `json:"temp"`
+	Puggie      string `json:"puggie"`
+	Trial       string `json:"trial"`
+	GuildMember string `json:"guildMember"`
+	Raider      string `json:"raider"`
+	Officer     string `json:"officer"`
+	RaidLeader  string `json:"raidLeader"`
+}
+
+type guildOfficer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ClassLeader string `json:"classLeader"` //Lower case in-game class the officer is class leader for, empty if none
+	GuildMaster bool   `json:"guildMaster"` //Exactly 1 officer must be the guild master, this is the person users are asked to contact on errors
+}
+
 type topic struct {
 	Name             string `json:"name"`
 	Order            int    `json:"order"` //Prio will be from lowest > highest, so 1 is the first in order of topic
@@ -95,7 +158,7 @@ type trackPost struct {
 type playerChannel struct {
 	RaiderName string `json:"raiderName"`
 	RaiderDiscordID string `json:"raiderDiscordID"`
-	FriendlyName string `json:threadNickName` //We want a way for the user to let the bot know which thread they want to respond to, incase player has more than 1 active current threads
+	FriendlyName string `json:"threadNickName"` //We want a way for the user to let the bot know which thread they want to respond to, incase player has more than 1 active current threads
 	TimeOfCreation string `json:"timeOfCreation"`
 	UserChannelID string `json:"userChannelID"` //DM channel with raider 
 	RespondChannelID string `json:"respondChannelID"` //Channel user will respond to using DMs 
@@ -104,12 +167,16 @@ type playerChannel struct {
 
 type keyvaultToken struct {
 	Name      string `json:"name"`
-	VersionID string `json:"version"`
+	VersionID string `json:"version"`  //Pins the Key Vault version of the secret, empty means the latest version
+	EnvName   string `json:"env"`      //Defaults to the name in upper snake case, e.g. botToken becomes BOT_TOKEN
+	Optional  bool   `json:"optional"` //Start-up fails when a secret that is not optional cannot be resolved
 }
 
 type keyvault struct {
-	Name   string
-	Tokens []keyvaultToken `json:"keyvaultToken"`
+	Name            string
+	Tokens          []keyvaultToken `json:"keyvaultToken"`
+	Providers       []string        `json:"providers"`       //Tried in order, any of "keyvault", "env" and "file"
+	SecretsFilePath string          `json:"secretsFilePath"` //Used by the file provider, either a JSON object or a .env file
 }
 
 type messageTemplate struct {
@@ -172,6 +239,9 @@ type raiderProfile struct {
 	RaidData              logsRaider            `json:"raidData"`
 	MainSwitch            map[string]bool       `json:"mainSwitch"`
 	BenchInfo             map[string][]bench    `json:"benchInfo"`
+	Absences              []raiderAbsence       `json:"absences,omitempty"`
+	AttendanceOverrides   map[string]string     `json:"attendanceOverrides,omitempty"` //Key = warcraftlogs report code, value = one of the attendance statuses set by an officer
+	SignUpHistory         []signUpRecord        `json:"signUpHistory,omitempty"`
 	TotalMainRaidsJoined  int                   `json:"totalMainRaidsJoined"`
 	TotalRaidsJoined      int                   `json:"totalRaidsJoined"`
 }
@@ -205,10 +275,15 @@ type lootLog struct {
 }
 
 type trackRaid struct { //Helper struct for scanning raid-helper events efficiently on discord``
-	PlayersAlreadyTracked map[string]bench `json:"playersAlreadyTracked"` //We will only track weekly benches using this field - As the automatic part that tracks benches, only tracks for current main raid (this week)
-	RaidDiscordTitle      string           `json:"raidDiscordTitle"`
-	DiscordMessageID      string           `json:"discordMessageID"`
-	ChannelID             string           `json:"channelID"`
+	PlayersAlreadyTracked map[string]bench     `json:"playersAlreadyTracked"` //We will only track weekly benches using this field - As the automatic part that tracks benches, only tracks for current main raid (this week)
+	RaidDiscordTitle      string               `json:"raidDiscordTitle"`
+	DiscordMessageID      string               `json:"discordMessageID"`
+	ChannelID             string               `json:"channelID"`
+	RaidStartUnixTime     int64                `json:"raidStartUnixTime,omitempty"`   //Unix seconds as given by raid-helper, used by /absent to list the upcoming raids
+	ReconciledLogCode     string               `json:"reconciledLogCode,omitempty"`   //The log the sign-ups have been compared with, see the function ReconcileGuildSignUps()
+	NaggedLeadHours       []int                `json:"naggedLeadHours,omitempty"`     //The lead times the missing raiders has been nagged at, see the function NagMissingSignUps()
+	TemplateName          string               `json:"templateName,omitempty"`        //The custom event template the event was created from, see the function CreateRaidHelperEvent()
+	BenchRecommendation   *benchRecommendation `json:"benchRecommendation,omitempty"` //The last recommendation of /benchrecommend and what the officers accepted of it
 }
 
 type bench struct {
@@ -220,12 +295,47 @@ type bench struct {
 	RaidNames           []string //Get from warcraftlog
 }
 
+type benchRecommendation struct {
+	CreatedBy  string      `json:"createdBy"` //Discord ID of the officer running /benchrecommend
+	CreatedAt  time.Time   `json:"createdAt"`
+	Picks      []benchPick `json:"picks"`
+	Accepted   []string    `json:"accepted,omitempty"` //Names of the picks the officers accepted, the rest is in Rejected
+	Rejected   []string    `json:"rejected,omitempty"`
+	ReviewedBy string      `json:"reviewedBy,omitempty"`
+	ReviewedAt time.Time   `json:"reviewedAt,omitempty"`
+}
+
+type benchPick struct {
+	Name   string  `json:"name"` //Main char of the raider, or the name in raid-helper when no raider profile matches
+	UserID string  `json:"userId"`
+	Role   string  `json:"role"`
+	Score  float64 `json:"score"`
+	Reason string  `json:"reason"`
+}
+
 type attendance struct {
-	RaidCount         int
+	RaidCount         int //Raids attended
 	RaidProcent       float64
 	MainRaid          bool
-	RaidsMissed       []string
+	RaidsMissed       []string //Unexcused raids only
 	LateNoticeProcent float64 //Out of the 100% of the time where a raider is ABSCENT, how many % of that time is the notice late
+	Benched           int
+	Excused           int //Left out of RaidProcent
+	Unexcused         int
+}
+
+type raiderAbsence struct {
+	RaidDate   string    `json:"raidDate"` //In format timeLayOutShort
+	Reason     string    `json:"reason"`
+	ReportedAt time.Time `json:"reportedAt"`
+}
+
+type signUpRecord struct { //The sign-up of a raider for a main raid compared with the log of the raid
+	RaidDate  string `json:"raidDate"` //In format timeLayOutShort
+	RaidTitle string `json:"raidTitle"`
+	LogCode   string `json:"logCode"`
+	SignUp    string `json:"signUp"`  //One of the signUp constants, empty when the raider did not sign up
+	Outcome   string `json:"outcome"` //One of the signUpOutcome constants
 }
 
 type logsRaiderBoss struct {
@@ -283,6 +393,23 @@ type logAllData struct { //Raw data
 	MetaData             logsBase
 	RaidTitle            string
 	RaidNames            []string
+	Encounters           []logEncounter
+}
+
+type logEncounter struct { //One boss of the raid, every attempt on the boss is merged into one record
+	EncounterID int64
+	BossName    string
+	Kill        bool
+	Duration    time.Duration //Of the kill, or of the last wipe when the boss was not killed
+	Wipes       int
+	Players     []logEncounterPlayer
+}
+
+type logEncounterPlayer struct { //Summed over every attempt on the boss
+	Name        string
+	DamageDone  int64
+	HealingDone int64
+	Deaths      int
 }
 
 type logPlayerPresent struct { //Calculate this on-demand by users / Do not cache, (customized strings NOT raw data)
@@ -385,14 +512,6 @@ type applicationCommand struct {
 	Interaction        *discordgo.Interaction
 }
 
-type schedule struct {
-	Name       string `json:"name"`
-	HourMinute string `json:"hour_minute"`
-	Weekday    time.Weekday
-	Interval   int `json:"interval"`
-	WeekdayInt int `json:"weekday_int"`
-}
-
 type raidHelper struct {
 	ID         string
 	DateString string
@@ -402,11 +521,13 @@ type raidHelper struct {
 
 // Must follow the API structure of the official documentation https://raid-helper.dev/documentation/api
 type raidHelperEvent struct {
-	LeaderID    string `json:"leaderId"`
-	Time        string `json:"time"`
-	Date        string `json:"date"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
+	LeaderID    string             `json:"leaderId"`
+	TemplateID  string             `json:"templateId"`
+	Time        string             `json:"time"` //In format 15:04
+	Date        string             `json:"date"` //In format timeLayOutShort
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Softres     *raidHelperSoftres `json:"softres,omitempty"`
 }
 
 // Must follow the API structure of the official documentation https://raid-helper.dev/documentation/api
@@ -449,8 +570,6 @@ var (
 	emojiesImport  = []emojies{}
 	KeyvaultConfig = keyvault{}
 	configCurrent  = config{
-		ServerID:            serverID,
-		WarcraftLogsGuildID: strconv.Itoa(warcraftLogsGuildID),
 		WarcraftLogsAppID:   warcraftLogsAppID,
 		DiscordAppID:        crackedAppID,
 	}
@@ -459,7 +578,9 @@ var (
 		Questions: make([]question, 0),
 	}
 	feedbackThreads = make([]playerChannel, 0)
-	raidChannelIDs = []string{}
+	mapOfGuildConfigs = make(map[string]guildConfig) //Key = discord server ID
+	guildIDs = []string{} //Same order as the guild config, the first one is the primary guild
+	mapOfRaidChannelIDs = make(map[string][]string) //Key = discord server ID
 	automaticAnnounceDiscordChannel = &discordgo.Channel{}
 	trackCacheChanged = make(chan struct{}, 1) //Channel will be between func AutoTrackPosts() & UseSlashCommand / Commands from the discord server
 
@@ -471,10 +592,6 @@ var (
 		"pointScaleStat/DPS & HPS":         8,
 	}
 
-	mapOfBossesToSkip = map[string]int{ //Should finish this - Will depend on if their are bosses in TBC that is also not regarded in warcraftlogs
-		"Gothik the Harvester": 123,
-	}
-
 	mapOfPointScaleProcent = make(map[string]float64)
 
 	messageTemplates = map[string]messageTemplate{
@@ -535,7 +652,7 @@ var (
 	slashCommandSubOptionSmallRaids = &discordgo.ApplicationCommandOption{
 		Type:        discordgo.ApplicationCommandOptionBoolean,
 		Name:        "includesmallraids",
-		Description: "Include any Onyxia and Zul'gurub raids.",
+		Description: "Include the secondary raids, e.g. Onyxia and Zul'gurub.",
 		Required:    false,
 	}
 
@@ -647,16 +764,70 @@ var (
 				Description: "Create an alert - The bot will notify you, once the time is up!",
 				Options: []*discordgo.ApplicationCommandOption{
 					{
-						Name:        "title",
-						Required:    true,
-						Description: "What would you like to be reminded of?",
-						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "set",
+						Description: "Create a new reminder, it is kept even when the bot restarts",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "title",
+								Required:    true,
+								Description: "What would you like to be reminded of?",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+							{
+								Name:        "time",
+								Required:    true,
+								Description: "In format: `1h30m15s` (Countdown), `23:59` or `thursday 19:00` (In your time zone)",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+							{
+								Name:        "repeat",
+								Required:    false,
+								Description: "Repeat the reminder, e.g. every raid night",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{
+										Name:  "every day",
+										Value: reminderRepeatDaily,
+									},
+									{
+										Name:  "every week",
+										Value: reminderRepeatWeekly,
+									},
+								},
+							},
+						},
 					},
 					{
-						Name:        "time",
-						Required:    true,
-						Description: "When would you like to be alerted? In format: `1h30m15s` (Countdown) or `23:59:59` (Servertime)",
-						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "list",
+						Description: "See all of your reminders",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "timezone",
+						Description: "See or set the time zone used for your reminders",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "zone",
+								Required:    false,
+								Description: "E.g. `Europe/London`, use `default` for the time zone of the server",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+						},
+					},
+					{
+						Name:        "cancel",
+						Description: "Cancel one of your reminders",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "id",
+								Required:    true,
+								Description: "The ID of the reminder, see `/myreminder list`",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+						},
 					},
 				},
 			},
@@ -673,8 +844,12 @@ var (
 									Color: blueColor,
 									Fields: []*discordgo.MessageEmbedField{
 										{
-											Name:  "If you want a clock format (Remember it will be server time)",
-											Value: "`11:00:00`\n`13:55:27`\n`12:01:00`\n(You **must** add hours, minutes & seconds)",
+											Name:  "If you want a clock format (Remember it will be your time zone, see `/myreminder timezone`)",
+											Value: "`11:00`\n`13:55:27`\n`12:01`\n(A time that has already passed today will be tomorrow)",
+										},
+										{
+											Name:  "If you want a specific day",
+											Value: "`thursday 19:00`\n`sun 12:30`\n(Use `repeat` for a reminder every week, e.g. every raid night)",
 										},
 										{
 											Name:  "If you want countdown format",
@@ -706,6 +881,84 @@ var (
 				Description: "Make the bot do a joke in your current channel",
 			},
 		},
+		"mysignupnags": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "mysignupnags",
+				Description: "Turn the DMs about raids you have not signed up for on or off",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "enabled",
+						Required:    true,
+						Description: "Set to false to stop the DMs",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+					},
+				},
+			},
+		},
+		"absent": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "absent",
+				Description: "Tell the officers you cannot make it to one or more raids",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "raids",
+						Description: "Pick the upcoming raids you will miss, the reason is asked for afterwards",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "range",
+						Description: "Report every raid between 2 dates, e.g. for a vacation",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "from",
+								Required:    true,
+								Description: "First day you are away in format dd-mm-yyyy",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+							{
+								Name:        "to",
+								Required:    true,
+								Description: "Last day you are away in format dd-mm-yyyy",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+							{
+								Name:        "reason",
+								Required:    true,
+								Description: "Why you are away, only the officers will see it",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+						},
+					},
+				},
+			},
+			Responses: map[string]applicationResponse{
+				"reason": {
+					Response: &discordgo.InteractionResponse{
+						Type: discordgo.InteractionResponseModal,
+						Data: &discordgo.InteractionResponseData{
+							CustomID: "absent_modal",
+							Title:    "Report absence",
+							Components: []discordgo.MessageComponent{
+								discordgo.ActionsRow{
+									Components: []discordgo.MessageComponent{
+										&discordgo.TextInput{
+											CustomID:    "absent_reason",
+											Label:       "Why are you away?",
+											Style:       discordgo.TextInputParagraph,
+											Placeholder: "Only the officers will see the reason",
+											Required:    true,
+											MinLength:   3,
+											MaxLength:   500,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"feedback": {
 			Template: &discordgo.ApplicationCommand{
 				Name:        "feedback",
@@ -789,18 +1042,21 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"closefeedback": {
 			Template: &discordgo.ApplicationCommand{
 				Name: "closefeedback",
 				Description: "Use when you want to close a given feedback thread. Must run in the thread itself",
 			},
+			RequiresPriviledge: true,
 		},
 		"deletebotchannel": {
 			Template: &discordgo.ApplicationCommand{
 				Name:        "deletebotchannel",
 				Description: "Force the bot to refresh its own bot-channel",
 			},
+			RequiresPriviledge: true,
 		},
 		"benchreason": {
 			Template: &discordgo.ApplicationCommand{
@@ -845,12 +1101,21 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"resetraidcache": {
 			Template: &discordgo.ApplicationCommand{
 				Name:        "resetraidcache",
-				Description: "Force the bot to recreate the raids cache - Can take multiple minutes...",
+				Description: "Retrieve the new and changed raids from warcraftlogs into the raids cache",
 				Version:     "1",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "full",
+						Description: "Recreate the whole raids cache from every log - Can take multiple minutes...",
+						Required:    false,
+					},
+				},
 			},
 			Responses: map[string]applicationResponse{
 				"result": {
@@ -916,6 +1181,20 @@ var (
 							},
 						},
 					},
+					{
+						Name:        "boss",
+						Description: "Kill-time trends and wipes per boss across every cached raid",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Required:    false,
+								Description: "Name of a boss, shows every cached raid with the boss",
+								Name:        "name",
+								Type:        discordgo.ApplicationCommandOptionString,
+							},
+							slashCommandSubOptionSmallRaids,
+						},
+					},
 				},
 			},
 			Responses: map[string]applicationResponse{
@@ -950,6 +1229,7 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"simplemessage": {
 			Template: &discordgo.ApplicationCommand{
@@ -980,12 +1260,14 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"deletechannelcontent": {
 			Template: &discordgo.ApplicationCommand{
 				Name:        "deletechannelcontent",
 				Description: "Delete all the content in a given channel",
 			},
+			RequiresPriviledge: true,
 		},
 		"seeraiderattendance": {
 			Template: &discordgo.ApplicationCommand{
@@ -993,6 +1275,35 @@ var (
 				Description: "Get a full overview over all raiders attendance",
 				Options:     slashCommandAdminUserOptions["playername"].Options,
 			},
+			RequiresPriviledge: true,
+		},
+		"overrideattendance": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "overrideattendance",
+				Description: "Set how a single raid counts in the attendance of a raider",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "playername",
+						Required:    true,
+						Description: "Use @<playername> to pick the raider",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:        "log",
+						Required:    true,
+						Description: "Code or link of the warcraftlogs report of the raid",
+						Type:        discordgo.ApplicationCommandOptionString,
+					},
+					{
+						Name:        "status",
+						Required:    true,
+						Description: "How the raid counts, remove makes the log decide again",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Choices:     GetAttendanceStatusChoices(),
+					},
+				},
+			},
+			RequiresPriviledge: true,
 		},
 		"seeraidermissedraids": {
 			Template: &discordgo.ApplicationCommand{
@@ -1007,6 +1318,7 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"seebench": {
 			Template: &discordgo.ApplicationCommand{
@@ -1041,12 +1353,56 @@ var (
 					slashCommandAdminUserOptions["playername"],
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"updateweeklyattendance": {
 			Template: &discordgo.ApplicationCommand{
 				Name:        "updateweeklyattendance",
-				Description: "Manually update the weekly attendance for raiders, cracked will automatically do it fridays at 12:00",
+				Description: "Manually update the weekly attendance for raiders, cracked will automatically do it on the schedule in /schedules",
+			},
+			RequiresPriviledge: true,
+		},
+		"reloadguildconfig": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "reloadguildconfig",
+				Description: "Reload server, channel, role and officer IDs from the guild config file without restarting the bot",
+			},
+			RequiresPriviledge: true,
+		},
+		"recentlogs": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "recentlogs",
+				Description: "See the latest log entries the bot has kept in memory since it started",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "level",
+						Description: "Only see entries at or above this level, leave empty for every entry",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{
+								Name:  "Information",
+								Value: "information",
+							},
+							{
+								Name:  "Warning",
+								Value: "warning",
+							},
+							{
+								Name:  "Error",
+								Value: "error",
+							},
+						},
+					},
+					{
+						Name:        "count",
+						Description: "The number of entries to see, leave empty for 10",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+					},
+				},
 			},
+			RequiresPriviledge: true,
 		},
 		"promotetrial": {
 			Template: &discordgo.ApplicationCommand{
@@ -1061,6 +1417,7 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
 		"syncdiscordroles": {
 			Template: &discordgo.ApplicationCommand{
@@ -1075,66 +1432,183 @@ var (
 					},
 				},
 			},
+			RequiresPriviledge: true,
 		},
-	}
-	/*
-		slashCommandTemplates = map[string]applicationCommand{
-			"playerinfo": {
-				Template: &discordgo.ApplicationCommand{
-					GuildID:     serverID,
-					Name:        "warcraftlogs",
-					Description: "Get information about you from Warcraftlogs",
-					Version:     "1",
-					//DefaultMemberPermissions: GetIntPointer(10),
-					Options: []*discordgo.ApplicationCommandOption{
-						{
-							Name:        "general-information",
-							Description: "Get general information about your parse",
-							Type:        discordgo.ApplicationCommandOptionSubCommand,
-						},
+		"schedules": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "schedules",
+				Description: "See, pause or trigger the jobs the bot runs on a schedule",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "list",
+						Description: "See every scheduled job with its last and next run",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
 					},
-				},
-				Responses: map[string]applicationResponse{
-					"general-information": {
-						Response: &discordgo.InteractionResponse{
-							Type: discordgo.InteractionResponseChannelMessageWithSource,
-							Data: &discordgo.InteractionResponseData{
-								Flags: discordgo.MessageFlagsEphemeral,
-								Embeds: []*discordgo.MessageEmbed{ //
-									{
-										Title:       "General info from Warcraftlogs",
-										Description: "This information is ONLY calculated in the perspective of your main",
-										URL:         "https://www.fresh.warcraftlogs.com/character/eu/thunderstrike/", //We need to add the char-name when the template is executed
-										Color:       0x00ff00,                                                         // Green color
-										Fields: []*discordgo.MessageEmbedField{
-											{
-												Name:  "Average parse",
-												Value: "",
-											},
-											{
-												Name:  `Average % performance`,
-												Value: "",
-											},
-											{
-												Name:  "Last time died besides a wipe",
-												Value: "",
-											},
-											{
-												Name:  "World buffs stats",
-												Value: "",
-											},
-											{
-												Name:  "Attendance % of main-raids since joining the guild",
-												Value: "",
-											},
-										},
-									},
-								}},
+					{
+						Name:        "pause",
+						Description: "Pause a scheduled job, it can still be triggered manually",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "job",
+								Required:    true,
+								Description: "The name of the scheduled job",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Choices:     GetJobChoices(),
+							},
 						},
 					},
-				},
-			},
-		}
+					{
+						Name:        "resume",
+						Description: "Resume a paused scheduled job",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "job",
+								Required:    true,
+								Description: "The name of the scheduled job",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Choices:     GetJobChoices(),
+							},
+						},
+					},
+					{
+						Name:        "trigger",
+						Description: "Run a scheduled job right now",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "job",
+								Required:    true,
+								Description: "The name of the scheduled job",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Choices:     GetJobChoices(),
+							},
+						},
+					},
+				},
+			},
+			RequiresPriviledge: true,
+		},
+		"createraidevent": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "createraidevent",
+				Description: "Create a raid-helper event with a soft-reserve sheet from a template in custom_event_templates.json",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "template",
+						Description: "The name of the event template, e.g. mc",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+					},
+					{
+						Name:        "channel",
+						Description: "Post the event in another channel than the one of the template",
+						Type:        discordgo.ApplicationCommandOptionChannel,
+						Required:    false,
+					},
+					{
+						Name:        "date",
+						Description: "Use format dd-mm-yyyy, leave empty for the next raid day after the reset of the raid",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+					},
+				},
+			},
+			RequiresPriviledge: true,
+		},
+		"benchrecommend": {
+			Template: &discordgo.ApplicationCommand{
+				Name:        "benchrecommend",
+				Description: "Recommend who to bench from the sign-ups of an upcoming raid, weighing benches, attendance and performance",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "event",
+						Description: "The message ID of the raid-helper event, leave empty for the next raid",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+					},
+					{
+						Name:        "tanks",
+						Description: "Tank slots in the raid, leave empty to use benchRotation in the guild config",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+					},
+					{
+						Name:        "healers",
+						Description: "Healer slots in the raid, leave empty to use benchRotation in the guild config",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+					},
+					{
+						Name:        "dps",
+						Description: "DPS slots in the raid, leave empty to use benchRotation in the guild config",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						Required:    false,
+					},
+				},
+			},
+			RequiresPriviledge: true,
+		},
+	}
+	/*
+		slashCommandTemplates = map[string]applicationCommand{
+			"playerinfo": {
+				Template: &discordgo.ApplicationCommand{
+					GuildID:     guildConfigDefault.ServerID,
+					Name:        "warcraftlogs",
+					Description: "Get information about you from Warcraftlogs",
+					Version:     "1",
+					//DefaultMemberPermissions: GetIntPointer(10),
+					Options: []*discordgo.ApplicationCommandOption{
+						{
+							Name:        "general-information",
+							Description: "Get general information about your parse",
+							Type:        discordgo.ApplicationCommandOptionSubCommand,
+						},
+					},
+				},
+				Responses: map[string]applicationResponse{
+					"general-information": {
+						Response: &discordgo.InteractionResponse{
+							Type: discordgo.InteractionResponseChannelMessageWithSource,
+							Data: &discordgo.InteractionResponseData{
+								Flags: discordgo.MessageFlagsEphemeral,
+								Embeds: []*discordgo.MessageEmbed{ //
+									{
+										Title:       "General info from Warcraftlogs",
+										Description: "This information is ONLY calculated in the perspective of your main",
+										URL:         "https://www.fresh.warcraftlogs.com/character/eu/thunderstrike/", //We need to add the char-name when the template is executed
+										Color:       0x00ff00,                                                         // Green color
+										Fields: []*discordgo.MessageEmbedField{
+											{
+												Name:  "Average parse",
+												Value: "",
+											},
+											{
+												Name:  `Average % performance`,
+												Value: "",
+											},
+											{
+												Name:  "Last time died besides a wipe",
+												Value: "",
+											},
+											{
+												Name:  "World buffs stats",
+												Value: "",
+											},
+											{
+												Name:  "Attendance % of main-raids since joining the guild",
+												Value: "",
+											},
+										},
+									},
+								}},
+						},
+					},
+				},
+			},
+		}
 	*/
 	mapOfWarcaftLogsQueries = map[string]map[string]any{
 		"playerRankings": {
@@ -1180,7 +1654,7 @@ var (
 				}
 			}`,
 			"variables": map[string]any{
-				"guildID": warcraftLogsGuildID, // Replace with the actual Guild ID from Step 1
+				"guildID": 0, //Set per guild by the function NewGuildLogsQuery()
 				"page":    1,
 			},
 		},
@@ -1188,8 +1662,14 @@ var (
 			"query": `query GetFights($code: String!) {
 				reportData {
 					report(code: $code) {
+						endTime
 						fights {
 							id
+							encounterID
+							startTime
+							endTime
+							kill
+							fightPercentage
 						}
 					}
 				}
@@ -1302,48 +1782,103 @@ var (
 				"encounterID": 0,
 			},
 		},
+		"encounterTables": {
+			"query": `query GetEncounterTables($code: String!, $fightIDs: [Int!]!, $encounterID: Int!) {
+				reportData {
+					report(code: $code) {
+						damageDone: table(dataType: DamageDone, fightIDs: $fightIDs, encounterID: $encounterID)
+						healingDone: table(dataType: Healing, fightIDs: $fightIDs, encounterID: $encounterID)
+						deaths: table(dataType: Deaths, fightIDs: $fightIDs, encounterID: $encounterID)
+					}
+				}
+			}`,
+			"variables": map[string]any{
+				"code":        "",        // Log code (string)
+				"fightIDs":    []int64{}, // The fights of the encounter
+				"encounterID": 0,
+			},
+		},
 	}
 
 	//Define the time where the Wow-guild startet to log
 	timeGuildStarted = "November 15, 2024 18:00:00"
 
-	// Mapping constant names to values
-	mapOfConstantRoles = map[string]string{
-		"roleDruid":   roleDruid,
-		"roleHunter":  roleHunter,
-		"roleMage":    roleMage,
-		"rolePaladin": rolePaladin,
-		"rolePriest":  rolePriest,
-		"roleRogue":   roleRogue,
-		"roleWarlock": roleWarlock,
-		"roleWarrior": roleWarrior,
-	}
-
-	mapOfConstantClasses = map[string]string{
-		"channelDruid":   channelDruid,
-		"channelHunter":  channelHunter,
-		"channelMage":    channelMage,
-		"channelPaladin": channelPaladin,
-		"channelPriest":  channelPriest,
-		"channelRogue":   channelRogue,
-		"channelWarlock": channelWarlock,
-		"channelWarrior": channelWarrior,
-		"channelShaman": channelShaman,
-	}
-
-	mapOfConstantOfficers = map[string]string{
-		"officerGMArlissa": officerGMArlissa,
-		"officerPriest":    officerPriest,
-		"officerRogue":     officerRogue,
-		"officerWarrior":   officerWarrior,
-		"officerMage":      officerMage,
-		"officerDruid":     officerDruid,
-	}
-
-	mapOfLoggers = map[string]string{
-		"Throyn1986": SplitOfficerName(officerPriest)["ID"],
-		"Zyrtec":     officialLogger1,
-		"Shufflez26": SplitOfficerName(officerGMArlissa)["ID"],
+	//This default one is only written to disk when no guild config exists - After that the file on disk is the source of truth
+	guildConfigDefault = guildConfig{
+		ServerID:            "630793944632131594",
+		WarcraftLogsGuildID: 773986,
+		TimeZone:            guildTimeZoneDefault,
+		AttendancePolicy: attendancePolicy{
+			BenchCredit:    1,
+			ExcuseAbsences: true,
+			NoticeHours:    24,
+		},
+		SignUpNags: signUpNagConfig{
+			LeadHours: []int{48, 24},
+		},
+		BenchRotation: benchRotationDefault,
+		Channels: guildChannels{
+			Info:        "1308521695564402899",
+			Feedback:    "1441245331214958625",
+			Log:         "1318700380900823103",
+			General:     "1308521052036530291",
+			GearCheck:   "1396200186208063608",
+			Voting:      "1316379489906855936",
+			SignUp:      "1346922479951675483",
+			SignUpPug:   "1334949433208606791",
+			SignUpNaxx:  "1418598263782899832",
+			Welcome:     "1309312094822203402",
+			Bot:         "1336098468615426189",
+			ServerRules: "1309545568925650974",
+			Officer:     "1308522605065539714",
+		},
+		ClassChannels: map[string]string{
+			"druid":   "667826282678976515",
+			"hunter":  "667826319333130260",
+			"mage":    "1308522161916481667",
+			"paladin": "667826420956921856",
+			"priest":  "667826515601391646",
+			"rogue":   "667826598678102023",
+			"warlock": "1308522389176324139",
+			"warrior": "1308522446500008006",
+			"shaman":  "1440432785155424438",
+		},
+		Categories: guildCategories{
+			Bot:        "1336097759073140920",
+			Assistance: "1465470731893866526",
+		},
+		Roles: guildRoles{
+			Temp:        "1335442062459535371",
+			Puggie:      "1335028472770461847",
+			Trial:       "1335374757847109768",
+			GuildMember: "651796644739940369",
+			Raider:      "1322681249294323814",
+			Officer:     "1309512897612746752",
+			RaidLeader:  "1308525656031625317",
+		},
+		ClassRoles: map[string]string{
+			"druid":   "1314533133688897577",
+			"hunter":  "1314532209759359056",
+			"mage":    "1314532563796361227",
+			"paladin": "1314531854031913000",
+			"priest":  "1314533393614241832",
+			"rogue":   "1314532022945054751",
+			"warlock": "1314532784529866843",
+			"warrior": "1314531430122131550",
+		},
+		Officers: []guildOfficer{
+			{ID: "346353264461217795", Name: "Arlissa", GuildMaster: true},
+			{ID: "812709542554370098", Name: "Throyn", ClassLeader: "priest"},
+			{ID: "655113437327917065", Name: "Akasuna", ClassLeader: "rogue"},
+			{ID: "626123398681985054", Name: "Joebaldo", ClassLeader: "warrior"},
+			{ID: "232480016854679553", Name: "Dumblydore", ClassLeader: "mage"},
+			{ID: "231066682842415105", Name: "Sleepybear", ClassLeader: "druid"},
+		},
+		Loggers: map[string]string{
+			"Throyn1986": "812709542554370098",
+			"Zyrtec":     "276387587155820544",
+			"Shufflez26": "346353264461217795",
+		},
 	}
 
 	feedbackSubjectsSlice = []string{
@@ -1358,56 +1893,34 @@ var (
 		"Whistleblower",
 	}
 
-	skippedBosses = []string{"gothik the harvester"}
-
 	classesPath             = baseCachePath + "classes.json"
 	keyvaultPath            = baseCachePath + "keyvault.json"
 	emojiesPath             = baseCachePath + "emojies.json"
-	cacheTrackedPostsCache =  baseCachePath + "cache_tracked_posts.json"
+	cacheTrackedPostsCache =  baseCachePath + "cache_tracked_posts.json" //Shared between the servers, the posts are keyed by the discord message id
 	configPath              = baseCachePath + "config.json"
 	configServerJoin        = baseCachePath + "config_join_server.json"
+	guildConfigPath         = baseCachePath + "config_guild.json"
+	guildCacheFolder        = "guilds/" //Every server gets its own folder below this one for caches that must not be mixed between guilds
 	raidHelperEventsPath    = baseCachePath + "raid_helper_events.json"
-	belowRaidersCachePath   = baseCachePath + "cache_trials_pugs.json"
+	belowRaidersCachePath   = baseCachePath + "cache_trials_pugs.json" //Shared between the servers like raidersCachePath, the members are keyed by the discord user id
 	raidersCachePath        = baseCachePath + "cache_raiders.json"
 	raiderProfilesCachePath = baseCachePath + "cache_raider_profiles.json"
 	raidHelperCachePath     = baseCachePath + "cache_raid_helper.json"
 	raidCachePath           = baseCachePath + "cache_raids.json" // Will be the largest file due to warcraftlogs info
 	raidAllDataPath         = baseCachePath + "cache_raid_all_data.json"
+	raidWatermarksCachePath = baseCachePath + "cache_raid_watermarks.json"
+	raidCatalogPath         = baseCachePath + "raids.json"
 	cachePlayerAlerts       = baseCachePath + "cache_player_alerts.json"
-	cachePlayerFeedbackChannels = baseCachePath + "cache_player_feedback_channels.json"
+	cacheUserTimeZones      = baseCachePath + "cache_user_time_zones.json"
+	cacheTimeZoneMigration  = baseCachePath + "cache_time_zone_migration.json"
+	cacheSignUpNagOptOuts   = baseCachePath + "cache_sign_up_nag_opt_outs.json"
+	cacheJobStates          = baseCachePath + "cache_job_states.json"
+	cachePlayerFeedbackChannels = baseCachePath + "cache_player_feedback_channels.json" //Shared between the servers, the channels are keyed by the discord channel id
 	informationLogPath      = baseCachePath + "information_log.json" // Will grow over time
 	//errorLogPathWarcraftLogs = baseCachePath + "warcraft_logs_query_errors.json" // Will grow over time
 	errorLogPath       = baseCachePath + "error_log.json" // Will grow over time
 	customSchedulePath = baseCachePath + "custom_schedules.json"
-
-	ScheduledEvents = []schedule{ //NIL
-		{
-			Name:       "updateweeklyattendance",
-			HourMinute: "12:00",
-			Weekday:    time.Friday,
-			Interval:   7,
-		},
-		/*
-			{
-				Name:       "sign1",
-				HourMinute: "20:00",
-				Weekday:    time.Friday,
-				Interval:   7,
-			},
-			{
-				Name:       "sign2",
-				HourMinute: "15:00",
-				Weekday:    time.Sunday,
-				Interval:   7,
-			},
-			{
-				Name:       "cleanup",
-				HourMinute: "19:45",
-				Weekday:    time.Thursday,
-				Interval:   7,
-			},
-		*/
-	}
+	customEventTemplatesPath = baseCachePath + "custom_event_templates.json"
 
 	mapOfTokens = map[string]string{
 		"Bot":           "",
@@ -1512,9 +2025,8 @@ var (
 		"Revenge",
 	}
 
-	mapOfRoles = make(map[string]string)
 
-	BotSessionMain = &discordgo.Session{}
+	BotSessionMain discordSession
 	
 
 	greenColor  = 0x00FF00 // Pure Green
@@ -1523,48 +2035,23 @@ var (
 	blueColor   = 0x0000FF // Pure Blue
 
 	raiderCacheMutex       sync.Mutex
+	raiderProfilesMutexes  sync.Map //Guild ID to the *sync.Mutex held for every read-modify-write of the raider profiles of the guild
 	raidHelperCascheMutex  sync.Mutex
 	postTrackMutex         sync.Mutex
-	errorLogMutex          sync.Mutex
 	configCacheMutex       sync.Mutex
-	MapOfUserDefinedAlerts sync.Map
+	guildConfigMutex       sync.RWMutex
 
 	GuildStartTime time.Time
 )
 
 const (
-	serverID            = "630793944632131594"
-	warcraftLogsGuildID = 773986
 	botName             = "raid-automater"
 	guildName           = "Hardened"
-	channelInfo         = "1308521695564402899"
-	channelFeedback     = "1441245331214958625"
-	channelLog          = "1318700380900823103"
-	channelGeneral      = "1308521052036530291"
-	channelGearCheck    = "1396200186208063608"
-	channelVoting       = "1316379489906855936"
-	channelSignUp       = "1346922479951675483"
-	channelSignUpPug    = "1334949433208606791"
-	channelSignUpNaxx   = "1418598263782899832"
-	channelWelcome      = "1309312094822203402"
-	channelBot          = "1336098468615426189"
-	channelServerRules  = "1309545568925650974"
-	channelOfficer      = "1308522605065539714"
 
 	channelNameAnnouncement = "bot-assistance-🤖"
 
 	googleSheetBaseURL = "https://docs.google.com/spreadsheets/d/1wlRwuKusSL01MReBgpbFXyat13LMZ6dtlgk5aN4Ruq0"
 
-	channelDruid   = "667826282678976515"
-	channelHunter  = "667826319333130260"
-	channelMage    = "1308522161916481667"
-	channelPaladin = "667826420956921856"
-	channelPriest  = "667826515601391646"
-	channelRogue   = "667826598678102023"
-	channelWarlock = "1308522389176324139"
-	channelWarrior = "1308522446500008006"
-	channelShaman = "1440432785155424438"
-
 	raidingChannelSubString = "signup"
 
 	crackedBuiltin     = "<:cracked:1312847304725893190>"
@@ -1576,37 +2063,7 @@ const (
 	mc                 = "<:mc:1355865300951892008>"
 	bwl                = "<:bwl:1355867897431593000>"
 
-	categoryBot        = "1336097759073140920"
-	categoryAssistance = "1465470731893866526"
-
-	roleTemp        = "1335442062459535371"
-	rolePuggie      = "1335028472770461847"
-	roleTrial       = "1335374757847109768"
-	roleGuildMember = "651796644739940369"
-	roleRaider      = "1322681249294323814"
-	roleWarrior     = "1314531430122131550"
-	rolePaladin     = "1314531854031913000"
-	roleRogue       = "1314532022945054751"
-	roleHunter      = "1314532209759359056"
-	roleMage        = "1314532563796361227"
-	roleWarlock     = "1314532784529866843"
-	roleDruid       = "1314533133688897577"
-	rolePriest      = "1314533393614241832"
-
-	roleOfficer    = "1309512897612746752"
-	roleRaidLeader = "1308525656031625317"
-
-	officerGMArlissa = "346353264461217795/Arlissa"
-	officerPriest    = "812709542554370098/Throyn"
-	officerRogue     = "655113437327917065/Akasuna"
-	officerWarrior   = "626123398681985054/Joebaldo"
-	officerMage      = "232480016854679553/Dumblydore"
-	officerDruid     = "231066682842415105/Sleepybear"
-
-	officialLogger1 = "276387587155820544" //Zyrtek
-
-	raidHelperEventBaseURL = "https://raid-helper.dev/api/v2/events/"
-	raidHelperId           = "579155972115660803"
+	raidHelperId = "579155972115660803"
 
 	baseCachePath = "./"
 
@@ -1633,17 +2090,16 @@ const (
 
 func init() {
 	fmt.Println("THIS IS VERSION 1.2.0")
-	CheckRuntime()
 }
 
 func CheckRuntime() {
-	err := os.WriteFile(logWarningsName, []byte{}, 0644)
+	err := InitializeLoggers()
 	if err != nil {
-		log.Fatalf("An error occured while trying to create file %s Please make sure the program has write access to the folder, error is: %s", logWarningsName, err)
+		log.Fatalf("An error occured while trying to create the log files %s and %s Please make sure the program has write access to the folder, error is: %s", informationLogPath, errorLogPath, err)
 	}
-	err = os.WriteFile(logErrorName, []byte{}, 0644)
+	err = StartLogShipper()
 	if err != nil {
-		log.Fatalf("An error occured while trying to create file %s Please make sure the program has write access to the folder, error is: %s", logErrorName, err)
+		log.Fatalf("An error occured while trying to handle the storage setup for the application %s", err.Error())
 	}
 	time.Sleep(5 * time.Second)
 
@@ -1655,63 +2111,39 @@ func CheckRuntime() {
 	WriteInformationLog("Class config successfully imported during start-up", "Import Class config")
 	ImportServerJoinConfig()
 	WriteInformationLog("Server join config successfully imported during start-up", "Import Player join config")
-
-	azCred, err := azidentity.NewDefaultAzureCredential(nil)
-	if err != nil {
-		WriteErrorLog("An error occured while trying to retrieve the default system assigned managed identity: Inside function CheckRuntime", err.Error())
-	} else {
-		WriteInformationLog("Default system assigned identity successfully retrieved during start-up", "Import Identity")
+	if err := ImportGuildConfig(); err != nil {
+		WriteErrorLog("An error occured while trying to import the guild config during start-up, the program will stop...", err.Error())
+		log.Fatalf("The guild config could not be imported, please fix the file on path %s and start the bot again, error is: %s", guildConfigPath, err)
 	}
-
-	azKeyvaultClient, err := azsecrets.NewClient(KeyvaultConfig.Name, azCred, nil)
-
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to start a new key vault client to: %s", KeyvaultConfig.Name), err.Error())
-	} else {
-		WriteInformationLog("Keyvault client successfully established during start-up", "Import Keyvault client")
+	if err := ImportRaidCatalog(); err != nil {
+		WriteErrorLog("An error occured while trying to import the raid catalog during start-up, the program will stop...", err.Error())
+		log.Fatalf("The raid catalog could not be imported, please fix the file on path %s and start the bot again, error is: %s", raidCatalogPath, err)
 	}
-
-	for _, tokenConfig := range KeyvaultConfig.Tokens {
-		secret, err := azKeyvaultClient.GetSecret(context.TODO(), tokenConfig.Name, "", nil)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the specific secret for %s", tokenConfig.Name), err.Error())
-		} else {
-			mapOfTokens[tokenConfig.Name] = *secret.Value
-		}
+	if err := ImportStorageConfig(); err != nil {
+		WriteErrorLog("An error occured while trying to set up the storage backend during start-up, the program will stop...", err.Error())
+		log.Fatalf("The storage backend could not be set up, please fix the file on path %s and start the bot again, error is: %s", storagePath, err)
+	}
+	if err := MigrateLegacyTimeStrings(); err != nil {
+		WriteErrorLog("An error occured while trying to convert the cached times to the time zone of the guild during start-up, the program will stop...", err.Error())
+		log.Fatalf("The cached times could not be converted to the time zone of the guild, please check the storage and start the bot again, error is: %s", err)
 	}
 
-	marshal := GetHttpResponseData("POST", "", "https://www.warcraftlogs.com/oauth/token", nil, true)
-	if mapOfToken, ok := marshal.(map[string]any); ok {
-		for attributeName, value := range mapOfToken {
-			if attributeName == "access_token" {
-				mapOfTokens["warcraftLogsRefreshToken"] = value.(string)
-			}
-		}
+	if err := ResolveSecrets(KeyvaultConfig); err != nil {
+		WriteErrorLog("An error occured while trying to resolve the secrets during start-up, the program will stop...", err.Error())
+		log.Fatalf("The secrets could not be resolved, please check the providers in %s and start the bot again, error is: %s", keyvaultPath, err)
 	}
 
-	if _, ok := mapOfTokens["warcraftLogsRefreshToken"]; !ok {
-		log.Fatalf("The warcraftlogs token could not be obtained and therefor the application must stop. See the error log at %s during startup", errorLogPath)
+	raidHelperCurrent = NewRaidHelperClient(mapOfTokens["raidHelperToken"])
+	warcraftLogsCurrent = NewWarcraftLogsClient(warcraftLogsAppID, mapOfTokens["raidHelperToken"])
+	if baseURL := os.Getenv(warcraftLogsBaseURLEnv); baseURL != "" {
+		warcraftLogsCurrent.SetBaseURL(baseURL)
+		WriteWarningLog(fmt.Sprintf("The warcraftlogs API has been replaced by %s from the environment variable %s", baseURL, warcraftLogsBaseURLEnv), "Warcraftlogs base URL")
 	}
-	customSchedules := RetrieveCustomSchedules()
-	if len(customSchedules) > 0 {
-		if !(len(customSchedules) == 1 && customSchedules[0].Name == "empty") {
-			removeEmpty := []schedule{}
-			for x, schedule := range customSchedules {
-				if schedule.Name != "empty" {
-					customSchedules[x].Weekday = time.Weekday(schedule.WeekdayInt)
-					removeEmpty = append(removeEmpty, customSchedules[x])
-				}
-			}
-			ScheduledEvents = append(ScheduledEvents, removeEmpty...)
-		}
-	} else {
-		WriteInformationLog("No custom schedules found, continuing...", "No custom schedules")
+	if _, err := warcraftLogsCurrent.GetAccessToken(true); err != nil {
+		WriteErrorLog("An error occured while trying to obtain the warcraftlogs token during start-up, the program will stop...", err.Error())
+		log.Fatalf("The warcraftlogs token could not be obtained and therefor the application must stop. See the error log at %s during startup", errorLogPath)
 	}
 
-	//err = StorageAccountAppendBlob(logWarningsName, azureContainerName, logWarningsName, StorageAccountClient(azureStorageURI), azContext)
-	if err != nil {
-		//log.Fatalf("An error occured while trying to handle the storage setup for the application %s", err.Error())
-	}
 
 	/*
 		Test different required connections for bot:
@@ -1719,7 +2151,7 @@ func CheckRuntime() {
 		Raid-helper API
 		WarcraftLogs API
 	*/
-	WriteInformationLog(fmt.Sprintf("Bot %s successfully established a connection with server id %s", botName, serverID), "Connect to Discord")
+	WriteInformationLog(fmt.Sprintf("Bot %s successfully established a connection with server ids %s", botName, strings.Join(GetGuildIDs(), ", ")), "Connect to Discord")
 	//RetriveRaidHelperEvent(BotSessionMain, true)
 	WriteInformationLog(fmt.Sprintf("Bot %s successfully established a connection with the raid-helper API", botName), "Connect to Raid-helper")
 	WriteInformationLog("The system is OK to start - Running main() in 5 seconds...", "System-startup OK")
@@ -1727,10 +2159,19 @@ func CheckRuntime() {
 }
 
 func main() {
+	CheckRuntime()
+	defer CloseLogShipper() //Deferred first so the logs from closing the session and storage are shipped as well
 	BotSessionMain = NewDiscordSession(false)
 	defer BotSessionMain.Close()
+	if len(os.Args) > 1 && os.Args[1] == syncCommandsCommand {
+		exitCode := RunSyncSlashCommands(BotSessionMain, os.Args[2:]) //Exits before the bot starts handling events
+		BotSessionMain.Close()
+		CloseLogShipper() //os.Exit() skips the deferred calls
+		os.Exit(exitCode)
+	}
+	defer storageCurrent.Close()
 	var err error
-	GuildStartTime, err = time.Parse(timeLayout, timeGuildStarted)
+	GuildStartTime, err = ParseGuildTime(timeLayout, timeGuildStarted)
 	if err != nil {
 		WriteErrorLog("An error occured while trying to parse the guilds start-time as time.Time type, during main(), the program will stop...", err.Error())
 		log.Fatalf("The guild-start-time of '%s' Is not valid, please set the constant 'timeGuildStarted' In format '%s'", timeGuildStarted, timeLayout)
@@ -1739,15 +2180,21 @@ func main() {
 	/*
 		SIGNALS BELOW
 	*/
-	raidChannelIDs = RetrieveSubsetDiscordChannels(raidingChannelSubString)
+	for _, guildID := range GetGuildIDs() {
+		mapOfRaidChannelIDs[guildID] = RetrieveSubsetDiscordChannels(guildID, raidingChannelSubString)
+	}
 	NewPlayerJoin(BotSessionMain)
 	//NotifyPlayerRaidQuestion((PrepareTemplateWithEmojie(messageTemplates["Ask_raider_direct_question_douse"])), BotSessionMain)
 	//AutoTrackRaidEvents(BotSessionMain)
 	
-	NewSlashCommand(BotSessionMain)
-	UseSlashCommand(BotSessionMain) //Contains go-routines
+	slashCommands := NewSlashCommandRegistry()
+	err = SyncSlashCommands(BotSessionMain, slashCommands, false)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sync the slash commands with Discord, during main(), the bot continues with the commands already registered", err.Error())
+	}
+	UseSlashCommand(BotSessionMain, slashCommands) //Contains go-routines
+	go RunReminderScheduler(BotSessionMain, reminderSchedulerInterval)
 	
-	DeleteOldSlashCommand(BotSessionMain)
 	CalculateRaidWeightsProcent()
 	
 	go AutoTrackPosts()
@@ -1756,43 +2203,18 @@ func main() {
 	AutoUpdateRaidLogCache(BotSessionMain, []string{})
 	go DeleteOldBotChannels(1, 30, BotSessionMain)
 
-	if profiles := ReadWriteRaiderProfiles(nil, true); len(profiles) == 0 {
-		InitializeDiscordProfiles(InitializeRaiderProfiles(), BotSessionMain, true) //Retrieve ALL raiders from ANY time since the guild startet logging
-	}
-	existing := CheckForExistingCache(raidAllDataPath)
-	logs := []logAllData{}
-	json.Unmarshal(existing, &logs)
-	for x, raid := range logs {
-		fmt.Println(x, "raid name:", raid.RaidTitle)
-	}
-	customSchedules := RetrieveCustomSchedules()
-	if len(customSchedules) > 0 {
-		if !(len(customSchedules) == 1 && customSchedules[0].Name == "empty") {
-			removeEmpty := []schedule{}
-			for x, schedule := range customSchedules {
-				if schedule.Name != "empty" {
-					customSchedules[x].Weekday = time.Weekday(schedule.WeekdayInt)
-					removeEmpty = append(removeEmpty, customSchedules[x])
-				}
-			}
-			ScheduledEvents = append(ScheduledEvents, removeEmpty...)
+	for _, guildID := range GetGuildIDs() {
+		if profiles := ReadWriteRaiderProfiles(guildID, nil, true); len(profiles) == 0 {
+			InitializeDiscordProfiles(guildID, InitializeRaiderProfiles(guildID), BotSessionMain, true) //Retrieve ALL raiders from ANY time since the guild startet logging
+		}
+		logs, _ := storageCurrent.ReadRaids(guildID, time.Time{})
+		for x, raid := range logs {
+			fmt.Println(guildID, x, "raid name:", raid.RaidTitle)
 		}
-	} else {
-		WriteInformationLog("No custom schedules found, continuing...", "No custom schedules")
 	}
 
 	//NotifyPlayerRaidPlan(BotSessionMain)
-	for x, taskSchedule := range ScheduledEvents {
-		fmt.Println(x, " -- ", "Task:", taskSchedule, "Time", taskSchedule.HourMinute, "Day", taskSchedule.Weekday.String())
-		switch taskSchedule.Name {
-		case "updateweeklyattendance":
-			{
-				RunAtSpecificTime(func() {
-					WriteInformationLog(AddWeeklyRaiderAttendance(), "Updating weekly attendance")
-				}, taskSchedule, false)
-			}
-		}
-	}
+	StartJobScheduler(BotSessionMain)
 	//fmt.Println(len(GetAllWarcraftLogsRaidData(false, true)))
 	//Since we are running inside a PaaS service, we will never stop unless forced
 
@@ -1804,17 +2226,18 @@ func main() {
 }
 
 func CreateTwoWayChannelCommunication() {
-	BotSessionMain.AddHandler(func(session *discordgo.Session, message *discordgo.MessageCreate){
-		channel, err := session.State.Channel(message.ChannelID)
+	BotSessionMain.AddHandler(func(session discordSession, message *discordgo.MessageCreate){
+		guild := GetGuildConfig(message.GuildID)
+		channel, err := session.StateChannel(message.ChannelID)
 		playerID := message.Author.ID
-		playerName := ResolvePlayerID(playerID , BotSessionMain)
+		playerName := ResolvePlayerID(guild.ServerID, playerID , BotSessionMain)
 		if err != nil {
 			channel, err = session.Channel(message.ChannelID)
 			if err != nil {
 				return
 			}
 		}
-		if message.Author.ID == session.State.User.ID || channel.Type != discordgo.ChannelTypeDM && channel.Type != discordgo.ChannelTypeGuildPublicThread {
+		if message.Author.ID == session.BotUserID() || channel.Type != discordgo.ChannelTypeDM && channel.Type != discordgo.ChannelTypeGuildPublicThread {
 			return
 		}
 		fmt.Println("CHANNEL NAME", channel.Name, channel.Type)
@@ -1879,18 +2302,18 @@ func CreateTwoWayChannelCommunication() {
 				}
 				feedbackResponse(player.RespondChannelID, strings.Join(feedbackResponseContentSlice[2:], " "))
 			}
-			case channel.Type == discordgo.ChannelTypeGuildPublicThread && channel.ParentID == channelFeedback: {
+			case channel.Type == discordgo.ChannelTypeGuildPublicThread && channel.ParentID == guild.Channels.Feedback: {
 				playerSlice := FindSpecificPlayerChannels(ReadWritePlayerChannels(), channel.ID)
 				if len(playerSlice) == 0 {
 					return
 				}
 				if strings.Contains(strings.ToLower(message.Content), "internal") {
-					WriteInformationLog(fmt.Sprintf("The officer with id %s and name %s responded to a discord feedback thread but does not wont it to be sent to the user, thread id %s and name %s, during the function CreateTwoWayChannelCommuncation()",message.Author.ID,ResolvePlayerID(message.Author.ID, session), channel.ID, channel.Name), "No response sent")
+					WriteInformationLog(fmt.Sprintf("The officer with id %s and name %s responded to a discord feedback thread but does not wont it to be sent to the user, thread id %s and name %s, during the function CreateTwoWayChannelCommuncation()",message.Author.ID,ResolvePlayerID(guild.ServerID, message.Author.ID, session), channel.ID, channel.Name), "No response sent")
 					return
 				}
-				_, err = session.ChannelMessageSend(playerSlice[0].UserChannelID, fmt.Sprintf("The officer %s has responded on feedback topic: %s\n\n%s", ResolvePlayerID(message.Author.ID, session), playerSlice[0].FriendlyName, message.Content))
+				_, err = session.ChannelMessageSend(playerSlice[0].UserChannelID, fmt.Sprintf("The officer %s has responded on feedback topic: %s\n\n%s", ResolvePlayerID(guild.ServerID, message.Author.ID, session), playerSlice[0].FriendlyName, message.Content))
 				if err != nil {
-					WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user with id %s and name %s from officer %s in thread with name %s, during the function CreateTwoWayChannelCommunication()", playerSlice[0].RaiderDiscordID, playerSlice[0].RaiderName, ResolvePlayerID(message.Author.ID, session), channel.Name), err.Error())
+					WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user with id %s and name %s from officer %s in thread with name %s, during the function CreateTwoWayChannelCommunication()", playerSlice[0].RaiderDiscordID, playerSlice[0].RaiderName, ResolvePlayerID(guild.ServerID, message.Author.ID, session), channel.Name), err.Error())
 					_, err = session.ChannelMessageSend(playerSlice[0].RespondChannelID, fmt.Sprintf("Was not possible to sent message to raider %s due to error %s", playerSlice[0].RaiderName, err.Error()))
 					if err != nil {
 						WriteErrorLog(fmt.Sprintf("An error occured while trying to let officers know that a feedback response did not get to the user with id %s and name %s, during the function CreateTwoWayChannelCommunication()", playerSlice[0].RaiderDiscordID, playerSlice[0].RaiderName), err.Error())
@@ -1909,11 +2332,12 @@ func FormatChannelSubsetResponseString(message string, channelIDs []string) []st
 	return returnSlice
 }
 
-func RetrieveSubsetDiscordChannels(subString string) []string {
-	allChannels, err := BotSessionMain.GuildChannels(serverID)
+func RetrieveSubsetDiscordChannels(guildID string, subString string) []string {
+	guild := GetGuildConfig(guildID)
+	allChannels, err := BotSessionMain.GuildChannels(guild.ServerID)
 	currentChannels := []string{}
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels from discord server %s, during the function DeleteOldBotChannels()", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels from discord server %s, during the function DeleteOldBotChannels()", guild.ServerID), err.Error())
 	}
 	for _, discordChan := range allChannels {
 		if strings.Contains(discordChan.Name, subString) {
@@ -1921,7 +2345,7 @@ func RetrieveSubsetDiscordChannels(subString string) []string {
 		}
 	}
 	if len(currentChannels) == 0 {
-		WriteInformationLog(fmt.Sprintf("The length of found channels is 0, which means multiple related bot operations cannot function properly - Please make sure channels are created containing %s as part of the name, on server with ID: %s, during the function RetrieveSignUpDiscordChannels()", subString, serverID), "No channels found")
+		WriteInformationLog(fmt.Sprintf("The length of found channels is 0, which means multiple related bot operations cannot function properly - Please make sure channels are created containing %s as part of the name, on server with ID: %s, during the function RetrieveSignUpDiscordChannels()", subString, guild.ServerID), "No channels found")
 	}
 	return currentChannels
 }
@@ -1940,17 +2364,24 @@ func CalculateRaidWeightsProcent() {
 	}
 }
 
-func SplitOfficerName(officerName string) map[string]string {
-	mapOfNames := make(map[string]string)
-	splitNames := strings.Split(officerName, "/")
-	if len(splitNames) != 2 {
-		WriteErrorLog(fmt.Sprintf("This function will only accept values of type string in format name/id but got %s, during the function SplitOfficerName()", officerName), "Wrong format of string")
-		mapOfNames[officerName] = officerName
-		return mapOfNames
+func GetGuildMaster(guildID string) guildOfficer {
+	for _, officer := range GetGuildConfig(guildID).Officers {
+		if officer.GuildMaster {
+			return officer
+		}
+	}
+	WriteErrorLog(fmt.Sprintf("No officer for server %s in the guild config on path %s has the flag guildMaster set to true, during the function GetGuildMaster()", guildID, guildConfigPath), "Missing guild master")
+	return guildOfficer{}
+}
+
+func GetClassLeader(guildID string, className string) guildOfficer {
+	for _, officer := range GetGuildConfig(guildID).Officers {
+		if officer.ClassLeader != "" && strings.EqualFold(officer.ClassLeader, className) {
+			return officer
+		}
 	}
-	mapOfNames["ID"] = splitNames[0]
-	mapOfNames["Name"] = splitNames[1]
-	return mapOfNames
+	WriteInformationLog(fmt.Sprintf("No class leader found for class %s in the guild config, the guild master will be used instead, during the function GetClassLeader()", className), "Class leader not found")
+	return GetGuildMaster(guildID)
 }
 
 func DefineFeedbackOptionsForTemplate() []*discordgo.ApplicationCommandOptionChoice {
@@ -1965,7 +2396,9 @@ func DefineFeedbackOptionsForTemplate() []*discordgo.ApplicationCommandOptionCho
 	return returnCommandOptionChoice
 }
 
-func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
+func ManageMergedGroups(guildID string, session discordSession, syncType string) []string {
+	guild := GetGuildConfig(guildID)
+	mapOfRoles := make(map[string]string)
 	mapOfPlayers := make(map[string]bool)
 	mapOfPlayerStatus := make(map[string]bool)
 	mapOfDeletedRoles := make(map[string]bool)
@@ -1974,10 +2407,10 @@ func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
 	countMerged := 0
 	countNotMerged := 0
 
-	rolesAll, err := session.GuildRoles(serverID)
+	rolesAll, err := session.GuildRoles(guild.ServerID)
 	if err != nil {
 		WriteErrorLog("An error occured while trying to retrive all guild roles, during the function ResolveGroupName()", err.Error())
-		return []string{fmt.Sprintf("An internal error occured - Please contact %s", SplitOfficerName(officerGMArlissa)["Name"])}
+		return []string{fmt.Sprintf("An internal error occured - Please contact %s", GetGuildMaster(guildID).Name)}
 	}
 
 	for _, role := range rolesAll {
@@ -1995,20 +2428,20 @@ func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
 		for _, mergedGroupName := range rootRoleNames {
 			roleID := mapOfRoles[mergedGroupName]
 			if roleID != "" && !mapOfDeletedRoles[mergedGroupName] {
-				err := session.GuildRoleDelete(serverID, roleID)
+				err := session.GuildRoleDelete(guild.ServerID, roleID)
 				if err != nil {
 					WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel %s, during the function ManageMergedGroups()", mergedGroupName), err.Error())
-					return []string{fmt.Sprintf("An internal error occured - Please contact %s")}
+					return []string{fmt.Sprintf("An internal error occured - Please contact %s", GetGuildMaster(guildID).Name)}
 				}
 				mentionable := true
-				newRole, err := session.GuildRoleCreate(serverID, &discordgo.RoleParams{
+				newRole, err := session.GuildRoleCreate(guild.ServerID, &discordgo.RoleParams{
 					Name:        mergedGroupName,
 					Mentionable: &mentionable,
 					Color:       mapOfMergedGroups[mergedGroupName].ColorOfRole,
 				})
 				if err != nil {
 					WriteErrorLog(fmt.Sprintf("An error occured while trying to create channel %s, during the function ManageMergedGroups()", mergedGroupName), err.Error())
-					return []string{fmt.Sprintf("An internal error occured - Please contact %s", SplitOfficerName(officerGMArlissa)["Name"])}
+					return []string{fmt.Sprintf("An internal error occured - Please contact %s", GetGuildMaster(guildID).Name)}
 				}
 				WriteInformationLog(fmt.Sprintf("The channel %s has been successfully created, during the function ManageMergedGroups()", mergedGroupName), "Successfully creating discord channel")
 				mapOfDeletedRoles[mergedGroupName] = true
@@ -2017,18 +2450,18 @@ func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
 		}
 	}
 
-	raidMembers := RetrieveUsersInRole([]string{roleRaider, roleTrial}, session) //SLICE OF IDS
+	raidMembers := RetrieveUsersInRole(guildID, []string{guild.Roles.Raider, guild.Roles.Trial}, session) //SLICE OF IDS
 
-	raidCache, err := ReadRaidDataCache((time.Now().Add(-lookbackDuration)), true)
+	raidCache, err := ReadRaidDataCache(guildID, (time.Now().Add(-lookbackDuration)), true)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the cache %s, during the function ManageMergedGroups()", raidAllDataPath), err.Error())
-		return []string{fmt.Sprintf("An internal error occured - Please contact %s", SplitOfficerName(officerGMArlissa)["Name"])}
+		return []string{fmt.Sprintf("An internal error occured - Please contact %s", GetGuildMaster(guildID).Name)}
 	}
 	for _, log := range raidCache {
 		for _, player := range log.Players {
 			if !mapOfPlayers[player.Name] {
 				raider := false
-				raiderDiscordID := ResolvePlayerName(player.Name, session)
+				raiderDiscordID := ResolvePlayerName(guildID, player.Name, session)
 				if slices.Contains(raidMembers, raiderDiscordID) {
 					raider = true
 					count++
@@ -2043,7 +2476,7 @@ func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
 				for _, spec := range player.Specs {
 					if spec.TypeRole != "dps" && raider {
 						mapOfPlayerStatus[player.Name] = raider
-						err := session.GuildMemberRoleAdd(serverID, ResolvePlayerName(player.Name, session), mapOfRoles[spec.TypeRole])
+						err := session.GuildMemberRoleAdd(guild.ServerID, ResolvePlayerName(guildID, player.Name, session), mapOfRoles[spec.TypeRole])
 						if err != nil {
 							WriteErrorLog(fmt.Sprintf("An error occured while trying to add player: %s to group %s, during the function ManageMergedGroups()", player.Name, spec.TypeRole), err.Error())
 							continue
@@ -2084,46 +2517,48 @@ func ManageMergedGroups(session *discordgo.Session, syncType string) []string {
 	return []string{returnString.String(), "Completed successfully"}
 }
 
-func DeleteOldBotChannels(timeInMinutes int, maxAwaitInMinutes int, session *discordgo.Session) {
+func DeleteOldBotChannels(timeInMinutes int, maxAwaitInMinutes int, session discordSession) {
 	timeInterval := time.Duration(timeInMinutes) * time.Minute
 	timeTicker := time.NewTicker(timeInterval)
 	defer timeTicker.Stop()
 	for range timeTicker.C {
-		playersInTempRole := RetrieveUsersInRole([]string{roleTemp}, session)
-		botChannels, err := session.GuildChannels(serverID)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels from discord server %s, during the function DeleteOldBotChannels()", serverID), err.Error())
-		}
-		timeNow := time.Now()
-		for _, channel := range botChannels {
-			if strings.Contains(channel.Name, "automatic-") {
-				allChannelMessages, _ := session.ChannelMessages(channel.ID, 100, "", "", "")
-				timeLastMessage := allChannelMessages[0].Timestamp
-				timeDuration := timeNow.Sub(timeLastMessage)
-				if timeDuration > time.Duration(maxAwaitInMinutes)*time.Minute {
-					_, err := session.ChannelDelete(channel.ID)
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel %s %s, during the function DeleteOldBotChannels()", channel.ID, channel.Name), err.Error())
-						continue
-					}
-					WriteInformationLog(fmt.Sprintf("The bot channel was successfully deleted - %s %s, during the function DeleteOldBotChannels()", channel.ID, channel.Name), "Successfully deleted channel")
-					regexID := regexp.MustCompile(`<@(\d+)>`)
-					messageContent := allChannelMessages[len(allChannelMessages)-2].Content //The last element in the array is empty - Second last is the actual message
-					playerIDSlice := regexID.FindStringSubmatch(messageContent)
-					if len(playerIDSlice) < 1 {
-						WriteErrorLog(fmt.Sprintf("No player ID was found in the first message from the bot, so the user cannot be deleted - Message %s, during the function DeleteOldBotChannels()", messageContent), err.Error())
-						continue
-					}
-					playerName := ResolvePlayerID(playerIDSlice[1], session)
-					for _, userID := range playersInTempRole {
-						if userID == playerIDSlice[1] {
-							err = session.GuildMemberDelete(serverID, userID)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to delete the user %s, during the function DeleteOldBotChannels()", playerName), err.Error())
+		for _, guild := range GetAllGuildConfigs() {
+			playersInTempRole := RetrieveUsersInRole(guild.ServerID, []string{guild.Roles.Temp}, session)
+			botChannels, err := session.GuildChannels(guild.ServerID)
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels from discord server %s, during the function DeleteOldBotChannels()", guild.ServerID), err.Error())
+			}
+			timeNow := time.Now()
+			for _, channel := range botChannels {
+				if strings.Contains(channel.Name, "automatic-") {
+					allChannelMessages, _ := session.ChannelMessages(channel.ID, 100, "", "", "")
+					timeLastMessage := allChannelMessages[0].Timestamp
+					timeDuration := timeNow.Sub(timeLastMessage)
+					if timeDuration > time.Duration(maxAwaitInMinutes)*time.Minute {
+						_, err := session.ChannelDelete(channel.ID)
+						if err != nil {
+							WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel %s %s, during the function DeleteOldBotChannels()", channel.ID, channel.Name), err.Error())
+							continue
+						}
+						WriteInformationLog(fmt.Sprintf("The bot channel was successfully deleted - %s %s, during the function DeleteOldBotChannels()", channel.ID, channel.Name), "Successfully deleted channel")
+						regexID := regexp.MustCompile(`<@(\d+)>`)
+						messageContent := allChannelMessages[len(allChannelMessages)-2].Content //The last element in the array is empty - Second last is the actual message
+						playerIDSlice := regexID.FindStringSubmatch(messageContent)
+						if len(playerIDSlice) < 1 {
+							WriteErrorLog(fmt.Sprintf("No player ID was found in the first message from the bot, so the user cannot be deleted - Message %s, during the function DeleteOldBotChannels()", messageContent), err.Error())
+							continue
+						}
+						playerName := ResolvePlayerID(guild.ServerID, playerIDSlice[1], session)
+						for _, userID := range playersInTempRole {
+							if userID == playerIDSlice[1] {
+								err = session.GuildMemberDelete(guild.ServerID, userID)
+								if err != nil {
+									WriteErrorLog(fmt.Sprintf("An error occured while trying to delete the user %s, during the function DeleteOldBotChannels()", playerName), err.Error())
+									break
+								}
+								WriteInformationLog(fmt.Sprintf("Successfully kicked user: %s", playerName), "Successfully deleted user")
 								break
 							}
-							WriteInformationLog(fmt.Sprintf("Successfully kicked user: %s", playerName), "Successfully deleted user")
-							break
 						}
 					}
 				}
@@ -2232,18 +2667,18 @@ func NewInteractionResponseToSpecificCommand(logType int, data string, discordTy
 	return templateCopy
 }
 
-func GetAllWarcraftLogsRaidData(inMem bool, newestOne bool, logCode string, botInfo ...any) []logAllData {
-	//time.Sleep(30 * time.Second)
-	quriesToRun := []map[string]any{}
+// Only fetches the reports that are new or still growing since the last run, see the function ListChangedWarcraftLogsReports().
+// Without any watermarks every report is fetched and the raid cache of the guild is replaced
+func GetAllWarcraftLogsRaidData(guildID string, inMem bool, newestOne bool, logCode string, botInfo ...any) []logAllData {
+	ingestionMutex.Lock()
+	defer ingestionMutex.Unlock()
 	WriteInformationLog("Retrieving warcraftlogs data for query with name: 'guildLogsRaidIDs' during function GetAllWarcraftLogsRaidData()", "Getting Warcraft logs data")
-	allLogsBase := []logsBase{}
-	//time.Sleep(5 * time.Second)
-	innerSession := &discordgo.Session{}
+	var innerSession discordSession
 	event := &discordgo.Interaction{}
 	doStatusCount := 0
 	doStatus := false
 	if len(botInfo) > 1 {
-		if session, ok := botInfo[0].(*discordgo.Session); ok {
+		if session, ok := botInfo[0].(discordSession); ok {
 			innerSession = session
 			doStatusCount++
 		}
@@ -2254,128 +2689,107 @@ func GetAllWarcraftLogsRaidData(inMem bool, newestOne bool, logCode string, botI
 		}
 		if doStatusCount == 2 {
 			doStatus = true
-			fmt.Println("WE WILL DO STATUS")
 		}
 	}
-	x := 1
-	for {
-		pageQuerie := mapOfWarcaftLogsQueries["guildLogsRaidIDs"]
-		if x != 1 || newestOne {
-			pageQuerie = SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["guildLogsRaidIDs"], x)[0]
+	sendStatus := func(message string) {
+		if !doStatus {
+			return
 		}
-		if logs, ok := GetWarcraftLogsData(pageQuerie)["logs"].([]logsBase); ok {
-			if len(logs) == 0 {
-				break
-			}
-			allLogsBase = append(allLogsBase, logs...)
+		interactionResponse := NewInteractionResponseToSpecificCommand(1, message)
+		_, err := innerSession.InteractionResponseEdit(event, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent status message to user during the function GetAllWarcraftLogsRaidData()", err.Error())
 		}
-		x++
 	}
-	mapOfQueries := SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["logsByOwnerAndCode"], allLogsBase)
-	if logCode != "" {
-		for _, mapOfQuery := range mapOfQueries {
-			for key, value := range mapOfQuery {
-				if key == "variables" {
-					for key, value := range value.(map[string]any) {
-						if key == "code" {
-							if value == logCode {
-								quriesToRun = append(quriesToRun, mapOfQuery)
-								break
-							}
-						}
-					}
-				}
-			}
+	readRaids := func() []logAllData {
+		raids, err := storageCurrent.ReadRaids(guildID, time.Time{})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raids for server %s, during function GetAllWarcraftLogsRaidData()", guildID), err.Error())
 		}
-	} else {
-		quriesToRun = mapOfQueries
+		WriteInformationLog(fmt.Sprintf("The following %d of type []logAllData has been found for server %s", len(raids), guildID), "Reading cache")
+		return raids
 	}
-	logsOfAllRaids := []logAllData{}
-	for x, query := range quriesToRun {
-		if doStatus {
-			interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("Progess on job|**Completed %.1f%% so far**", float64(x)/float64(len(quriesToRun))*100))
-			_, err := innerSession.InteractionResponseEdit(event, &discordgo.WebhookEdit{
-				Embeds: &interactionResponse.Data.Embeds,
-			})
-			if err != nil {
-				WriteErrorLog("An error occured while trying to sent status message to user during the function GetAllWarcraftLogsRaidData()", err.Error())
+
+	watermarks, err := storageCurrent.ReadReportWatermarks(guildID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the report watermarks for server %s, no logs are retrieved, during the function GetAllWarcraftLogsRaidData()", guildID), err.Error())
+		return readRaids()
+	}
+	replaceCache := len(watermarks) == 0
+	changedReports, countOfListed, err := ListChangedWarcraftLogsReports(guildID, watermarks)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to list the guild logs for server %s, only the %d logs found so far are retrieved, during the function GetAllWarcraftLogsRaidData()", guildID, len(changedReports)), err.Error())
+		replaceCache = false //Older raids would otherwise be lost from the cache
+	}
+	if logCode != "" {
+		reportsToRun := []logsBase{}
+		for _, report := range changedReports {
+			if report.Code == logCode {
+				reportsToRun = append(reportsToRun, report)
+				break
 			}
 		}
-
-		index := x
-		time.Sleep(1 * time.Second)
-		newQuery := SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["allFightIDsForRaid"], []logsBase{allLogsBase[index]})
-		WriteInformationLog("Retrieving warcraftlogs data for query with name: 'allFightIDsForRaid' during function GetAllWarcraftLogsRaidData()", "Getting Warcraft logs data")
-		if len(newQuery) == 0 {
-			continue
+		if len(reportsToRun) == 0 {
+			WriteInformationLog(fmt.Sprintf("The log %s is already stored with its newest data or was not found, during the function GetAllWarcraftLogsRaidData()", logCode), "Warcraft logs return data", LogField("logCode", logCode))
 		}
-		fightIDs := GetWarcraftLogsData(newQuery[0])
-		newQuery = SetWarcraftLogQueryVariables(quriesToRun[index], fightIDs)
-		WriteInformationLog("Retrieving warcraftlogs data for query with name: 'logsByOwnerAndCode' during function GetAllWarcraftLogsRaidData()", "Getting Warcraft logs data")
+		changedReports = reportsToRun
+		replaceCache = false
+	}
+	if newestOne && len(changedReports) > 1 {
+		WriteInformationLog("The flag of 'newestLog' Was used, therefor only 1 log is returned... During function GetAllWarcraftLogsData()", "Warcraft logs return data")
+		changedReports = changedReports[:1]
+		replaceCache = false
+	}
+	WriteInformationLog(fmt.Sprintf("Found %d new or changed logs out of %d listed logs for server %s, during the function GetAllWarcraftLogsRaidData()", len(changedReports), countOfListed, guildID), "Getting Warcraft logs data", LogField("guildID", guildID))
+	sendStatus(fmt.Sprintf("Progress on job|Found %d new or changed logs out of %d listed logs - Please wait...", len(changedReports), countOfListed))
 
-		log := GetWarcraftLogsData(newQuery[0])
-		if VerifyWarcraftLogData(log) {
-			allDataLogs, _ := UnwrapFullWarcraftLogRaid(log, query)
-			logsOfAllRaids = append(logsOfAllRaids, allDataLogs)
-			//logsOfAllRaids = RetriveRaiderSpecificData(logsOfAllRaids, actorIDs) //Adding data to the existing data
+	results := IngestWarcraftLogsReports(changedReports, func(done int, total int) {
+		sendStatus(fmt.Sprintf("Progess on job|**Completed %.1f%% so far** (%d/%d logs)", float64(done)/float64(total)*100, done, total))
+	})
+	logsOfAllRaids := []logAllData{}
+	for _, result := range results {
+		if result.Err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the log %s, the log is retried on the next run, during the function GetAllWarcraftLogsRaidData()", result.Base.Code), result.Err.Error(), LogField("logCode", result.Base.Code))
+			continue
 		}
-		if newestOne {
-			WriteInformationLog("The flag of 'newestLog' Was used, therefor only 1 log is returned... During function GetAllWarcraftLogsData()", "Warcraft logs return data")
-			break
+		if result.Verified {
+			logsOfAllRaids = append(logsOfAllRaids, result.Raid)
 		}
 	}
-
-	return WriteRaidCache(UpdateClassSpec(logsOfAllRaids))
-}
-
-func WriteRaidCache(logDataSlice []logAllData) []logAllData {
-	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
-	encoder.SetEscapeHTML(false)
-	encoder.SetIndent("", " ")
-	existingLogData := []logAllData{}
-	returnLogAllData := []logAllData{}
-	if len(logDataSlice) == 1 {
-		if logDataBytes := CheckForExistingCache(raidAllDataPath); logDataBytes != nil {
-			json.Unmarshal(logDataBytes, &existingLogData)
-		} else {
-			WriteInformationLog("The slice of logAllData has a length of %d, inside of function WriteRaidCache()", "Writing raid cache")
-		}
+	logsOfAllRaids = UpdateClassSpec(logsOfAllRaids)
+	var writeErr error
+	if replaceCache {
+		writeErr = storageCurrent.WriteRaids(guildID, logsOfAllRaids)
+	} else if len(logsOfAllRaids) > 0 {
+		writeErr = storageCurrent.AddRaids(guildID, logsOfAllRaids)
 	}
-	existingLogData = append(logDataSlice, existingLogData...)
-	sort.Slice(existingLogData, func(i, j int) bool {
-		timeI, _ := time.Parse(timeLayout, existingLogData[i].RaidStartTimeString)
-		timeJ, _ := time.Parse(timeLayout, existingLogData[j].RaidStartTimeString)
-		return timeI.After(timeJ)
-	})
-	err := encoder.Encode(existingLogData)
-	if err != nil {
-		WriteErrorLog("An error occured while trying to json-encode all the raids", err.Error())
+	if writeErr != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write %d raids for server %s, the logs are retrieved again on the next run, during function GetAllWarcraftLogsRaidData()", len(logsOfAllRaids), guildID), writeErr.Error())
+		return readRaids()
 	}
-	err = os.WriteFile(raidAllDataPath, buf.Bytes(), 0644)
-	json.Unmarshal(buf.Bytes(), &returnLogAllData)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to write the json data to path %s during function GetAllWarcraftLogsRaidData()", raidAllDataPath), err.Error())
+	UpdateReportWatermarks(watermarks, results)
+	if err := storageCurrent.WriteReportWatermarks(guildID, watermarks); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write the report watermarks for server %s, during the function GetAllWarcraftLogsRaidData()", guildID), err.Error())
 	}
-	WriteInformationLog(fmt.Sprintf("The following %d of type []logAllData has been found on path %s", len(returnLogAllData), raidAllDataPath), "Reading cache")
-	return returnLogAllData
+	return readRaids()
 }
 
-func RetrieveSpecificEncounterLog(encounterID map[string]int64) []map[string]any {
-	mapOfEncounters := []map[string]any{}
-	mapOfEncounterQueries := SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["logsByEncounterID"], encounterID)
-	for x, mapOfEncounterQuery := range mapOfEncounterQueries {
-		data := GetWarcraftLogsData(mapOfEncounterQuery)
-		if data != nil && data["errors"] == nil {
-			mapOfEncounters = append(mapOfEncounters, data)
-		} else {
-			fmt.Printf("The following map %d had errors \n%s", x, data)
+func RetrieveSpecificEncounterLog(encounterIDs []int64) []warcraftLogsEncounter {
+	encounters := []warcraftLogsEncounter{}
+	for _, encounterID := range encounterIDs {
+		encounter, err := warcraftLogsCurrent.GetEncounter(encounterID)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the encounter %d, during the function RetrieveSpecificEncounterLog()", encounterID), err.Error())
+			continue
 		}
+		encounters = append(encounters, encounter)
 	}
-	return mapOfEncounters
+	return encounters
 }
 
-func UnwrapFullWarcraftLogRaid(mapToUnwrap map[string]any, warcraftLogsQuery map[string]any) (logAllData, []int) {
+func UnwrapFullWarcraftLogRaid(mapToUnwrap map[string]any) (logAllData, []int) {
 	playerLogs := []logPlayer{}
 	mapSemiUnwrapped := map[string]any{} //mapToUnwrap["logs"].(map[string]any)["data"].(map[string]any)["reportData"].(map[string]any)["report"]
 	returnBeforeCleaning := logAllData{}
@@ -2658,27 +3072,6 @@ func UnwrapFullWarcraftLogRaid(mapToUnwrap map[string]any, warcraftLogsQuery map
 			}
 		}
 	}
-	mapOfZoneNames := make(map[string]bool)
-	for _, encounter := range encounterIDs {
-		mapOfEncounter := map[string]any{
-			"encounterID": int64(encounter),
-		}
-		newQuery := SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["logsByEncounterID"], mapOfEncounter)
-		if len(newQuery) > 0 {
-			encounterData := GetWarcraftLogsData(newQuery[0])["encounter"].(map[string]any)
-			if VerifyWarcraftLogData(encounterData) {
-				if mapOfData, ok := encounterData["data"].(map[string]any); ok {
-					if mapOfWorldData, ok := mapOfData["worldData"].(map[string]any); ok {
-						if mapOfEncounter, ok := mapOfWorldData["encounter"].(map[string]any); ok {
-							if mapOfZone, ok := mapOfEncounter["zone"].(map[string]any); ok {
-								mapOfZoneNames[mapOfZone["name"].(string)] = true
-							}
-						}
-					}
-				}
-			}
-		}
-	}
 	raidNames := []string{}
 	raidTitleNamesSlice := []string{}
 	if raidTitle, ok := mapSemiUnwrapped["title"].(string); ok {
@@ -2689,6 +3082,15 @@ func UnwrapFullWarcraftLogRaid(mapToUnwrap map[string]any, warcraftLogsQuery map
 	for _, raidShortName := range raidTitleNamesSlice {
 		raidNames = append(raidNames, RaidNameLongHandConversion(raidShortName))
 	}
+	if strings.Join(raidNames, "") == "" { //The title does not name any raid in the raid catalog, the zone of the log might
+		if mapOfZone, ok := mapSemiUnwrapped["zone"].(map[string]any); ok {
+			if zoneID, ok := mapOfZone["id"].(float64); ok {
+				if raid, ok := GetRaidInstanceByZone(int(zoneID)); ok {
+					raidNames = []string{raid.Name}
+				}
+			}
+		}
+	}
 
 	raidTimeHrs := int(time.Duration(totalRaidTime * float64(time.Millisecond)).Hours())
 	raidTimeMinutes := int((time.Duration(totalRaidTime * float64(time.Millisecond)).Minutes())) % 60
@@ -2701,14 +3103,15 @@ func UnwrapFullWarcraftLogRaid(mapToUnwrap map[string]any, warcraftLogsQuery map
 		RaidTime:             time.Duration(totalRaidTime),
 		RaidTimeString:       fmt.Sprintf("%02d:%02d:%02d", raidTimeHrs, raidTimeMinutes, raidTimeSeconds),
 		RaidStartUnixTime:    unixTime,
-		RaidStartTimeString:  time.UnixMilli(unixTime).Format(timeLayout),
+		RaidStartTimeString:  time.UnixMilli(unixTime).In(GetGuildLocation("")).Format(timeLayout),
 		TotalDeaths:          deathCounter,
 		MetaData: logsBase{
 			LoggerName: mapSemiUnwrapped["owner"].(map[string]any)["name"].(string),
 			Code:       mapSemiUnwrapped["code"].(string),
 		},
-		RaidTitle: mapSemiUnwrapped["title"].(string),
-		RaidNames: raidNames,
+		RaidTitle:  mapSemiUnwrapped["title"].(string),
+		RaidNames:  raidNames,
+		Encounters: UnwrapWarcraftLogEncounters(mapSemiUnwrapped, playerLogs),
 	}
 
 	return returnBeforeCleaning, actorIDs
@@ -2782,51 +3185,6 @@ func VerifyAllLogData(currentLog logAllData) bool {
 	return true
 }
 
-func RetrieveCustomSchedules() []schedule {
-	customSchedules := []schedule{}
-	if customSchedulesBytes := CheckForExistingCache(customSchedulePath); len(customSchedulesBytes) > 0 {
-		err := json.Unmarshal(customSchedulesBytes, &customSchedules)
-		if err != nil {
-			WriteErrorLog("An error occured while trying to unmarshal json content for the custom schedules: Inside function RetrieceCustomSchedules()", err.Error())
-		}
-	}
-
-	for x, customSchedule := range customSchedules {
-		switch customSchedule.Weekday {
-		case 0:
-			{
-				customSchedules[x].Weekday = time.Sunday
-			}
-		case 1:
-			{
-				customSchedules[x].Weekday = time.Monday
-			}
-		case 2:
-			{
-				customSchedules[x].Weekday = time.Tuesday
-			}
-		case 3:
-			{
-				customSchedules[x].Weekday = time.Wednesday
-			}
-		case 4:
-			{
-				customSchedules[x].Weekday = time.Thursday
-			}
-		case 5:
-			{
-				customSchedules[x].Weekday = time.Friday
-			}
-		case 6:
-			{
-				customSchedules[x].Weekday = time.Saturday
-			}
-		}
-	}
-
-	return customSchedules
-}
-
 func GetIntPointer(n int) *int {
 	return &n
 }
@@ -2835,50 +3193,6 @@ func GetStringPointer(s string) *string {
 	return &s
 }
 
-func NewSlashCommand(session *discordgo.Session) {
-	sliceOfSlashCommandMaps := []map[string]applicationCommand{}
-	//sliceOfSlashCommandMaps = append(sliceOfSlashCommandMaps, slashCommandTemplates)
-	sliceOfSlashCommandMaps = append(sliceOfSlashCommandMaps, slashCommandAdminCenter)
-	sliceOfSlashCommandMaps = append(sliceOfSlashCommandMaps, slashCommandAllUsers)
-	for _, slice := range sliceOfSlashCommandMaps {
-		for name, template := range slice {
-			_, err := session.ApplicationCommandCreate(session.State.User.ID, serverID, template.Template)
-			if err != nil {
-				WriteErrorLog(fmt.Sprintf("An error occured while trying to create Slash template: %s inside function NewSlashCommand()", name), err.Error())
-			}
-		}
-	}
-}
-
-func DeleteOldSlashCommand(session *discordgo.Session) {
-	mapOfApplicationCommandsToKeep := make(map[string]bool)
-	for nameOfUserCommand := range slashCommandAllUsers {
-		mapOfApplicationCommandsToKeep[nameOfUserCommand] = false //Initalize keys basically
-	}
-
-	for nameOfAdminCommand := range slashCommandAdminCenter {
-		mapOfApplicationCommandsToKeep[nameOfAdminCommand] = false //Initalize keys basically
-	}
-	botID := session.State.User.ID
-	allBotApplicationCommands, err := session.ApplicationCommands(botID, serverID)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all application commands for bot %s, during the function DeleteOldSlashCommand()", botID), err.Error())
-		return
-	}
-	for _, discordCommand := range allBotApplicationCommands {
-		fmt.Println("NAME OF APP", discordCommand.Name)
-		if _, ok := mapOfApplicationCommandsToKeep[discordCommand.Name]; !ok {
-			//Found channel to delete
-			err = session.ApplicationCommandDelete(botID, serverID, discordCommand.ID)
-			if err != nil {
-				WriteErrorLog(fmt.Sprintf("An error occured while trying to delete application command %s for bot %s, during the function DeleteOldSlashCommand()", discordCommand.Name, botID), err.Error())
-				continue
-			}
-			WriteInformationLog(fmt.Sprintf("Application slashcommand with name %s has been deleted successfully from the server %s, during the function DeleteOldSlashCommand()", discordCommand.Name, serverID), "Successfully deleted slash command")
-		}
-	}
-}
-
 func RoundTwoDecimalsFloat(num float64) float64 {
 	return math.Round(num*100) / 100
 }
@@ -2985,6 +3299,10 @@ func SummarizedMergedRaidLogsMeta(dataLoggedSlice []logAllData) ([]string, strin
 	var lowestRaidTimer int64
 	highestPlayerCount := 0
 	var highestAverageItemLevel float64
+	lowestDeathsCode := ""
+	lowestRaidTimerCode := ""
+	highestPlayerCountCode := ""
+	highestAverageItemLevelCode := ""
 	for x := 1; x <= splitDataFactor; x++ {
 		maxCounter = x * baseToFactor
 		minCounter = maxCounter - baseToFactor
@@ -3003,24 +3321,32 @@ func SummarizedMergedRaidLogsMeta(dataLoggedSlice []logAllData) ([]string, strin
 				sliceOfAveragePlayerCount = append(sliceOfAveragePlayerCount, log.PlayersCount)
 				if z == 0 {
 					lowestDeaths = log.TotalDeaths
+					lowestDeathsCode = log.MetaData.Code
 					lowestRaidTimer = int64(log.RaidTime)
+					lowestRaidTimerCode = log.MetaData.Code
 					highestPlayerCount = log.PlayersCount
+					highestPlayerCountCode = log.MetaData.Code
 					highestAverageItemLevel = log.RaidAverageItemLevel
+					highestAverageItemLevelCode = log.MetaData.Code
 				} else {
 					if log.TotalDeaths < lowestDeaths {
 						lowestDeaths = log.TotalDeaths
+						lowestDeathsCode = log.MetaData.Code
 					}
 
 					if int64(log.RaidTime) < lowestRaidTimer {
 						lowestRaidTimer = int64(log.RaidTime)
+						lowestRaidTimerCode = log.MetaData.Code
 					}
 
 					if log.RaidAverageItemLevel > highestAverageItemLevel {
 						highestAverageItemLevel = log.RaidAverageItemLevel
+						highestAverageItemLevelCode = log.MetaData.Code
 					}
 
 					if log.PlayersCount > highestPlayerCount {
 						highestPlayerCount = log.PlayersCount
+						highestPlayerCountCode = log.MetaData.Code
 					}
 				}
 			}
@@ -3083,7 +3409,7 @@ Average death-rate: **%s**
 Average Item-level of all raiders: **%s**
 
 Average player-count: **%s**
-`, dataLoggedSlice[len(dataLoggedSlice)-1].RaidStartTimeString, dataLoggedSlice[0].RaidStartTimeString, totalNumberOfLogs, antiCrackedBuiltin, percentDifferenceRaidTime, percentDifferenceDeaths, percentDifferenceItemLevel, percentDifferencePlayerCount, FormatDurationFromMilliseconds(CalculateAverageSum(sliceOfAverageRaidTime)), FormatFloatSmart(CalculateAverageSum(sliceOfAverageDeath)), FormatFloatSmart(CalculateAverageSum(sliceOfAverageItemLevel)), FormatFloatSmart(CalculateAverageSum(sliceOfAveragePlayerCount)))
+`, dataLoggedSlice[len(dataLoggedSlice)-1].RaidStartTimeString, dataLoggedSlice[0].RaidStartTimeString, totalNumberOfLogs, antiCrackedBuiltin, FormatDurationFromMilliseconds(float64(lowestRaidTimer)), lowestRaidTimerCode, lowestDeaths, lowestDeathsCode, highestPlayerCount, highestPlayerCountCode, FormatFloatSmart(highestAverageItemLevel), highestAverageItemLevelCode, percentDifferenceRaidTime, percentDifferenceDeaths, percentDifferenceItemLevel, percentDifferencePlayerCount, FormatDurationFromMilliseconds(CalculateAverageSum(sliceOfAverageRaidTime)), FormatFloatSmart(CalculateAverageSum(sliceOfAverageDeath)), FormatFloatSmart(CalculateAverageSum(sliceOfAverageItemLevel)), FormatFloatSmart(CalculateAverageSum(sliceOfAveragePlayerCount)))
 
 		}
 	}
@@ -3133,7 +3459,7 @@ func SummarizeRaidLogMeta(dataLogged logAllData) string {
 	Raids completed: **%s**
 
 	Raid average item level: **%.2f**
-	`, dataLogged.RaidStartTimeString, dataLogged.MetaData.Code, dataLogged.PlayersCount, dataLogged.TotalDeaths, dataLogged.RaidTimeString, strings.Join(dataLogged.RaidNames, " & "), dataLogged.RaidAverageItemLevel)
+	`, dataLogged.RaidStartTimeString, dataLogged.MetaData.Code, dataLogged.PlayersCount, dataLogged.TotalDeaths, dataLogged.RaidTimeString, FormatRaidNames(dataLogged.RaidNames), dataLogged.RaidAverageItemLevel)
 }
 
 func CreateMessageEmbedsLargeLogData(allLogData []logAllData) []*discordgo.MessageEmbed {
@@ -3143,7 +3469,7 @@ func CreateMessageEmbedsLargeLogData(allLogData []logAllData) []*discordgo.Messa
 	embedDataMessage := &discordgo.MessageEmbed{
 		Description: "Please see all calculations of the specific raid type below:",
 		Color:       greenColor,
-		Title:       fmt.Sprintf("For raid type: **%s**", allLogData[0].RaidNames[0]),
+		Title:       fmt.Sprintf("For raid type: **%s**", FormatRaidName(allLogData[0].RaidNames[0])),
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Value: messageDataString,
@@ -3239,7 +3565,7 @@ func NewInteractionOutput(data any, currentResponse *discordgo.InteractionRespon
 	}
 }
 
-func CapturePointInTimeRaidLogData(timeString string, onlyMainRaid bool) ([]logAllData, error) {
+func CapturePointInTimeRaidLogData(guildID string, timeString string, onlyMainRaid bool) ([]logAllData, error) {
 	var finalTimeParsed time.Duration
 	timePassedDefault := 30 * 24 * time.Hour
 	timeStringLower := strings.ToLower(strings.TrimSpace(timeString))
@@ -3266,7 +3592,7 @@ func CapturePointInTimeRaidLogData(timeString string, onlyMainRaid bool) ([]logA
 	}
 
 	dataToParse := time.Now().Add(-finalTimeParsed)
-	currentRaids, err := ReadRaidDataCache(dataToParse, onlyMainRaid)
+	currentRaids, err := ReadRaidDataCache(guildID, dataToParse, onlyMainRaid)
 	fmt.Println("THIS IS THE NUMBER OF OF LOGS:", dataToParse.Format(timeLayout), len(currentRaids))
 	if err != nil {
 		WriteErrorLog("Error reading raid data cache:", err.Error())
@@ -3295,67 +3621,21 @@ func CheckUserBoolResponseFlag(interactionData []*discordgo.ApplicationCommandIn
 }
 
 func RaidNameShortHandConversion(raidName string) string {
-	switch raidName {
-	case "Onyxia":
-		{
-			return "ony"
-		}
-	case "Molten Core":
-		{
-			return "mc"
-		}
-	case "Blackwing Lair":
-		{
-			return "bwl"
-		}
-	case "Zul'Gurub":
-		{
-			return "zg"
-		}
-	case "Temple of Ahn'Qiraj":
-		{
-			return "aq40"
-		}
-	case "Naxxramas":
-		{
-			return "naxx"
-		}
+	if raid, ok := GetRaidInstance(raidName); ok {
+		return raid.ShortName
 	}
 	return ""
 }
 
 func RaidNameLongHandConversion(shortName string) string {
-	switch shortName {
-	case "ony":
-		{
-			return "Onyxia"
-		}
-	case "mc":
-		{
-			return "Molten Core"
-		}
-	case "bwl":
-		{
-			return "Blackwing Lair"
-		}
-	case "zg":
-		{
-			return "Zul'Gurub"
-		}
-	case "aq40":
-		{
-			return "Temple of Ahn'Qiraj"
-		}
-	case "naxx":
-		{
-			return "Naxxramas"
-		}
+	if raid, ok := GetRaidInstance(shortName); ok {
+		return raid.Name
 	}
 	return ""
 }
 
-func NewWarcraftLogsGeneralDataResponse(useOnlyMainRaids bool, periodAsString string) ([]*discordgo.InteractionResponse, error) {
-	currentRaids, err := CapturePointInTimeRaidLogData(periodAsString, useOnlyMainRaids)
+func NewWarcraftLogsGeneralDataResponse(guildID string, useOnlyMainRaids bool, periodAsString string) ([]*discordgo.InteractionResponse, error) {
+	currentRaids, err := CapturePointInTimeRaidLogData(guildID, periodAsString, useOnlyMainRaids)
 	maxBytes := 6000
 	returnInteractionResponses := []*discordgo.InteractionResponse{}
 	//fmt.Println("THIS IS THE OPTION CHOSEN:", useOnlyMainRaids, "DATA", currentRaids, len(currentRaids))
@@ -3437,1854 +3717,1642 @@ func GetDiscordUser(event *discordgo.InteractionCreate) *discordgo.User {
 	return event.Member.User
 }
 
-func UseSlashCommand(session *discordgo.Session) {
-	session.AddHandler(func(innerSession *discordgo.Session, event *discordgo.InteractionCreate) {
-		userID := GetDiscordUser(event).ID
+func UseSlashCommand(session discordSession, registry *slashCommandRegistry) {
+	session.AddHandler(func(innerSession discordSession, event *discordgo.InteractionCreate) {
+		registry.Dispatch(innerSession, event)
+	})
+}
 
-		if event.Type == discordgo.InteractionMessageComponent {
-			innerSession.ChannelMessageDelete(event.ChannelID, event.Message.ID)
-			switch event.MessageComponentData().CustomID {
-			case "general":
-				{
-					interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Bot categories %s (NOT ALL COMMANDS)|", crackedBuiltin))
-					fields := []discordgo.MessageEmbedField{
-						{
-							Name:   "✋ Say `hi` from any channel",
-							Value:  "\u200B",
-							Inline: true,
-						},
-						{
-							Name:   "✍ Say `feedback` from any channel",
-							Value:  "\u200B",
-							Inline: true,
-						},
-						{
-							Name:   "⏰ Say `myreminder` from any channel",
-							Value:  "\u200B",
-							Inline: true,
-						},
-						{
-							Name:   "\u200B",
-							Value:  "Run `/hi` to greet the bot and get a response",
-							Inline: true,
-						},
-						{
-							Name:   "\u200B",
-							Value:  "Run `/feedback` to see sub-categories. This will notify the officer team",
-							Inline: true,
-						},
-						{
-							Name:   "\u200B",
-							Value:  fmt.Sprintf("Run `/myreminder` to make custom alerts for ANYTHING you need\nYou can even create more alerts at once %s", crackedBuiltin),
-							Inline: true,
-						},
-						{
-							Name:   "\u200B",
-							Value:  "Run `/joke` to see what happens 👀",
-							Inline: false,
-						},
-					}
-					pointerFields := make([]*discordgo.MessageEmbedField, len(fields))
-					for i := range fields {
-						pointerFields[i] = &fields[i]
-					}
-					interactionResponse.Data.Embeds[0].Fields = pointerFields
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sen", err.Error())
-					}
-					time.Sleep(time.Second * 5)
-					_, err = innerSession.FollowupMessageCreate(event.Interaction, false, NewWebhookParamGIF("the-hi-command.gif"))
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a GIF to user %s during the slash command /howto during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-					}
-					return
-				}
-			case "stats":
-				{
-					interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Bot commands related to raiding %s|", crackedBuiltin))
-					fields := []*discordgo.MessageEmbedField{
-						{
-							Name:   fmt.Sprintf("Type `/my` See simple raid-stats about YOU %s", crackedBuiltin),
-							Value:  "`/myattendance` => (See your attendance since you joined)\n\n`/myraiderperformance` => (See average raid stats about you)\n\n`/mymissedraids` => (Get a list of specific raids u have missed)\n\n",
-							Inline: true,
-						},
-						{
-							Name:   "\u200B",
-							Value:  "\u200B",
-							Inline: false,
-						},
-						{
-							Name:   fmt.Sprintf("Type `/aboutme` See a more detailed view of your raid-performance %s\n", crackedBuiltin),
-							Value:  "⚠️`/aboutme logs` => (See log-specific data about you)⚠️\n\n⚠️`/aboutme playerinfo` => (See general data about your main)⚠️",
-							Inline: false,
-						},
-					}
-					interactionResponse.Data.Embeds[0].Fields = fields
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent response to user using slash command howto, during the function UseSlashCommand()", err.Error())
-					}
-					return
+// The buttons of the /howto message, see the function HandleHowTo()
+func HandleGeneralButton(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Bot categories %s (NOT ALL COMMANDS)|", crackedBuiltin))
+	fields := []discordgo.MessageEmbedField{
+		{
+			Name:   "✋ Say `hi` from any channel",
+			Value:  "\u200B",
+			Inline: true,
+		},
+		{
+			Name:   "✍ Say `feedback` from any channel",
+			Value:  "\u200B",
+			Inline: true,
+		},
+		{
+			Name:   "⏰ Say `myreminder` from any channel",
+			Value:  "\u200B",
+			Inline: true,
+		},
+		{
+			Name:   "\u200B",
+			Value:  "Run `/hi` to greet the bot and get a response",
+			Inline: true,
+		},
+		{
+			Name:   "\u200B",
+			Value:  "Run `/feedback` to see sub-categories. This will notify the officer team",
+			Inline: true,
+		},
+		{
+			Name:   "\u200B",
+			Value:  fmt.Sprintf("Run `/myreminder` to make custom alerts for ANYTHING you need\nYou can even create more alerts at once %s", crackedBuiltin),
+			Inline: true,
+		},
+		{
+			Name:   "\u200B",
+			Value:  "Run `/joke` to see what happens 👀",
+			Inline: false,
+		},
+	}
+	pointerFields := make([]*discordgo.MessageEmbedField, len(fields))
+	for i := range fields {
+		pointerFields[i] = &fields[i]
+	}
+	interactionResponse.Data.Embeds[0].Fields = pointerFields
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sen", err.Error())
+	}
+	time.Sleep(time.Second * 5)
+	_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, false, NewWebhookParamGIF("the-hi-command.gif"))
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a GIF to user %s during the slash command /howto during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleStatsButton(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Bot commands related to raiding %s|", crackedBuiltin))
+	fields := []*discordgo.MessageEmbedField{
+		{
+			Name:   fmt.Sprintf("Type `/my` See simple raid-stats about YOU %s", crackedBuiltin),
+			Value:  "`/myattendance` => (See your attendance since you joined)\n\n`/myraiderperformance` => (See average raid stats about you)\n\n`/mymissedraids` => (Get a list of specific raids u have missed)\n\n",
+			Inline: true,
+		},
+		{
+			Name:   "\u200B",
+			Value:  "\u200B",
+			Inline: false,
+		},
+		{
+			Name:   fmt.Sprintf("Type `/aboutme` See a more detailed view of your raid-performance %s\n", crackedBuiltin),
+			Value:  "⚠️`/aboutme logs` => (See log-specific data about you)⚠️\n\n⚠️`/aboutme playerinfo` => (See general data about your main)⚠️",
+			Inline: false,
+		},
+	}
+	interactionResponse.Data.Embeds[0].Fields = fields
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent response to user using slash command howto, during the function UseSlashCommand()", err.Error())
+	}
+}
+
+// Custom ID benchreason/<raid title>, the buttons are sent by the function HandleBenchReason()
+func HandleBenchReasonButton(request *slashCommandRequest) {
+	raidName := request.CustomIDParts[1] //Will be safe as we check for len(2)
+	messageID := ""
+	if trackedRaids := ReadWriteRaidHelperCache(request.Guild.ServerID); len(trackedRaids) > 0 {
+		foundBenchedRaidSlice := []trackRaid{}
+		for ID, raid := range trackedRaids {
+			if raid.RaidDiscordTitle == raidName {
+				messageID = ID
+				foundBenchedRaidSlice = append(foundBenchedRaidSlice, raid)
+			}
+		}
+		if len(foundBenchedRaidSlice) == 0 {
+			interactionResponse := NewInteractionResponseToSpecificCommand(0, "Raid %s not found|This might be due to the raid being deleted, please make sure a raid is already created before running this command")
+			err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s, using button %s during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), request.CustomID), err.Error())
+			}
+			return
+		}
+		foundBenchRaid := trackedRaids[messageID] //The same raid as the modal is sent for
+		if raidHelperEvent, err := raidHelperCurrent.GetEvent(messageID); err == nil { //The cache only holds the benches of the last edit of the event
+			foundBenchRaid.PlayersAlreadyTracked = GetWeeklyBenchFromEvent(raidHelperEvent)
+			err = UpdateTrackedRaids(request.Guild.ServerID, func(currentTrackedRaids map[string]trackRaid) error {
+				if currentTrackedRaid, ok := currentTrackedRaids[messageID]; ok {
+					currentTrackedRaid.PlayersAlreadyTracked = foundBenchRaid.PlayersAlreadyTracked
+					currentTrackedRaids[messageID] = currentTrackedRaid
 				}
+				return nil
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to store the benches of the raid-helper event %s, during the function HandleBenchReasonButton()", messageID), err.Error(), LogField("guildID", request.Guild.ServerID))
 			}
-			customID := event.MessageComponentData().CustomID
-			customIDSplit := strings.Split(customID, "/")
-			if len(customIDSplit) != 2 {
-				WriteErrorLog(fmt.Sprintf("The customID provided by the modolar response is not in the correct fomat: %s, expected <command>/<value>", customID), "Wrong format")
-				interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("button %s|This button is not yet supported... Please contact %s", customID, SplitOfficerName(officerGMArlissa)["Name"]))
-				err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-				if err != nil {
-					WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s, using button %s, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession), customID), err.Error())
+		} else {
+			WriteWarningLog(fmt.Sprintf("The raid-helper event %s could not be retrieved, the benches in the raid-helper cache are used instead: %s, during the function HandleBenchReasonButton()", messageID, err.Error()), "Raid-helper fallback", LogField("guildID", request.Guild.ServerID))
+		}
+		if len(foundBenchRaid.PlayersAlreadyTracked) == 0 {
+			WriteErrorLog(fmt.Sprintf("There was no players currently benched from raid %s, during the button %s, during the function UseSlashCommand()", raidName, request.CustomID), "None benched")
+			interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("No one benched|No raiders are currently benched from raid: %s, please bench people first, then rerun command `/benchreason`", raidName))
+			err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to tell the user %s that no raiders are currently benched in raid %s, using button %s during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), raidName, request.CustomID), err.Error())
+			}
+			return
+		}
+		specificBenchReasonRaiderSlice := []string{}
+		for raiderName := range foundBenchRaid.PlayersAlreadyTracked {
+			specificBenchReasonRaiderSlice = append(specificBenchReasonRaiderSlice, fmt.Sprintf("\n%s=", raiderName))
+		}
+		benchReasonCopy := cloneBenchReasonResponse(slashCommandAdminCenter["benchreason"].Responses["reason"])
+		benchReasonCopy.Response.Data.CustomID = fmt.Sprintf("%s/%s", benchReasonCopy.Response.Data.CustomID, messageID)
+		for x, component := range benchReasonCopy.Response.Data.Components {
+			row, ok := component.(discordgo.ActionsRow)
+			if !ok {
+				continue
+			}
+			for _, inner := range row.Components {
+				input, ok := inner.(*discordgo.TextInput)
+				if !ok {
+					continue
+				}
+				if input.CustomID == "specific_reason" {
+					input.Label = "Specific reasons for this week"
+					input.Value = strings.Join(specificBenchReasonRaiderSlice, "\n")
+					input.Required = true
 				}
-				return
 			}
-			switch customIDSplit[0] {
-			case "benchreason":
-				{
-					raidName := customIDSplit[1] //Will be safe as we check for len(2)
-					trackedRaids := make(map[string]trackRaid)
-					messageID := ""
-					if raidCache := CheckForExistingCache(raidHelperCachePath); len(raidCache) > 0 {
-						err := json.Unmarshal(raidCache, &trackedRaids)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal raid-helper cache with path %s using button %s during the function UseSlashCommand()", raidHelperCachePath, customID), err.Error())
-							return
-						}
-						foundBenchedRaidSlice := []trackRaid{}
-						for ID, raid := range trackedRaids {
-							if raid.RaidDiscordTitle == raidName {
-								messageID = ID
-								foundBenchedRaidSlice = append(foundBenchedRaidSlice, raid)
-							}
-						}
-						if len(foundBenchedRaidSlice) == 0 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "Raid %s not found|This might be due to the raid being deleted, please make sure a raid is already created before running this command")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s, using button %s during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession), customID), err.Error())
-							}
-							return
-						}
-						foundBenchRaid := foundBenchedRaidSlice[0]
-						if len(foundBenchRaid.PlayersAlreadyTracked) == 0 {
-							WriteErrorLog(fmt.Sprintf("There was no players currently benched from raid %s, during the button %s, during the function UseSlashCommand()", raidName, customID), "None benched")
-							interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("No one benched|No raiders are currently benched from raid: %s, please bench people first, then rerun command `/benchreason`", raidName))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to tell the user %s that no raiders are currently benched in raid %s, using button %s during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession), raidName, customID), err.Error())
-							}
-							return
-						}
-						specificBenchReasonRaiderSlice := []string{}
-						for raiderName := range foundBenchRaid.PlayersAlreadyTracked {
-							specificBenchReasonRaiderSlice = append(specificBenchReasonRaiderSlice, fmt.Sprintf("\n%s=", raiderName))
-						}
-						benchReasonCopy := cloneBenchReasonResponse(slashCommandAdminCenter["benchreason"].Responses["reason"])
-						benchReasonCopy.Response.Data.CustomID = fmt.Sprintf("%s/%s", benchReasonCopy.Response.Data.CustomID, messageID)
-						for x, component := range benchReasonCopy.Response.Data.Components {
-							row, ok := component.(discordgo.ActionsRow)
-							if !ok {
-								continue
-							}
-							for _, inner := range row.Components {
-								input, ok := inner.(*discordgo.TextInput)
-								if !ok {
+			benchReasonCopy.Response.Data.Components[x] = row
+		}
+		err := request.Session.InteractionRespond(request.Event.Interaction, benchReasonCopy.Response)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent modolar response to user %s using button %s, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), request.CustomID), err.Error())
+		}
+	}
+}
+
+// Custom ID feedback_modal/<subject>/<user ID>/<anonymous>, the modal is sent by the function HandleFeedback()
+func HandleFeedbackModal(request *slashCommandRequest) {
+	modolarData := request.Event.ModalSubmitData()
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "feedback|Analysing the description provided...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent initial response to the submit of a feedback description using slash command /feedback, during the function UseSlashCommand()", err.Error())
+	}
+	feedbackDescription := ""
+	content := ""
+	for _, component := range modolarData.Components {
+		if row, ok := component.(*discordgo.ActionsRow); ok {
+			if description, ok := row.Components[0].(*discordgo.TextInput); ok {
+				feedbackDescription = description.Value
+				break
+			}
+		}
+	}
+	anonymous, err := strconv.ParseBool(request.CustomIDParts[3])
+	if err != nil {
+		WriteErrorLog("An error occured while trying to convert string value of %s to bool, during the slash command feedback, during the function UseSlashCommand()", err.Error())
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("feedback|Problem submitting feedback, please contact <@%s>", GetGuildMaster(request.Guild.ServerID).ID))
+		err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to respond to slash command /feedback after submitting a description, during the function UshSlashCommand()", err.Error())
+		}
+		return
+	}
+	content = "**############# FEEDBACK START #############**"
+	playerName := ""
+	if !anonymous {
+		playerName = ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)
+	} else {
+		playerName = "Anonymous"
+	}
+	content = fmt.Sprintf("%s\n\n**Raider:** %s\n\n**Category:** %s\n\n**Description:** %s\n\n**############# FEEDBACK END #############**", content, playerName, request.CustomIDParts[1], feedbackDescription)
+	threadName := fmt.Sprintf("Topic: %s - From: %s", request.CustomIDParts[1], playerName)
+	thread, err := request.Session.ThreadStart(request.Guild.Channels.Feedback, threadName, discordgo.ChannelTypeGuildPublicThread, 10080)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to create new thread %s in channel %s, using slash command /feedback, during the function UseSlashCommand()", threadName, request.Guild.Channels.Feedback), err.Error())
+	} else {
+		WriteInformationLog(fmt.Sprintf("The new thread %s has been successfully created for feedback given by player: %s, using the slash command /feedback, during the function UseSlashCommand()", threadName, playerName), "Creating thread")
+	}
+	_, err = request.Session.ChannelMessageSend(thread.ID, content)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to send feedback to the thread with name %s and ID: %s, using slash command /feedback, during the function UseSlashCommand()", threadName, thread.ID), err.Error())
+	}
+	if !anonymous {
+		dmChannel, err := request.Session.UserChannelCreate(request.UserID)
+		if err != nil {
+			interactionResponse = NewInteractionResponseToSpecificCommand(1, "feedback|Was not able to make a direct channel with you.\nThis mneans that you cannot get updates about your feedback.\nIf this is an issue, please contact an officer")
+			_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+				Embeds: interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent warning to user with ID %s and name %s, using the slash command /feedback, during the function UseSlashCommand()", request.UserID, playerName), err.Error())
+			}
+
+		}
+		playerDirectChannel := playerChannel{
+			RaiderName: playerName,
+			RaiderDiscordID: request.UserID,
+			TimeOfCreation: GetTimeString(),
+			UserChannelID: dmChannel.ID,
+			RespondChannelID: thread.ID,
+			FriendlyName: strings.Split(request.CustomIDParts[1], "\n")[0],
+			IsActive: true,
+		}
+		ReadWritePlayerChannels(playerDirectChannel)
+		_, err = request.Session.ChannelMessageSend(playerDirectChannel.UserChannelID, fmt.Sprintf("Hi %s - Inside this chat window you can add any new information to the current given feedback - You can even write back to an officer when they respond to it!\n\nThe feedback post sent to officers can be seen below:\n\n%s", playerDirectChannel.RaiderName, content))
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent direct message to user with id %s and name %s inside dm channel %s, using slash command /feedback, during the function UseSlashCommand()", playerDirectChannel.RaiderDiscordID, playerDirectChannel.RaiderName, playerDirectChannel.UserChannelID), err.Error())
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("feedback|An error occured while trying to reach you in DM's - Please show the following error to %s:\n\n%s", GetGuildMaster(request.Guild.ServerID).Name, err.Error()))
+			_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+				Embeds: interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user with id %s and name %s, using slash command /feedback, during the function UseSlashCommand()", playerDirectChannel.RaiderDiscordID, playerDirectChannel.RaiderName), err.Error())
+			}
+			return
+		}
+		interactionResponse = NewInteractionResponseToSpecificCommand(2, "feedback|Please see the message from the bot regarding how to talk to an officer via feedback created")
+		_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+			Embeds: interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent message about a new dm channel being created for user %s, using slash command /feedback, during the function UseSlashCommand()", playerName), err.Error())
+		}
+	} 	
+
+	interactionResponse = NewInteractionResponseToSpecificCommand(1, "submit feedback|Message success", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+		Content: "Thank you - Your feedback is **HIGHLY** appriciated. ALL officers has just recieved this information",
+		Flags:   discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		WriteErrorLog("An error occured while trying to respond to slash command /feedback and the users final message, during the function UshSlashCommand()", err.Error())
+	}
+}
+
+// Custom ID bench_modal/<raid message ID>, the modal is sent by the function HandleBenchReasonButton()
+func HandleBenchModal(request *slashCommandRequest) {
+	modolarData := request.Event.ModalSubmitData()
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "feedback|Analysing the description provided...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent initial response to the submit of bench reasons using the button benchreason, during the function UseSlashCommand()", err.Error())
+	}
+	generalBenchReason := ""
+	messageID := request.CustomIDParts[1]
+	mapOfSpecificReason := make(map[string]string)
+	for _, component := range modolarData.Components {
+		if row, ok := component.(*discordgo.ActionsRow); ok {
+			for _, anyType := range row.Components {
+				if component, ok := anyType.(*discordgo.TextInput); ok {
+					switch component.CustomID {
+					case "specific_reason":
+						{
+							sliceOfSpecificPlayersNotCleaned := strings.Split(component.Value, "\n")
+							for _, playerLine := range sliceOfSpecificPlayersNotCleaned {
+								playerLineSlice := strings.Split(playerLine, "=")
+								if len(playerLineSlice) == 2 {
+									playerName := playerLineSlice[0]
+									if mapOfSpecificReason[playerName] == "" {
+										mapOfSpecificReason[playerName] = playerLineSlice[1]
+									}
+								} else {
+									WriteErrorLog(fmt.Sprintf("The format provided in the modal is incorrect - got %s but need format raider=reason", component.Value), "Wrong provided format")
 									continue
 								}
-								if input.CustomID == "specific_reason" {
-									input.Label = "Specific reasons for this week"
-									input.Value = strings.Join(specificBenchReasonRaiderSlice, "\n")
-									input.Required = true
-								}
 							}
-							benchReasonCopy.Response.Data.Components[x] = row
 						}
-						err = innerSession.InteractionRespond(event.Interaction, benchReasonCopy.Response)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent modolar response to user %s using button %s, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession), customID), err.Error())
+					default:
+						{
+							generalBenchReason = component.Value
 						}
 					}
 				}
 			}
-		} else if event.Type == discordgo.InteractionModalSubmit {
-			interactionResponse := NewInteractionResponseToSpecificCommand(1, "feedback|Analysing the description provided...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-			err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-			if err != nil {
-				WriteErrorLog("An error occured while trying to sent initial response to the submit of a feedback description using slash command /feedback, during the function UseSlashCommand()", err.Error())
+		}
+	}
+	allTrackedRaids := ReadWriteRaidHelperCache(request.Guild.ServerID)
+	trackedRaid := trackRaid{}
+	if _, ok := allTrackedRaids[messageID]; !ok {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("Raid to bench for not found|This should not happen - Please contact %s", GetGuildMaster(request.Guild.ServerID).Name))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s using the modolar benchreason, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+		return
+	}
+	trackedRaid = allTrackedRaids[messageID]
+	currentBenchedRaiders := []raiderProfile{}
+	allRaiders := ReadWriteRaiderProfiles(request.Guild.ServerID, []raiderProfile{}, false)
+	for playerName, reason := range mapOfSpecificReason {
+		for name := range trackedRaid.PlayersAlreadyTracked {
+			for _, raider := range allRaiders {
+				if name == raider.MainCharName {
+					currentBenchedRaiders = append(currentBenchedRaiders, raider)
+					break
+				}
 			}
-			modolarData := event.ModalSubmitData()
-			customIDSlice := strings.Split(modolarData.CustomID, "/")
-			switch customIDSlice[0] {
-			case "feedback_modal":
-				{
-					feedbackDescription := ""
-					content := ""
-					for _, component := range modolarData.Components {
-						if row, ok := component.(*discordgo.ActionsRow); ok {
-							if description, ok := row.Components[0].(*discordgo.TextInput); ok {
-								feedbackDescription = description.Value
-								break
-							}
-						}
-					}
-					anonymous, err := strconv.ParseBool(customIDSlice[3])
-					if err != nil {
-						WriteErrorLog("An error occured while trying to convert string value of %s to bool, during the slash command feedback, during the function UseSlashCommand()", err.Error())
-						interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("feedback|Problem submitting feedback, please contact <@%s>", SplitOfficerName(officerGMArlissa)["ID"]))
-						err = innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to respond to slash command /feedback after submitting a description, during the function UshSlashCommand()", err.Error())
-						}
-						break
-					}
-					content = "**############# FEEDBACK START #############**"
-					playerName := ""
-					if !anonymous {
-						playerName = ResolvePlayerID(userID, innerSession)
-					} else {
-						playerName = "Anonymous"
-					}
-					content = fmt.Sprintf("%s\n\n**Raider:** %s\n\n**Category:** %s\n\n**Description:** %s\n\n**############# FEEDBACK END #############**", content, playerName, customIDSlice[1], feedbackDescription)
-					threadName := fmt.Sprintf("Topic: %s - From: %s", customIDSlice[1], playerName)
-					thread, err := innerSession.ThreadStart(channelFeedback, threadName, discordgo.ChannelTypeGuildPublicThread, 10080)
+			if playerName == name {
+				currentBench := trackedRaid.PlayersAlreadyTracked[playerName]
+				if len(reason) != 0 {
+					currentBench.Reason = reason
+				} else {
+					currentBench.Reason = generalBenchReason
+				}
+				trackedRaid.PlayersAlreadyTracked[playerName] = currentBench
+			}
+		}
+	}
+
+	for x, raider := range currentBenchedRaiders {
+		benchInfoLastWeek := make(map[string][]bench)
+		benchInfoLastWeek["lastWeek"] = append(benchInfoLastWeek["lastWeek"], trackedRaid.PlayersAlreadyTracked[raider.MainCharName])
+		currentBenchedRaiders[x].BenchInfo = benchInfoLastWeek
+	}
+	ReadWriteRaiderProfiles(request.Guild.ServerID, currentBenchedRaiders, false)
+	//interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("Successfully updated %d raider-profiles|Bench reasons updated", len(currentRaiderProfiles)))
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to do final success respond to user %s using modolar bench reason, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleDeleteBotChannel(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Running command...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		return
+	}
+	channels, err := request.Session.GuildChannels(request.Guild.ServerID)
+	for _, channel := range channels {
+		if channel.Name == channelNameAnnouncement {
+			_, err = request.Session.ChannelDelete(channel.ID)
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel with ID %s, using slash command /deletebotchannel, during the function UseSlashCommand()", channel.ID), err.Error())
+				interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("deletebotchannel|It was not possible to delete channel with ID: %s, please let %s know", channel.Name, GetGuildMaster(request.Guild.ServerID).Name))
+				_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+					Embeds: &interactionResponse.Data.Embeds,
+				})
+				if err != nil {
+					WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+				}
+				return
+			}
+		}
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, "deletebotchannel|Bot channel(s) deleted.. Please give the bot a moment to recreate it...")
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while truing to sent success response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleCloseFeedback(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "closefeedback|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the intial response to user with id %s and name %s, returning early, using slash command /closefeedback, during the function UseSlashCommand()", request.UserID, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		return
+	}
+	channelID := request.Event.Interaction.ChannelID
+	playerChannelSlice := FindSpecificPlayerChannels(ReadWritePlayerChannels(), channelID)
+	if len(playerChannelSlice) == 0 {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, "closefeedback|The channel the command was run in is not a feedback thread...")
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user with id %s and name %s, using the slash command /closefeedback, during the function UseSlashCommand()", request.UserID, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+		return
+	}
+	player := playerChannelSlice[0]
+	player.IsActive = false
+	ReadWritePlayerChannels(player)
+	_, err = request.Session.ChannelMessageSend(player.UserChannelID, fmt.Sprintf("The officer ``%s`` has closed your feedback of topic ``%s``\n\nIf your not satisfied with the result, you are more than welcome to reach out to any officer for clarification", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), player.FriendlyName))
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent feedback thread status closed to user with id %s and name %s, using slash command /closefeedback, during the function UseSlashCommand()", player.RaiderDiscordID, player.RaiderName), err.Error())
+		interactionResponse = NewInteractionResponseToSpecificCommand(1, "closefeedback|The feedback thread is successfully closed BUT the bot was not able to tell player %s directly...\n\nPlease tell the player manually!")
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to let the officer with id %s and name %s know that the closing thread message could not be sent directly to player %s, using slash command /closefeedback, during the function UseSlashCommand()", request.UserID, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), player.RaiderName), err.Error())
+		}
+		return
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("closefeedback|The feedback has been successfully closed and the player %s is notified!\n\nYou can simply let this thread be now as it will be automatically archived by discord", player.RaiderName))
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent final success response to officer with id %s and name %s using slash command /closefeedback, during the function UseSlashCommand()", request.UserID, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleBenchReason(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Initiating benching|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /benchreason, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		return
+	}
+	raidCacheMap := ReadWriteRaidHelperCache(request.Guild.ServerID)
+	if len(raidCacheMap) == 0 {
+		interactionResponse = NewInteractionResponseToSpecificCommand(1, "benchreason|No raids found... Please make sure to have created the actual raid-signup using raid-helper, before using this command")
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to tell user %s that no current raids are active using slash command /benchreason, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+		return
+	}
+
+	buttonsOfRaids := []discordgo.Button{}
+	for _, raid := range raidCacheMap {
+		raidTitle := raid.RaidDiscordTitle
+		button := discordgo.Button{
+			Label:    raidTitle,
+			Style:    discordgo.PrimaryButton,
+			CustomID: fmt.Sprintf("benchreason/%s", raidTitle),
+		}
+		buttonsOfRaids = append(buttonsOfRaids, button)
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(1, "Raid selection|Please press the raid, where you want to give reasons for benching raiders")
+	row := discordgo.ActionsRow{}
+	for _, button := range buttonsOfRaids {
+		row.Components = append(row.Components, button)
+	}
+	interactionResponse.Data.Components = []discordgo.MessageComponent{
+		row,
+	}
+
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Content:    GetStringPointer("`Select raid below`"),
+		Components: &interactionResponse.Data.Components,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a length of %d buttons using slash command /benchreason, during the function UseSlashCommand()", len(row.Components)), err.Error())
+	}
+}
+
+func HandleResetRaidCache(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting sync of raiding cache|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to make initial response to user %s using slash command /resetraidcache, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+	if request.BoolOption("full") {
+		if err := ResetReportWatermarks(request.Guild.ServerID); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to reset the report watermarks on slash command resetraidcache from user %s, during the function HandleResetRaidCache()", request.UserID), err.Error())
+			RespondSlashCommandError(request, "resetraidcache|The raids cache could not be reset, please try again later")
+			return
+		}
+		WriteInformationLog(fmt.Sprintf("User %s has reset the report watermarks of server %s, every log will be retrieved again", request.UserID, request.Guild.ServerID), "Full raid cache reset", LogField("guildID", request.Guild.ServerID))
+	}
+	GetAllWarcraftLogsRaidData(request.Guild.ServerID, false, false, "", request.Session, request.Event.Interaction)
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("resetraidcache|The raid-data is now syncronized directly with Warcraftlogs - ALL data provided by any bot command and related to raid-info is now valid %s", crackedBuiltin))
+	_, err = request.Session.ChannelMessageSendEmbeds(request.Event.ChannelID, interactionResponse.Data.Embeds)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to send a final response to the user %s using slash command resetraidcache, inside of the function UseSlashCommand()", request.UserID), err.Error())
+	} else {
+		WriteInformationLog(fmt.Sprintf("A message successfully sent to the user %s during the function UseSlashCommand()", request.UserID), "Successfully sent embed message")
+	}
+}
+
+func HandleDeleteChannelContent(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Deleting channel content|Please wait...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent initial response to user %s using slash command /deletechannelcontent, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session))
+	}
+	DeleteMessagesInBulk(request.Event.ChannelID, request.Session)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to delete messages", err.Error())
+
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("deletechannelcontent|Deletion complete... %s", crackedBuiltin))
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog("An error occured while making a final response to user using slash command /deletechannelcontent, during function UseSlashCommand()", err.Error())
+	}
+}
+
+func HandleUpdateWeeklyAttendance(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting work on attendance update|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to start the initial deferred response to the user, with the slash command /updateweeklyattendance during the function UseSlashCommand()", err.Error())
+		return
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(1, "Starting job|Analysing raider attendance...")
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog("An error occured while trying to letting the admin know that the bot is analysing raider attendance using slash command /updateweeklyattendance, during the functioin UseSlashCommand()", err.Error())
+	}
+	returnString := AddWeeklyRaiderAttendance(request.Guild.ServerID, request.Session, request.Event.Interaction)
+	if strings.Contains(returnString, "error") || strings.Contains(returnString, "cannot") {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("updateweeklyattendance|The following error occured inside the bot: %s", returnString))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent error response to user %s with slash command updateweekyattendance 1, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("updateweeklyattendance|%s", returnString))
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent error response to user %s with slash command updateweekyattendance 2, during the function UseSlashCommand()", err.Error())
+	}
+}
+
+func HandleReloadGuildConfig(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Reloading guild config|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to make initial response to user %s using slash command /reloadguildconfig, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		return
+	}
+	err = ImportGuildConfig()
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to reload the guild config on request from user %s using slash command /reloadguildconfig, the old config is kept, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("reloadguildconfig|The guild config was NOT reloaded, the bot keeps using the old one\n\nError: %s", err.Error()))
+	} else {
+		for _, guildID := range GetGuildIDs() {
+			mapOfRaidChannelIDs[guildID] = RetrieveSubsetDiscordChannels(guildID, raidingChannelSubString)
+		}
+		interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("reloadguildconfig|The guild config on path %s has been reloaded for the servers %s %s", guildConfigPath, strings.Join(GetGuildIDs(), ", "), crackedBuiltin))
+	}
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Embeds: &interactionResponse.Data.Embeds,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the final response to user %s using slash command /reloadguildconfig, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleSeeAllRaidersAttendance(request *slashCommandRequest) {
+	raiderStruct, err := storageCurrent.ReadRaiderProfiles(request.Guild.ServerID)
+	if err == nil && len(raiderStruct.Raiders) == 0 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|No raider-profiles found... Please contact Arlissa")
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent error response to user %s with slash command seeraiderattendance, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	onlyCurrentRaiders := []string{}
+	if err != nil {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("seeraiderattendance|%s... Please contact Arlissa", err.Error()))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent error response to user %s with slash command seeraiderattendance, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	sort.Slice(raiderStruct.Raiders, func(i, j int) bool {
+		return raiderStruct.Raiders[i].AttendanceInfo["guildStart"].RaidProcent > raiderStruct.Raiders[j].AttendanceInfo["guildStart"].RaidProcent
+	})
+	for _, raider := range raiderStruct.Raiders {
+		if strings.Contains(strings.Join(raider.DiscordRoles, ","), request.Guild.Roles.Raider) {
+			totalOGPoints := math.Floor(float64(10*raider.AttendanceInfo["guildStart"].RaidCount) * (100/raider.AttendanceInfo["guildStart"].RaidProcent + 1))
+			onlyCurrentRaiders = append(onlyCurrentRaiders, fmt.Sprintf("%s => %.0f => %.0f", raider.MainCharName, raider.AttendanceInfo["guildStart"].RaidProcent, totalOGPoints))
+		}
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraiderattendance|playerName => OG %% => 3 Months %% => 2 Months %% => 1 Month %%\n%s", strings.Join(onlyCurrentRaiders, "\n")))
+	request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+}
+
+func HandleAnnounceBot(request *slashCommandRequest) {
+	patternDiscordMentions := regexp.MustCompile(`<(@!?|@&|#)\d{17,19}>`)
+	active := false
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting announcement|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the initial response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		return
+	}
+	announceDescription := ""
+	announceTitle := ""
+	channelID := ""
+	for _, option := range request.Data.Options {
+		if _, ok := option.Value.(string); !ok {
+			WriteErrorLog(fmt.Sprintf("The discordgo template is set to garuentee this to be a string, but its not, for officer %s, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), "Data is not string")
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|A bug happened inside the bot - Please report this to %s", GetGuildMaster(request.Guild.ServerID).Name))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+			}
+			return
+		}	
+		switch option.Name {
+			case "title": {
+				announceTitle = option.Value.(string)
+				fmt.Println("TITLE", announceTitle)
+				if exists, channelID := CheckForPost(request.Guild.ServerID, announceTitle); exists {
+					interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An announcement with that title already exists <#%s>\nPlease delete the old one first or run again with a different title", channelID))
+					_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+						Embeds: &interactionResponse.Data.Embeds,
+					})
 					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to create new thread %s in channel %s, using slash command /feedback, during the function UseSlashCommand()", threadName), err.Error())
-					} else {
-						WriteInformationLog(fmt.Sprintf("The new thread %s has been successfully created for feedback given by player: %s, using the slash command /feedback, during the function UseSlashCommand()", threadName, playerName), "Creating thread")
+						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response of announcement already exists in channel ID %s to user %s using slash command /announcebot, during the function UseSlashCommand()", channelID, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
 					}
-					_, err = innerSession.ChannelMessageSend(thread.ID, content)
+					return
+				} else {
+					fmt.Println("TITLE DOESNT EXIST")
+				}
+			}
+			case "description": {
+				announceDescription = option.StringValue()
+				fmt.Println("Descriptiojm", announceDescription, len(announceDescription))
+				if len(announceDescription) <= 25 {
+					interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The announcement provided of `%s` is less than 25 characters long...", announceDescription))
+					_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+						Embeds: &interactionResponse.Data.Embeds,
+					})
 					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to send feedback to the thread with name %s and ID: %s, using slash command /feedback, during the function UseSlashCommand()", threadName, thread.ID), err.Error())
+						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error reponse to user %s about description being too short, found len %d but need len 25 minimum, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session), len(announceDescription)), err.Error())
 					}
-					if !anonymous {
-						dmChannel, err := innerSession.UserChannelCreate(userID)
-						if err != nil {
-							interactionResponse = NewInteractionResponseToSpecificCommand(1, "feedback|Was not able to make a direct channel with you.\nThis mneans that you cannot get updates about your feedback.\nIf this is an issue, please contact an officer")
-							_, err = innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-								Embeds: interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent warning to user with ID %s and name %s, using the slash command /feedback, during the function UseSlashCommand()", userID, playerName), err.Error())
-							}
-
-						}
-						playerDirectChannel := playerChannel{
-							RaiderName: playerName,
-							RaiderDiscordID: userID,
-							TimeOfCreation: GetTimeString(),
-							UserChannelID: dmChannel.ID,
-							RespondChannelID: thread.ID,
-							FriendlyName: strings.Split(customIDSlice[1], "\n")[0],
-							IsActive: true,
-						}
-						ReadWritePlayerChannels(playerDirectChannel)
-						_, err = innerSession.ChannelMessageSend(playerDirectChannel.UserChannelID, fmt.Sprintf("Hi %s - Inside this chat window you can add any new information to the current given feedback - You can even write back to an officer when they respond to it!\n\nThe feedback post sent to officers can be seen below:\n\n%s", playerDirectChannel.RaiderName, content))
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent direct message to user with id %s and name %s inside dm channel %s, using slash command /feedback, during the function UseSlashCommand()", playerDirectChannel.RaiderDiscordID, playerDirectChannel.RaiderName, playerDirectChannel.UserChannelID), err.Error())
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("feedback|An error occured while trying to reach you in DM's - Please show the following error to %s:\n\n%s", SplitOfficerName(officerGMArlissa)["Name"], err.Error()))
-							_, err = innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-								Embeds: interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user with id %s and name %s, using slash command /feedback, during the function UseSlashCommand()", playerDirectChannel.RaiderDiscordID, playerDirectChannel.RaiderName), err.Error())
-							}
-							return
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, "feedback|Please see the message from the bot regarding how to talk to an officer via feedback created")
-						_, err = innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-							Embeds: interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent message about a new dm channel being created for user %s, using slash command /feedback, during the function UseSlashCommand()", playerName), err.Error())
-						}
-					} 	
+					fmt.Println("Why dont we stop on this return?")
+					return
 				}
-			case "bench_modal":
-				{
-					generalBenchReason := ""
-					messageID := customIDSlice[1]
-					mapOfSpecificReason := make(map[string]string)
-					for _, component := range modolarData.Components {
-						if row, ok := component.(*discordgo.ActionsRow); ok {
-							for _, anyType := range row.Components {
-								if component, ok := anyType.(*discordgo.TextInput); ok {
-									switch component.CustomID {
-									case "specific_reason":
-										{
-											sliceOfSpecificPlayersNotCleaned := strings.Split(component.Value, "\n")
-											for _, playerLine := range sliceOfSpecificPlayersNotCleaned {
-												playerLineSlice := strings.Split(playerLine, "=")
-												if len(playerLineSlice) == 2 {
-													playerName := playerLineSlice[0]
-													if mapOfSpecificReason[playerName] == "" {
-														mapOfSpecificReason[playerName] = playerLineSlice[1]
-													}
-												} else {
-													WriteErrorLog(fmt.Sprintf("The format provided in the modal is incorrect - got %s but need format raider=reason", component.Value), "Wrong provided format")
-													continue
-												}
-											}
-										}
-									default:
-										{
-											generalBenchReason = component.Value
-										}
-									}
-								}
-							}
-						}
+				mentions := patternDiscordMentions.FindAllString(announceDescription, -1)
+				if len(mentions) == 0 && (strings.Contains(announceDescription, "#") || strings.Contains(announceDescription, "@")) {
+					interactionResponse = NewInteractionResponseToSpecificCommand(1, "announcebot|It looks like you tried to add either users, channels or roles to the description.\nMake sure tags are added to the description correctly and try again!")
+					_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+						Embeds: &interactionResponse.Data.Embeds,
+					})
+					if err != nil {
+						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent warning to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
 					}
-					allTrackedRaids := ReadWriteRaidHelperCache()
-					trackedRaid := trackRaid{}
-					if _, ok := allTrackedRaids[messageID]; !ok {
-						interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("Raid to bench for not found|This should not happen - Please contact %s", SplitOfficerName(officerGMArlissa)["Name"]))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
+					return
+				} else {
+					fmt.Println("DO WE REACH HERE?", announceDescription)
+				}
+			}
+			case "channel": {
+					if id := RetrieveChannelID(option.StringValue()); id != "" {
+						channelID = id
+					} else {
+						interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The channel provided %s is not valid - Please rerun and use format #<channel>.\nMake sure the channel link is parsed, so DONT type it manually", option.StringValue()))
+						_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
 							Embeds: &interactionResponse.Data.Embeds,
 						})
 						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s using the modolar benchreason, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
+							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the channel format being incorrect, using the slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
 						}
 						return
 					}
-					trackedRaid = allTrackedRaids[messageID]
-					currentBenchedRaiders := []raiderProfile{}
-					allRaiders := ReadWriteRaiderProfiles([]raiderProfile{}, false)
-					for playerName, reason := range mapOfSpecificReason {
-						for name := range trackedRaid.PlayersAlreadyTracked {
-							for _, raider := range allRaiders {
-								if name == raider.MainCharName {
-									currentBenchedRaiders = append(currentBenchedRaiders, raider)
-									break
-								}
-							}
-							if playerName == name {
-								currentBench := trackedRaid.PlayersAlreadyTracked[playerName]
-								if len(reason) != 0 {
-									currentBench.Reason = reason
-								} else {
-									currentBench.Reason = generalBenchReason
-								}
-								trackedRaid.PlayersAlreadyTracked[playerName] = currentBench
-							}
-						}
-					}
-
-					for x, raider := range currentBenchedRaiders {
-						benchInfoLastWeek := make(map[string][]bench)
-						benchInfoLastWeek["lastWeek"] = append(benchInfoLastWeek["lastWeek"], trackedRaid.PlayersAlreadyTracked[raider.MainCharName])
-						currentBenchedRaiders[x].BenchInfo = benchInfoLastWeek
-					}
-					ReadWriteRaiderProfiles(currentBenchedRaiders, false)
-					//interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("Successfully updated %d raider-profiles|Bench reasons updated", len(currentRaiderProfiles)))
-					_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-						Embeds: &interactionResponse.Data.Embeds,
-					})
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to do final success respond to user %s using modolar bench reason, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-					}
-					return
 				}
 			}
-
-			interactionResponse = NewInteractionResponseToSpecificCommand(1, "submit feedback|Message success", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-			innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-			_, err = innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-				Content: "Thank you - Your feedback is **HIGHLY** appriciated. ALL officers has just recieved this information",
-				Flags:   discordgo.MessageFlagsEphemeral,
+		}
+		fmt.Println("DID WE FIND A CHANNEL ID?", channelID)
+		embedToSend := &discordgo.MessageEmbed{
+			Title: announceTitle,
+			Color: blueColor,
+			Fields: []*discordgo.MessageEmbedField{
+				{	
+					Value: announceDescription,
+					Name: "\u200B",
+				},
+			},
+		}
+		linkedChannelName := GetChannelName(channelID, request.Session)
+		if linkedChannelName == "" && active {
+			WriteErrorLog(fmt.Sprintf("The length of the resolved linked channel name is 0, which means we cannot find the channel again when it changes id - This means the config found at %s was changed BEFORE this code could run, which means it will return early, for user %s using slash command /announcebot, during the function UseSlashCommand()", configPath, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), "Channel not found")
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The channel <#%s> has changed before the bot could find it, please rerun command `/announcebot`\nPro tip: press arrow up, in the chat to reuse the same command and values", channelID))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
 			})
 			if err != nil {
-				WriteErrorLog("An error occured while trying to respond to slash command /feedback and the users final message, during the function UshSlashCommand()", err.Error())
+				WriteErrorLog(fmt.Sprintf("An error occured while tryhing to sent error response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
 			}
-
-		} else if event.Type != discordgo.InteractionApplicationCommand && event.GuildID != "" && userID != innerSession.State.User.ID {
 			return
 		}
-		// Acknowledge the interaction immediately to avoid the "Application did not respond" error
-		interactionData := discordgo.ApplicationCommandInteractionData{}
-		if event.Type == discordgo.InteractionApplicationCommand {
-			interactionData = event.ApplicationCommandData()
+		message, err := request.Session.ChannelMessageSendComplex(request.Guild.Channels.Info, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{embedToSend},
+		})
+		active = channelID != ""
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the announce message in channel %s, user %s using slash command /botannounce, during the function UseSlashCommand()", request.Guild.Channels.Info, ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An error happened inside the bot - %s", err.Error()))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the announcement message that could not be sent, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+			}
+			return
 		}
-		if CheckForOfficerRank(userID, innerSession) {
-			if len(interactionData.Options) == 0 {
-				switch interactionData.Name {
-				case "deletebotchannel":
-					{
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "Running command...|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							return
-						}
-						channels, err := innerSession.GuildChannels(serverID)
-						for _, channel := range channels {
-							if channel.Name == channelNameAnnouncement {
-								_, err = innerSession.ChannelDelete(channel.ID)
-								if err != nil {
-									WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel with ID %s, using slash command /deletebotchannel, during the function UseSlashCommand()", channel.ID), err.Error())
-									interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("deletebotchannel|It was not possible to delete channel with ID: %s, please let %s know", channel.Name, SplitOfficerName(officerGMArlissa)["Name"]))
-									_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-										Embeds: &interactionResponse.Data.Embeds,
-									})
-									if err != nil {
-										WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-									}
-									return
-								}
-							}
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, "deletebotchannel|Bot channel(s) deleted.. Please give the bot a moment to recreate it...")
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while truing to sent success response to user %s, using slash command /deletebotchannel, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-					}
-				case "closefeedback": {
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "closefeedback|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the intial response to user with id %s and name %s, returning early, using slash command /closefeedback, during the function UseSlashCommand()", userID, ResolvePlayerID(userID, session)), err.Error())
-							return
-						}
-						channelID := event.Interaction.ChannelID
-						playerChannelSlice := FindSpecificPlayerChannels(ReadWritePlayerChannels(), channelID)
-						if len(playerChannelSlice) == 0 {
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, "closefeedback|The channel the command was run in is not a feedback thread...")
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user with id %s and name %s, using the slash command /closefeedback, during the function UseSlashCommand()", userID, ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-						player := playerChannelSlice[0]
-						player.IsActive = false
-						ReadWritePlayerChannels(player)
-						_, err = innerSession.ChannelMessageSend(player.UserChannelID, fmt.Sprintf("The officer ``%s`` has closed your feedback of topic ``%s``\n\nIf your not satisfied with the result, you are more than welcome to reach out to any officer for clarification", ResolvePlayerID(userID, session), player.FriendlyName))
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent feedback thread status closed to user with id %s and name %s, using slash command /closefeedback, during the function UseSlashCommand()", player.RaiderDiscordID, player.RaiderName), err.Error())
-							interactionResponse = NewInteractionResponseToSpecificCommand(1, "closefeedback|The feedback thread is successfully closed BUT the bot was not able to tell player %s directly...\n\nPlease tell the player manually!")
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to let the officer with id %s and name %s know that the closing thread message could not be sent directly to player %s, using slash command /closefeedback, during the function UseSlashCommand()", userID, ResolvePlayerID(userID, session), player.RaiderName), err.Error())
-							}
-							return
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("closefeedback|The feedback has been successfully closed and the player %s is notified!\n\nYou can simply let this thread be now as it will be automatically archived by discord", player.RaiderName))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent final success response to officer with id %s and name %s using slash command /closefeedback, during the function UseSlashCommand()", userID, ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-					}
-				case "benchreason":
-					{
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "Initiating benching|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured during the initial defered response to user %s, using slash command /benchreason, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							return
-						}
-						cacheRaidHelper := CheckForExistingCache(raidHelperCachePath)
-						if len(cacheRaidHelper) == 0 {
-							interactionResponse = NewInteractionResponseToSpecificCommand(1, "benchreason|No raids found... Please make sure to have created the actual raid-signup using raid-helper, before using this command")
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to tell user %s that no current raids are active using slash command /benchreason, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-						raidCacheMap := make(map[string]trackRaid)
-						err = json.Unmarshal(cacheRaidHelper, &raidCacheMap)
-						if err != nil {
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("benchreason|An error occured inside the bot when trying to convert raid-helper bytes to struct - Please contact %s", ResolvePlayerID(SplitOfficerName(officerGMArlissa)["Name"], innerSession)))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s using slash command /benchreason, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-
-						buttonsOfRaids := []discordgo.Button{}
-						for _, raid := range raidCacheMap {
-							raidTitle := raid.RaidDiscordTitle
-							button := discordgo.Button{
-								Label:    raidTitle,
-								Style:    discordgo.PrimaryButton,
-								CustomID: fmt.Sprintf("benchreason/%s", raidTitle),
-							}
-							buttonsOfRaids = append(buttonsOfRaids, button)
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, "Raid selection|Please press the raid, where you want to give reasons for benching raiders")
-						row := discordgo.ActionsRow{}
-						for _, button := range buttonsOfRaids {
-							row.Components = append(row.Components, button)
-						}
-						interactionResponse.Data.Components = []discordgo.MessageComponent{
-							row,
-						}
-
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Content:    GetStringPointer("`Select raid below`"),
-							Components: &interactionResponse.Data.Components,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a length of %d buttons using slash command /benchreason, during the function UseSlashCommand()", len(row.Components)), err.Error())
-						}
-					}
-				case "resetraidcache":
-					{
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting full reset of raiding cache|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to make initial response to user %s using slash command /resetraidcache, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, "Calculating amount of logs!|")
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent a message to user %s using the slash command /resetraidcache 1, during the function UseSlashCommand", err.Error())
-						}
-						currentLogsBase := GetWarcraftLogsData(mapOfWarcaftLogsQueries["guildLogsRaidIDs"])
-						lenCurrentLogBase := 0
-						if allLogs, ok := currentLogsBase["logs"].([]logsBase); ok {
-							lenCurrentLogBase = len(allLogs)
-						} else {
-							WriteErrorLog(fmt.Sprintf("Was not possible to find any valid guild raids using the function GetWarcraftLogsData() on slash command raidreset from user %s", userID), "During function UseSlashCommand()")
+		_, err = request.Session.ChannelMessageSend(request.Guild.Channels.Info, strings.Join(SeperateAnyTagsInMessage(announceDescription), ", "))
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent tag message, for user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An error happened inside the bot - %s", err.Error()))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the announcement message that could not be sent, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+			}
+			return
+		}
+		post := trackPost{
+			ChannelID: message.ChannelID,
+			LinkedChannelID: channelID,
+			Active: active,
+			MessageID: message.ID,
+			LinkedChannelName:linkedChannelName,
+		}
+		ReadWriteTrackPosts(post)
+		select {
+			case trackCacheChanged <- struct{}{}:
+			default:
+		}
+		fmt.Println("DO WE REACH TOWARDS THE END=?", post)
+		returnString := ""
+		if active {
+			returnString = fmt.Sprintf("Successfully created and will be tracked to channel ID <#%s>\nTo stop the tracking of a specific post, please run `/stopannouncebot`", channelID)
+		} else {
+			returnString = fmt.Sprintf("Successfully created message and can be found here => https://discord.com/channels/%s/%s/%s", request.Guild.ServerID, post.ChannelID, post.MessageID)
+		}
+		interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("announcebot|%s", returnString))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent final successfull response to user %s, using the slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+}
 
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("Progress on command|The bot found a total of %d logs - Please wait...", lenCurrentLogBase))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent a message to user %s using the slash command /resetraidcache 2, during the function UseSlashCommand", err.Error())
-						}
-						GetAllWarcraftLogsRaidData(false, false, "", innerSession, event.Interaction)
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("resetraidcache|The raid-data is now syncronized directly with Warcraftlogs - ALL data provided by any bot command and related to raid-info is now valid %s", crackedBuiltin))
-						_, err = innerSession.ChannelMessageSendEmbeds(event.ChannelID, interactionResponse.Data.Embeds)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to send a final response to the user %s using slash command resetraidcache, inside of the function UseSlashCommand()", userID), err.Error())
-						} else {
-							WriteInformationLog(fmt.Sprintf("A message successfully sent to the user %s during the function UseSlashCommand()", userID), "Successfully sent embed message")
-						}
-					}
-				case "deletechannelcontent":
-					{
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "Deleting channel content|Please wait...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent initial response to user %s using slash command /deletechannelcontent, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession))
-						}
-						DeleteMessagesInBulk(event.ChannelID, innerSession)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to delete messages", err.Error())
+func HandlePromoteTrial(request *slashCommandRequest) {
+	nameSlice := strings.Split(request.StringOption("trialname"), "@")
+	if len(nameSlice) != 2 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, "promotetrial|Format incorrect - Must be @<playername>, e.g. @Arlissa")
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+		}
+		return
+	}
+	taggedID := strings.ReplaceAll(nameSlice[1], ">", "")
+	nickNameTrial := ResolvePlayerID(request.Guild.ServerID, taggedID, request.Session)
+	matched := false
+	for _, trial := range RetrieveUsersInRole(request.Guild.ServerID, []string{request.Guild.Roles.Trial}, request.Session) {
+		if trial == taggedID {
+
+			matched = true
+			err := request.Session.GuildMemberRoleAdd(request.Guild.ServerID, trial, request.Guild.Roles.Raider)
+			err2 := request.Session.GuildMemberRoleRemove(request.Guild.ServerID, trial, request.Guild.Roles.Trial)
+			if err != nil || err2 != nil {
+				response := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("promotetrial|The following error occured when trying to add %s to the raider role or remove trial", nickNameTrial))
+				request.Session.InteractionRespond(request.Event.Interaction, &response)
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to promote user %s to raider or remove trial role, during the function UseSlashCommand()", nickNameTrial), fmt.Sprintf("%s%s", err.Error(), err2.Error()))
+				break
+			}
+			break
+		}
+	}
+	if !matched {
+		response := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("promotetrial|The user %s did not have the trial role...", nickNameTrial))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &response)
+		if err != nil {
+			WriteErrorLog("An error ocurred while trying to respond to admin, during the function UseSlashCommand()", err.Error())
+		}
+	}
+	response := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("promotetrial|The player %s has been successfully promoted to rank Raider", nickNameTrial))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &response)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to respond to admin, during the function UseSlashCommand()", err.Error())
+	}
+}
 
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("deletechannelcontent|Deletion complete... %s", crackedBuiltin))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while making a final response to user using slash command /deletechannelcontent, during function UseSlashCommand()", err.Error())
-						}
-					}
-				case "updateweeklyattendance":
-					{
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting work on attendance update|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to start the initial deferred response to the user, with the slash command /updateweeklyattendance during the function UseSlashCommand()", err.Error())
-							break
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, "Starting job|Analysing raider attendance...")
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to letting the admin know that the bot is analysing raider attendance using slash command /updateweeklyattendance, during the functioin UseSlashCommand()", err.Error())
-						}
-						returnString := AddWeeklyRaiderAttendance(innerSession, event.Interaction)
-						if strings.Contains(returnString, "error") || strings.Contains(returnString, "cannot") {
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("updateweeklyattendance|The following error occured inside the bot: %s", returnString))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent error response to user %s with slash command updateweekyattendance 1, during the function UseSlashCommand()", err.Error())
-							}
-							break
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("updateweeklyattendance|%s", returnString))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent error response to user %s with slash command updateweekyattendance 2, during the function UseSlashCommand()", err.Error())
-						}
-					}
-				case "seeraiderattendance":
-					{
-						currentRaidersBytes := CheckForExistingCache(raiderProfilesCachePath)
-						if len(currentRaidersBytes) == 0 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|No raider-profiles found... Please contact Arlissa")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent error response to user %s with slash command seeraiderattendance, during the function UseSlashCommand()", err.Error())
-							}
-							break
-						}
-						raiderStruct := raiderProfiles{}
-						onlyCurrentRaiders := []string{}
-						err := json.Unmarshal(currentRaidersBytes, &raiderStruct)
-						if err != nil {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("seeraiderattendance|%s... Please contact Arlissa", err.Error()))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent error response to user %s with slash command seeraiderattendance, during the function UseSlashCommand()", err.Error())
-							}
-							break
-						}
-						sort.Slice(raiderStruct.Raiders, func(i, j int) bool {
-							return raiderStruct.Raiders[i].AttendanceInfo["guildStart"].RaidProcent > raiderStruct.Raiders[j].AttendanceInfo["guildStart"].RaidProcent
-						})
-						for _, raider := range raiderStruct.Raiders {
-							if strings.Contains(strings.Join(raider.DiscordRoles, ","), roleRaider) {
-								totalOGPoints := math.Floor(float64(10*raider.AttendanceInfo["guildStart"].RaidCount) * (100/raider.AttendanceInfo["guildStart"].RaidProcent + 1))
-								onlyCurrentRaiders = append(onlyCurrentRaiders, fmt.Sprintf("%s => %.0f => %.0f", raider.MainCharName, raider.AttendanceInfo["guildStart"].RaidProcent, totalOGPoints))
-							}
-						}
-						interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraiderattendance|playerName => OG %% => 3 Months %% => 2 Months %% => 1 Month %%\n%s", strings.Join(onlyCurrentRaiders, "\n")))
-						innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					}
-				}
-			} else {
-				switch interactionData.Name {
-				case "announcebot":
-				{
-					patternDiscordMentions := regexp.MustCompile(`<(@!?|@&|#)\d{17,19}>`)
-					active := false
-					interactionResponse := NewInteractionResponseToSpecificCommand(1, "Starting announcement|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the initial response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-						return
-					}
-					announceDescription := ""
-					announceTitle := ""
-					channelID := ""
-					for _, option := range interactionData.Options {
-						if _, ok := option.Value.(string); !ok {
-							WriteErrorLog(fmt.Sprintf("The discordgo template is set to garuentee this to be a string, but its not, for officer %s, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), "Data is not string")
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|A bug happened inside the bot - Please report this to %s", SplitOfficerName(officerGMArlissa)["Name"]))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}	
-						switch option.Name {
-							case "title": {
-								announceTitle = option.Value.(string)
-								fmt.Println("TITLE", announceTitle)
-								if exists, channelID := CheckForPost(announceTitle); exists {
-									interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An announcement with that title already exists <#%s>\nPlease delete the old one first or run again with a different title", channelID))
-									_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-										Embeds: &interactionResponse.Data.Embeds,
-									})
-									if err != nil {
-										WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response of announcement already exists in channel ID %s to user %s using slash command /announcebot, during the function UseSlashCommand()", channelID, ResolvePlayerID(userID, innerSession)), err.Error())
-									}
-									return
-								} else {
-									fmt.Println("TITLE DOESNT EXIST")
-								}
-							}
-							case "description": {
-								announceDescription = option.StringValue()
-								fmt.Println("Descriptiojm", announceDescription, len(announceDescription))
-								if len(announceDescription) <= 25 {
-									interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The announcement provided of `%s` is less than 25 characters long...", announceDescription))
-									_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-										Embeds: &interactionResponse.Data.Embeds,
-									})
-									if err != nil {
-										WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error reponse to user %s about description being too short, found len %d but need len 25 minimum, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession), len(announceDescription)), err.Error())
-									}
-									fmt.Println("Why dont we stop on this return?")
-									return
-								}
-								mentions := patternDiscordMentions.FindAllString(announceDescription, -1)
-								if len(mentions) == 0 && (strings.Contains(announceDescription, "#") || strings.Contains(announceDescription, "@")) {
-									interactionResponse = NewInteractionResponseToSpecificCommand(1, "announcebot|It looks like you tried to add either users, channels or roles to the description.\nMake sure tags are added to the description correctly and try again!")
-									_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-										Embeds: &interactionResponse.Data.Embeds,
-									})
-									if err != nil {
-										WriteErrorLog(fmt.Sprintf("An error occured while trying to sent warning to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-									}
-									return
-								} else {
-									fmt.Println("DO WE REACH HERE?", announceDescription)
-								}
-							}
-							case "channel": {
-									if id := RetrieveChannelID(option.StringValue()); id != "" {
-										channelID = id
-									} else {
-										interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The channel provided %s is not valid - Please rerun and use format #<channel>.\nMake sure the channel link is parsed, so DONT type it manually", option.StringValue()))
-										_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-											Embeds: &interactionResponse.Data.Embeds,
-										})
-										if err != nil {
-											WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the channel format being incorrect, using the slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-										}
-										return
-									}
-								}
-							}
-						}
-						fmt.Println("DID WE FIND A CHANNEL ID?", channelID)
-						embedToSend := &discordgo.MessageEmbed{
-							Title: announceTitle,
-							Color: blueColor,
-							Fields: []*discordgo.MessageEmbedField{
-								{	
-									Value: announceDescription,
-									Name: "\u200B",
-								},
-							},
-						}
-						linkedChannelName := GetChannelName(channelID, innerSession)
-						if linkedChannelName == "" && active {
-							WriteErrorLog(fmt.Sprintf("The length of the resolved linked channel name is 0, which means we cannot find the channel again when it changes id - This means the config found at %s was changed BEFORE this code could run, which means it will return early, for user %s using slash command /announcebot, during the function UseSlashCommand()", configPath, ResolvePlayerID(userID, innerSession)), "Channel not found")
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|The channel <#%s> has changed before the bot could find it, please rerun command `/announcebot`\nPro tip: press arrow up, in the chat to reuse the same command and values", channelID))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while tryhing to sent error response to user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-						message, err := innerSession.ChannelMessageSendComplex(channelInfo, &discordgo.MessageSend{
-							Embeds: []*discordgo.MessageEmbed{embedToSend},
-						})
-						active = channelID != ""
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the announce message in channel %s, user %s using slash command /botannounce, during the function UseSlashCommand()", channelInfo, ResolvePlayerID(userID, innerSession)), err.Error())
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An error happened inside the bot - %s", err.Error()))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the announcement message that could not be sent, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-						_, err = innerSession.ChannelMessageSend(channelInfo, strings.Join(SeperateAnyTagsInMessage(announceDescription), ", "))
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent tag message, for user %s using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("announcebot|An error happened inside the bot - %s", err.Error()))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s about the announcement message that could not be sent, using slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-							}
-							return
-						}
-						post := trackPost{
-							ChannelID: message.ChannelID,
-							LinkedChannelID: channelID,
-							Active: active,
-							MessageID: message.ID,
-							LinkedChannelName:linkedChannelName,
-						}
-						ReadWriteTrackPosts(post)
-						select {
-							case trackCacheChanged <- struct{}{}:
-							default:
-						}
-						fmt.Println("DO WE REACH TOWARDS THE END=?", post)
-						returnString := ""
-						if active {
-							returnString = fmt.Sprintf("Successfully created and will be tracked to channel ID <#%s>\nTo stop the tracking of a specific post, please run `/stopannouncebot`", channelID)
-						} else {
-							returnString = fmt.Sprintf("Successfully created message and can be found here => https://discord.com/channels/%s/%s/%s", serverID, post.ChannelID, post.MessageID)
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("announcebot|%s", returnString))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent final successfull response to user %s, using the slash command /announcebot, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-						
-				}
-				case "raidsummary":
-					{
-						useOnlyMainRaids, _ := CheckUserBoolResponseFlag(interactionData.Options, "includesmallraids")
-						switch interactionData.Options[0].Name {
-						case "alltime":
-							{
-								fmt.Println("YEP WE ARE HERE")
-							}
-						case "month":
-							{
-								interactionResponses, err := NewWarcraftLogsGeneralDataResponse(useOnlyMainRaids, "")
-								if err != nil {
-									responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: %s", SplitOfficerName(officerGMArlissa)["Name"], err.Error()))
-									err := innerSession.InteractionRespond(event.Interaction, &responseError)
-									if err != nil {
-										WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-									}
-									break
-								}
-								if len(interactionResponses[0].Data.Embeds) > 10 {
-									responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: The number of embeds exceeded 10, raids must be merged incorrectly %s", SplitOfficerName(officerGMArlissa)["Name"], crackedBuiltin))
-									for _, embed := range interactionResponses[0].Data.Embeds {
-										fmt.Println("EMBED TITLE::", embed.Title)
-									}
-									err := innerSession.InteractionRespond(event.Interaction, &responseError)
-									if err != nil {
-										WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-									}
-									break
-								}
-								err = innerSession.InteractionRespond(event.Interaction, interactionResponses[0])
-								if err != nil {
-									WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-								}
-							}
-						case "lastraid":
-							{
+func HandleSimpleMessage(request *slashCommandRequest) {
+	if request.Data.Options == nil {
+		WriteInformationLog("The user %s did not provide any value to the command, this is crucial for the code to run, during function UseSlashCommand() breaing from loop...", "User input is nil")
+		return
+	}
 
-							}
-						case "specificraid":
-							{
+	if stringValue, ok := request.Data.Options[0].Value.(string); ok {
+		request.Session.InteractionRespond(request.Event.Interaction, &discordgo.InteractionResponse{
+			Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		})
+		copyTemplate := DeepCopyInteractionResponse(slashCommandAdminCenter["simplemessage"].Responses["messagetouser"].Response)
+		sliceOfResponseString := SeperateAnyTagsInMessage(stringValue)
+		embedFields := []*discordgo.MessageEmbedField{
+			{Value: stringValue},
+		}
+		copyTemplate.Data.Embeds[0].Fields = embedFields
+		_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &copyTemplate.Data.Embeds,
+		})
+		if err != nil {
+			err = request.Session.InteractionRespond(request.Event.Interaction, copyTemplate)
+			if err != nil {
+				WriteErrorLog("It was not possible to sent a failed slash command error back to the admin, breaking early inside function UseSlashCommand()", err.Error())
+				return
+			}
 
-							}
-						case "daysorweeks":
-							{
-								if useDefaultTimeString, _ := CheckUserBoolResponseFlag(interactionData.Options, "timestring"); useDefaultTimeString {
-									interactionResponses, err := NewWarcraftLogsGeneralDataResponse(useOnlyMainRaids, "30d")
-									if err != nil {
-										responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: %s", SplitOfficerName(officerGMArlissa)["Name"], err.Error()))
-										err := innerSession.InteractionRespond(event.Interaction, &responseError)
-										if err != nil {
-											WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-										}
-										break
-									}
-									if len(interactionResponses) == 0 {
-										fmt.Println("WE BROKE FROM THE INTERACTION")
-										break
-									}
-									if len(interactionResponses[0].Data.Embeds) > 9 {
-										responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: The number of embeds exceeded 10, raids must be merged incorrectly %s", SplitOfficerName(officerGMArlissa)["Name"], antiCrackedBuiltin))
-										err := innerSession.InteractionRespond(event.Interaction, &responseError)
-										if err != nil {
-											WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-										}
-										break
-									}
-									err = innerSession.InteractionRespond(event.Interaction, interactionResponses[0])
-									if err != nil {
-										WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
-									}
-								} else {
-									subCommand := interactionData.Options[0]
-									timeStringValue := ""
-									for _, option := range subCommand.Options {
-										if option.Name == "timestring" {
-											if option.Value != nil {
-												timeStringValue = option.Value.(string)
-												// use timestring here
-											}
-										}
-									}
-									if timeStringValue == "" {
-										for _, option := range interactionData.Options {
-											fmt.Println("OPTIONAL NAME", option.Name, option.Value, len(interactionData.Options))
-										}
-										responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary dayorweek|An error inside the bot, please report this error to %s\n\nError: Even though check through inner function checkUserBoolResponseFlag(), the command response is nil.. %s", SplitOfficerName(officerGMArlissa)["Name"], antiCrackedBuiltin))
-										err := innerSession.InteractionRespond(event.Interaction, &responseError)
-										if err != nil {
-											WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
-										}
-										break
-									}
-									interactionResponses, err := NewWarcraftLogsGeneralDataResponse(useOnlyMainRaids, timeStringValue)
-									fmt.Println("RESPONSES FOUND:", len(interactionResponses))
-									if err != nil {
-										WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
-									}
-									if len(interactionResponses) > 0 {
-										interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("raidsummary daysorweeks|Request period: **%s**\n\n**Please note that the command output might be split into multiple messages** %s", timeStringValue, crackedBuiltin))
-										err = innerSession.InteractionRespond(event.Interaction, &interactionResponse)
+		}
+		if sliceOfResponseString != nil {
+			tagUsersString := strings.Join(sliceOfResponseString, ", ")
+			request.Session.ChannelMessageSend(request.Event.ChannelID, tagUsersString)
+		}
+	} else {
+		WriteErrorLog(fmt.Sprintf("It was not possible to convert the value %s to string, this is crucial for this slash command, will break early...", request.Data.Options[0].Value), "During function UseSlashCommand()")
+	}
+}
 
-										if err != nil {
-											WriteErrorLog("An error occurred while sending the initial interaction response", err.Error())
-										}
+func HandleSeeBench(request *slashCommandRequest) {
+	options := request.Data.Options
+	raiderDiscordID := ""
+	period := ""
+	singleRaider := false
+	for _, option := range options {
+		switch option.Name {
+		case "playername":
+			{
+				singleRaider = true
+				raiderDiscordID = option.StringValue()
+			}
+		case "period":
+			{
+				period = option.StringValue()
+			}
+		}
+	}
 
-										for _, response := range interactionResponses {
-											// Ensure the followup is ephemeral
-											response.Data.Flags = discordgo.MessageFlagsEphemeral
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "seebench|Running command")
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to send initial defered response to the user %s, using slash command /seebench, during the function UseSlashCommand()", err.Error())
+		return
+	}
+	if singleRaider && len(strings.Split(raiderDiscordID, "@")) != 2 && raiderDiscordID != "" {
+		fmt.Println("VALUES:", singleRaider, len(strings.Split(raiderDiscordID, "@")), raiderDiscordID)
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, "seebench|Format incorrect - Must be @<playername>, e.g. @Arlissa")
+		_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+		}
+		return
+	}
+	raiderProfiles := []raiderProfile{}
+	if raiderDiscordID == "" {
+		raiderProfiles = GetRaiderProfiles(request.Guild.ServerID)
+	} else {
+		profile, _ := GetRaiderProfile(request.Guild.ServerID, FormatRaiderID(raiderDiscordID))
+		raiderProfiles = append(raiderProfiles, profile)
+	}
+	switch len(raiderProfiles) {
+	case 0:
+		{
+			interactionResponse := NewInteractionResponseToSpecificCommand(0, "seebench|No raider profiles found... Please run bot command `/resetraidcache` Then run this command again")
+			err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+			if err != nil {
+				WriteErrorLog("An error occured while trying to send error response to user %s using slash command /seebench, during the function UseSlashCommand()", err.Error())
+			}
+			return
+		}
+	default:
+		{
+			returnString := NewRaidProfileBenchSummaries(raiderProfiles, period)
 
-											// Convert InteractionResponseData into WebhookParams
-											followup := &discordgo.WebhookParams{
-												Content: response.Data.Content,
-												Embeds:  response.Data.Embeds,
-												Flags:   discordgo.MessageFlagsEphemeral,
-											}
+			interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("`seebench` For period: **%s**|%s", period, returnString))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog("An error occured while trying to send final response to user %s, during the slash command /seebench, during the function UseSlashCommand()", err.Error())
+			}
+			//interactionResponse := NewInteractionResponseToSpecificCommand(2, )
+		}
+	}
+}
 
-											_, err = innerSession.FollowupMessageCreate(event.Interaction, true, followup)
-											if err != nil {
-												WriteErrorLog("An error occurred while sending follow-up embeds", err.Error())
-											}
-										}
-									}
+// Without the option playername the attendance of every raider is shown
+func HandleSeeRaiderAttendance(request *slashCommandRequest) {
+	if !request.HasOption("playername") {
+		HandleSeeAllRaidersAttendance(request)
+		return
+	}
+	nameSlice := strings.Split(request.StringOption("playername"), "@")
+	if len(nameSlice) != 2 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|Format incorrect - Must be @<playername>, e.g. @Arlissa")
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+		}
+		return
+	}
+	raiderProfile, errString := GetRaiderProfile(request.Guild.ServerID, nameSlice[1])
+	if errString != "" {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("playername|%s", errString))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+		}
+		return
+	}
+	attendanceSummary := NewRaidProfileAttendanceSummary(raiderProfile)
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraiderattendance|%s", attendanceSummary))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+	}
+}
 
-									if err != nil {
-										WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
-									}
-								}
-							}
-						}
-					}
-				case "promotetrial":
-					{
-						nameSlice := strings.Split(interactionData.Options[0].StringValue(), "@")
-						if len(nameSlice) != 2 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "promotetrial|Format incorrect - Must be @<playername>, e.g. @Arlissa")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-							}
-							break
-						}
-						taggedID := strings.ReplaceAll(nameSlice[1], ">", "")
-						nickNameTrial := ResolvePlayerID(taggedID, innerSession)
-						matched := false
-						for _, trial := range RetrieveUsersInRole([]string{roleTrial}, innerSession) {
-							if trial == taggedID {
-
-								matched = true
-								err := innerSession.GuildMemberRoleAdd(serverID, trial, roleRaider)
-								err2 := innerSession.GuildMemberRoleRemove(serverID, trial, roleTrial)
-								if err != nil || err2 != nil {
-									response := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("promotetrial|The following error occured when trying to add %s to the raider role or remove trial", nickNameTrial))
-									innerSession.InteractionRespond(event.Interaction, &response)
-									WriteErrorLog(fmt.Sprintf("An error occured while trying to promote user %s to raider or remove trial role, during the function UseSlashCommand()", nickNameTrial), fmt.Sprintf("%s%s", err.Error(), err2.Error()))
-									break
-								}
-								break
-							}
-						}
-						if !matched {
-							response := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("promotetrial|The user %s did not have the trial role...", nickNameTrial))
-							err := innerSession.InteractionRespond(event.Interaction, &response)
-							if err != nil {
-								WriteErrorLog("An error ocurred while trying to respond to admin, during the function UseSlashCommand()", err.Error())
-							}
-						}
-						response := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("promotetrial|The player %s has been successfully promoted to rank Raider", nickNameTrial))
-						err := innerSession.InteractionRespond(event.Interaction, &response)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to respond to admin, during the function UseSlashCommand()", err.Error())
-						}
-					}
-				case "simplemessage":
-					{
-						if interactionData.Options == nil {
-							WriteInformationLog("The user %s did not provide any value to the command, this is crucial for the code to run, during function UseSlashCommand() breaing from loop...", "User input is nil")
-							break
-						}
-
-						if stringValue, ok := interactionData.Options[0].Value.(string); ok {
-							innerSession.InteractionRespond(event.Interaction, &discordgo.InteractionResponse{
-								Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
-							})
-							copyTemplate := DeepCopyInteractionResponse(slashCommandAdminCenter["simplemessage"].Responses["messagetouser"].Response)
-							sliceOfResponseString := SeperateAnyTagsInMessage(stringValue)
-							embedFields := []*discordgo.MessageEmbedField{
-								{Value: stringValue},
-							}
-							copyTemplate.Data.Embeds[0].Fields = embedFields
-							_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &copyTemplate.Data.Embeds,
-							})
-							if err != nil {
-								err = innerSession.InteractionRespond(event.Interaction, copyTemplate)
-								if err != nil {
-									WriteErrorLog("It was not possible to sent a failed slash command error back to the admin, breaking early inside function UseSlashCommand()", err.Error())
-									break
-								}
+func HandleSeeRaiderMissedRaids(request *slashCommandRequest) {
+	nameSlice := strings.Split(request.StringOption("playername"), "@")
+	if len(nameSlice) != 2 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|Format incorrect - Must be @<playername>, e.g. @Arlissa")
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", request.UserID), err.Error())
+		}
+		return
+	}
+	raiderProfile, errString := GetRaiderProfile(request.Guild.ServerID, nameSlice[1])
+	if errString != "" {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("seeraidermissedraids|The player %s is not a raider...", raiderProfile.MainCharName))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent respond to user from slash command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
+			return
+		}
+	}
+	mapOfRaidsMissed := make(map[string]bool)
+	listOfRaids := []string{}
+	for period, raiderAttendance := range raiderProfile.AttendanceInfo {
+		for _, missedRaid := range raiderAttendance.RaidsMissed {
+			if !mapOfRaidsMissed[missedRaid] && period != "guildStart" {
+				sliceOfRaidName := strings.Split(missedRaid, "/")
+				listOfRaids = append(listOfRaids, fmt.Sprintf("(%s) https://fresh.warcraftlogs.com/reports/%s", sliceOfRaidName[0], sliceOfRaidName[1]))
+				mapOfRaidsMissed[missedRaid] = true
+			}
+		}
+	}
+	if len(listOfRaids) == 0 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraidermissedraids|No raids missed for raider: %s", raiderProfile.MainCharName))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent respond to the user with command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
+			return
+		}
+	} else {
+		interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraidermissedraids|The following %d raids has been missed last 3 months by: %s\n\n%s", len(listOfRaids), raiderProfile.MainCharName, strings.Join(listOfRaids, "\n")))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent respond to the user with command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
+			return
+		}
+	}
+}
 
-							}
-							if sliceOfResponseString != nil {
-								tagUsersString := strings.Join(sliceOfResponseString, ", ")
-								innerSession.ChannelMessageSend(event.ChannelID, tagUsersString)
-							}
-						} else {
-							WriteErrorLog(fmt.Sprintf("It was not possible to convert the value %s to string, this is crucial for this slash command, will break early...", interactionData.Options[0].Value), "During function UseSlashCommand()")
-						}
-					}
-				case "seebench":
-					{
-						options := interactionData.Options
-						raiderDiscordID := ""
-						period := ""
-						singleRaider := false
-						for _, option := range options {
-							switch option.Name {
-							case "playername":
-								{
-									singleRaider = true
-									raiderDiscordID = option.StringValue()
-								}
-							case "period":
-								{
-									period = option.StringValue()
-								}
-							}
-						}
+func HandleSyncDiscordRoles(request *slashCommandRequest) {
+	if !request.BoolOption("fullsync") {
+		interactionResponse := NewInteractionResponseToSpecificCommand(1, "syncdiscordroles|Delta sync is not enabled yet... Please set fullsync to true")
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
+		}
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "syncdiscordroles|Starting sync, please wait...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
+		return
+	}
+	returnAnswer := ManageMergedGroups(request.Guild.ServerID, request.Session, "full")
 
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "seebench|Running command")
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to send initial defered response to the user %s, using slash command /seebench, during the function UseSlashCommand()", err.Error())
-							break
-						}
-						if singleRaider && len(strings.Split(raiderDiscordID, "@")) != 2 && raiderDiscordID != "" {
-							fmt.Println("VALUES:", singleRaider, len(strings.Split(raiderDiscordID, "@")), raiderDiscordID)
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "seebench|Format incorrect - Must be @<playername>, e.g. @Arlissa")
-							_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-							}
-							break
-						}
-						raiderProfiles := []raiderProfile{}
-						if raiderDiscordID == "" {
-							raiderProfiles = GetRaiderProfiles()
-						} else {
-							profile, _ := GetRaiderProfile(FormatRaiderID(raiderDiscordID))
-							raiderProfiles = append(raiderProfiles, profile)
-						}
-						switch len(raiderProfiles) {
-						case 0:
-							{
-								interactionResponse := NewInteractionResponseToSpecificCommand(0, "seebench|No raider profiles found... Please run bot command `/resetraidcache` Then run this command again")
-								err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-								if err != nil {
-									WriteErrorLog("An error occured while trying to send error response to user %s using slash command /seebench, during the function UseSlashCommand()", err.Error())
-								}
-								break
-							}
-						default:
-							{
-								returnString := NewRaidProfileBenchSummaries(raiderProfiles, period)
+	if len(returnAnswer) == 1 {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("syncdiscordroles|%s", returnAnswer[0]))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Content: GetStringPointer(returnAnswer[0]),
+		})
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
+			return
+		}
+	}
 
-								interactionResponse = NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("`seebench` For period: **%s**|%s", period, returnString))
-								_, err = session.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-									Embeds: &interactionResponse.Data.Embeds,
-								})
-								if err != nil {
-									WriteErrorLog("An error occured while trying to send final response to user %s, during the slash command /seebench, during the function UseSlashCommand()", err.Error())
-								}
-								//interactionResponse := NewInteractionResponseToSpecificCommand(2, )
-							}
-						}
-					}
-				case "seeraiderattendance":
-					{
-						nameSlice := strings.Split(interactionData.Options[0].StringValue(), "@")
-						if len(nameSlice) != 2 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|Format incorrect - Must be @<playername>, e.g. @Arlissa")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-							}
-							break
-						}
-						raiderProfile, errString := GetRaiderProfile(nameSlice[1])
-						if errString != "" {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("playername|%s", errString))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-							}
-							break
-						}
-						attendanceSummary := NewRaidProfileAttendanceSummary(raiderProfile)
-						interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraiderattendance|%s", attendanceSummary))
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-						}
-					}
-				case "seeraidermissedraids":
-					{
-						nameSlice := strings.Split(interactionData.Options[0].StringValue(), "@")
-						if len(nameSlice) != 2 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, "seeraiderattendance|Format incorrect - Must be @<playername>, e.g. @Arlissa")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent response to user %s and command playername during the function UseSlashCommand()", userID), err.Error())
-							}
-							break
-						}
-						raiderProfile, errString := GetRaiderProfile(nameSlice[1])
-						if errString != "" {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("seeraidermissedraids|The player %s is not a raider...", raiderProfile.MainCharName))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent respond to user from slash command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
-								break
-							}
-						}
-						mapOfRaidsMissed := make(map[string]bool)
-						listOfRaids := []string{}
-						for period, raiderAttendance := range raiderProfile.AttendanceInfo {
-							for _, missedRaid := range raiderAttendance.RaidsMissed {
-								if !mapOfRaidsMissed[missedRaid] && period != "guildStart" {
-									sliceOfRaidName := strings.Split(missedRaid, "/")
-									listOfRaids = append(listOfRaids, fmt.Sprintf("(%s) https://fresh.warcraftlogs.com/reports/%s", sliceOfRaidName[0], sliceOfRaidName[1]))
-									mapOfRaidsMissed[missedRaid] = true
-								}
-							}
-						}
-						if len(listOfRaids) == 0 {
-							interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraidermissedraids|No raids missed for raider: %s", raiderProfile.MainCharName))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent respond to the user with command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
-								break
-							}
-						} else {
-							interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("seeraidermissedraids|The following %d raids has been missed last 3 months by: %s\n\n%s", len(listOfRaids), raiderProfile.MainCharName, strings.Join(listOfRaids, "\n")))
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent respond to the user with command seeraidermissedraids, during the function UseSlashCommand()", err.Error())
-								break
-							}
-						}
-					}
-				case "syncdiscordroles":
-					{
-						if !interactionData.Options[0].BoolValue() {
-							interactionResponse := NewInteractionResponseToSpecificCommand(1, "syncdiscordroles|Delta sync is not enabled yet... Please set fullsync to true")
-							err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
-							}
-						}
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, "syncdiscordroles|Starting sync, please wait...", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
-							break
-						}
-						returnAnswer := ManageMergedGroups(innerSession, "full")
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Content: GetStringPointer(returnAnswer[0]),
+	})
 
-						if len(returnAnswer) == 1 {
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("syncdiscordroles|%s", returnAnswer[0]))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Content: GetStringPointer(returnAnswer[0]),
-							})
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
-								break
-							}
-						}
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
+	}
+}
 
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Content: GetStringPointer(returnAnswer[0]),
-						})
+func HandleRaidSummaryMonth(request *slashCommandRequest) {
+	useOnlyMainRaids, _ := CheckUserBoolResponseFlag(request.Data.Options, "includesmallraids")
+	interactionResponses, err := NewWarcraftLogsGeneralDataResponse(request.Guild.ServerID, useOnlyMainRaids, "")
+	if err != nil {
+		responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: %s", GetGuildMaster(request.Guild.ServerID).Name, err.Error()))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &responseError)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	if len(interactionResponses[0].Data.Embeds) > 10 {
+		responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: The number of embeds exceeded 10, raids must be merged incorrectly %s", GetGuildMaster(request.Guild.ServerID).Name, crackedBuiltin))
+		for _, embed := range interactionResponses[0].Data.Embeds {
+			fmt.Println("EMBED TITLE::", embed.Title)
+		}
+		err := request.Session.InteractionRespond(request.Event.Interaction, &responseError)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	err = request.Session.InteractionRespond(request.Event.Interaction, interactionResponses[0])
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
+	}
+}
 
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent respond to the user with command syncdiscordroles, during the function UseSlashCommand()", err.Error())
-						}
-					}
-				case "aboutme":
-					{
-						fmt.Println("WE DONT REACH HERE?")
-						interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("aboutme|This feature is not out yet 🚧 please contact <@%s> for more information", SplitOfficerName(officerGMArlissa)["ID"]))
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to reply to user %s using slash command /aboutme, during function UseSlashCommand()", err.Error())
-						}
-					}
-				}
+func HandleRaidSummaryDaysOrWeeks(request *slashCommandRequest) {
+	useOnlyMainRaids, _ := CheckUserBoolResponseFlag(request.Data.Options, "includesmallraids")
+	if useDefaultTimeString, _ := CheckUserBoolResponseFlag(request.Data.Options, "timestring"); useDefaultTimeString {
+		interactionResponses, err := NewWarcraftLogsGeneralDataResponse(request.Guild.ServerID, useOnlyMainRaids, "30d")
+		if err != nil {
+			responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: %s", GetGuildMaster(request.Guild.ServerID).Name, err.Error()))
+			err := request.Session.InteractionRespond(request.Event.Interaction, &responseError)
+			if err != nil {
+				WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
 			}
+			return
 		}
-		if CheckForRaiderRank(userID, innerSession) && strings.Contains("myattendance,mymissedraids,mynewmain,myraiderperformance,myreminder,hi,howto,joke,feedback", interactionData.Name) {
-			newRaiderProfile, _ := GetRaiderProfile(userID)
-			switch interactionData.Name {
-			case "myattendance":
-				{
-					attendanceSummary := NewRaidProfileAttendanceSummary(newRaiderProfile)
-					interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("myattendance|%s", attendanceSummary))
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error ocurred while trying to sent the response error to the user from slash command /myattendance, during the function UseSlashCommand()", err.Error())
-					}
+		if len(interactionResponses) == 0 {
+			fmt.Println("WE BROKE FROM THE INTERACTION")
+			return
+		}
+		if len(interactionResponses[0].Data.Embeds) > 9 {
+			responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary month|An error inside the bot, please report this error to %s\n\nError: The number of embeds exceeded 10, raids must be merged incorrectly %s", GetGuildMaster(request.Guild.ServerID).Name, antiCrackedBuiltin))
+			err := request.Session.InteractionRespond(request.Event.Interaction, &responseError)
+			if err != nil {
+				WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
+			}
+			return
+		}
+		err = request.Session.InteractionRespond(request.Event.Interaction, interactionResponses[0])
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary month, during the function UseSlashCommand()", err.Error())
+		}
+	} else {
+		subCommand := request.Data.Options[0]
+		timeStringValue := ""
+		for _, option := range subCommand.Options {
+			if option.Name == "timestring" {
+				if option.Value != nil {
+					timeStringValue = option.Value.(string)
+					// use timestring here
 				}
-			case "mymissedraids":
-				{
-					mapOfRaidsMissed := make(map[string]bool)
-					listOfRaids := []string{}
-					for period, raiderAttendance := range newRaiderProfile.AttendanceInfo {
-						for _, missedRaid := range raiderAttendance.RaidsMissed {
-							if !mapOfRaidsMissed[missedRaid] && period != "guildStart" {
-								sliceOfRaidName := strings.Split(missedRaid, "/")
-								listOfRaids = append(listOfRaids, fmt.Sprintf("(%s) https://fresh.warcraftlogs.com/reports/%s", sliceOfRaidName[0], sliceOfRaidName[1]))
-								mapOfRaidsMissed[missedRaid] = true
-							}
-						}
-					}
-					if len(listOfRaids) == 0 {
-						interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("mymissedraids|Not 1 single raid missed the last 3 months, pumper %s", crackedBuiltin))
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent the respond to user %s using command /mymissedraids, during the function UseSlashCommand()", err.Error())
-						}
-						break
-					}
-					responseString := ""
-					if len(listOfRaids) > 4 {
-						responseString = fmt.Sprintf(
-							"You have missed over 4 raids the last 3 months %s, that is 25%% of all the raids in the period.\n\n"+
-								"If it's due to vacation, all good — if it's instead due to motivation, please reach out to %s or %s, let's talk.\n\n"+
-								"The list of missed raids:\n\n%s",
-							antiCrackedBuiltin,
-							fmt.Sprintf("<@%s>", SplitOfficerName(officerGMArlissa)["ID"]),
-							fmt.Sprintf("<@%s>", SplitOfficerName(officerRogue)["ID"]),
-							strings.Join(listOfRaids, "\n"),
-						)
-						fmt.Println("STRING:", responseString)
-					} else {
-						responseString = fmt.Sprintf("You have missed %d raids over the last 3 months, please see the list of raids:\n\n%s", len(listOfRaids), strings.Join(listOfRaids, "\n"))
-					}
-					interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("mymissedraids|%s", responseString))
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent the response to user %s using command /mymissedraids, during the function UseSlashCommand()", err.Error())
-					}
+			}
+		}
+		if timeStringValue == "" {
+			for _, option := range request.Data.Options {
+				fmt.Println("OPTIONAL NAME", option.Name, option.Value, len(request.Data.Options))
+			}
+			responseError := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("raidsummary dayorweek|An error inside the bot, please report this error to %s\n\nError: Even though check through inner function checkUserBoolResponseFlag(), the command response is nil.. %s", GetGuildMaster(request.Guild.ServerID).Name, antiCrackedBuiltin))
+			err := request.Session.InteractionRespond(request.Event.Interaction, &responseError)
+			if err != nil {
+				WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
+			}
+			return
+		}
+		interactionResponses, err := NewWarcraftLogsGeneralDataResponse(request.Guild.ServerID, useOnlyMainRaids, timeStringValue)
+		fmt.Println("RESPONSES FOUND:", len(interactionResponses))
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
+		}
+		if len(interactionResponses) > 0 {
+			interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("raidsummary daysorweeks|Request period: **%s**\n\n**Please note that the command output might be split into multiple messages** %s", timeStringValue, crackedBuiltin))
+			err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+
+			if err != nil {
+				WriteErrorLog("An error occurred while sending the initial interaction response", err.Error())
+			}
+
+			for _, response := range interactionResponses {
+				// Ensure the followup is ephemeral
+				response.Data.Flags = discordgo.MessageFlagsEphemeral
+
+				// Convert InteractionResponseData into WebhookParams
+				followup := &discordgo.WebhookParams{
+					Content: response.Data.Content,
+					Embeds:  response.Data.Embeds,
+					Flags:   discordgo.MessageFlagsEphemeral,
 				}
-			case "mynewmain":
-				{
-					userInput := interactionData.Options[0].StringValue()
-					raiderProfileOld, errString := GetRaiderProfile(userInput)
-					if errString != "" {
-						interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("mynewmain|The char with name %s is not found in any raids, please contact @Arlissa", userInput))
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent the response to user %s using command /mynewmain, during the function UseSlashCommand()", err.Error())
-							break
-						}
-					}
-					interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("mynewmain|Request sent to the officers about linking old main %s with new main %s\n\nYou will recieve a response from the bot, once an officer responds to the request", raiderProfileOld.MainCharName, newRaiderProfile.MainCharName))
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent the response to user %s using command /mynewmain, during the function UseSlashCommand()", err.Error())
-						break
-					}
-					_, err = innerSession.ChannelMessageSendComplex(channelOfficer, &discordgo.MessageSend{
-						Content: fmt.Sprintf("The raider %s has requested to have his/hers raider attendance added from char %s", newRaiderProfile.MainCharName, raiderProfileOld.MainCharName),
-						Components: []discordgo.MessageComponent{
-							discordgo.ActionsRow{
-								Components: []discordgo.MessageComponent{
-									discordgo.Button{
-										Label:    "Yes",
-										Style:    discordgo.PrimaryButton,
-										CustomID: "button_yes",
-									},
-									discordgo.Button{
-										Label:    "No",
-										Style:    discordgo.DangerButton,
-										CustomID: "button_no",
-									},
-								},
-							},
-						},
-					})
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a complex message in the officer channel from the use of command /mynewmain from raider %s, during the function UseSlashCommand()", newRaiderProfile.MainCharName), err.Error())
-					}
+
+				_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, followup)
+				if err != nil {
+					WriteErrorLog("An error occurred while sending follow-up embeds", err.Error())
 				}
-			case "myraiderperformance":
-				{
-					raider := raiderProfile{}
-					interactionResponse := NewInteractionResponseToSpecificCommand(1, "Calculating performance|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent initial response to the user %s, using the slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
-						return
-					}
-					raiderProfiles := ReadWriteRaiderProfiles([]raiderProfile{}, false)
-					if len(raiderProfiles) == 0 {
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, "No raider-profiles found|Please ask an officer to run command `resetraidcache`")
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error ocurred while trying to send error response to user %s, using slash command /myraiderperformance, during the function UseSlashCommand", err.Error())
-						}
-						return
-					}
-					currentRaiderProfileMap := map[string]raiderProfile{}
-					for _, raider := range raiderProfiles {
-						if raider.ID == userID {
-							currentRaiderProfileMap[raider.ID] = raider
-						}
-					}
-					if _, ok := currentRaiderProfileMap[userID]; !ok {
-						interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("Raider not found|This is either due to %s not being in 1 main raid yet or a bug. You can verify this by running command `/myattendance`", ResolvePlayerID(userID, innerSession)))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						WriteErrorLog(fmt.Sprintf("An error ocurred while trying to send error response to user 2 %s , using slash command /myraiderperformance, during the function UseSlashCommand",userID), "User not found")
-						return
-					}
-					timeRaidDataLastUpdated, _ := time.Parse(timeLayoutLogs, currentRaiderProfileMap[userID].RaidData.TimeOfData)
-					isDataOld := CheckForLaterThanDuration(timeRaidDataLastUpdated, 7)
-					if isDataOld {
-						logsLastThreeMonth, err := ReadRaidDataCache(time.Now().AddDate(0, -3, 0), true)
-						logsToKeep := []logAllData{}
-						if err != nil {
-							interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myraiderperformance|%s", err.Error()))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							if err != nil {
-								WriteErrorLog("An error occured while trying to send an error message to the user, the message is %s, using slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
-							}
-							return
-						}
-						for x, log := range logsLastThreeMonth {
-							mapOfPlayers := make(map[string]logPlayer)
-							interactionResponse = NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("myraiderperformance|Retrieving Warcraftlogs data - **%.1f%% so far**", float64(x)/float64(len(logsLastThreeMonth))*100)) //interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("Progess on job|**Completed %.1f%% so far**", float64(x) / float64(len(quriesToRun)) * 100))
-							_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Embeds: &interactionResponse.Data.Embeds,
-							})
-							for _, player := range log.Players {
-								mapOfPlayers[player.Name] = player
-							}
-							raiderName := ResolvePlayerID(userID, innerSession)
-							if _, ok := mapOfPlayers[raiderName]; ok {
-								logsToKeep = append(logsToKeep, log)
-							}
-						}
-						interactionResponse = NewInteractionResponseToSpecificCommand(1, "myraiderperformance|Calculating your raid-data, please wait...")
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to sent initial response to the user %s, using the slash command /myraiderperformance 2, during the function UseSlashCommand()", err.Error())
-							break
-						}
-						time.Sleep(time.Second * 2)
-						messageGIF, err := innerSession.FollowupMessageCreate(event.Interaction, true, NewWebhookParamGIF("the-calculator.gif", yellowColor))
-						fmt.Println("WHO IS THIS GUY", currentRaiderProfileMap[userID], userID, len(logsToKeep))
-						raider = CalculateRaiderPerformance(currentRaiderProfileMap[userID], logsToKeep)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to clear last FollowUpMessageCreate using slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
-						}
-						err = innerSession.FollowupMessageDelete(event.Interaction, messageGIF.ID)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to delete a follow up message from user %s using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-					} else {
-						raider = currentRaiderProfileMap[userID]
-					}
-					if len(raider.RaidData.LastRaid.Specs) == 0 {
-						WriteErrorLog(fmt.Sprintf("The raider %s does not have any calculcated raider performance, please see any earlier error... during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), "Missing data")
-						interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myraiderperformance|Raidata could not be calculated\nPlease consult %s for help!", SplitOfficerName(officerGMArlissa)["Name"]))
-						_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Embeds: &interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s using slash command /myraiderperformance", ResolvePlayerID(userID, innerSession)), err.Error())
-						}
-						return
-					}
-					//rowBreak := &discordgo.MessageEmbedField{Name: "\u200B", Value: "\u200B", Inline: false}
-					statLineBestBoss := &discordgo.MessageEmbedField{}
-					statLineWorseBoss := &discordgo.MessageEmbedField{}
-					topLine := &discordgo.MessageEmbedField{}
-					statLineBestBoss.Inline = true
-					statLineWorseBoss.Inline = true
-					if raider.RaidData.LastRaid.HealingDone > raider.RaidData.LastRaid.DamageDone {
-						statLineBestBoss.Name = "HPS"
-						statLineWorseBoss.Name = "HPS"
-						statLineBestBoss.Value = fmt.Sprintf("`%.f`", raider.RaidData.Parses.BestBoss.HPS)
-						statLineWorseBoss.Value = fmt.Sprintf("`%.f`", raider.RaidData.Parses.WorstBoss.HPS)
-					} else {
-						statLineBestBoss.Name = "DPS"
-						statLineWorseBoss.Name = "DPS"
-						statLineBestBoss.Value = fmt.Sprintf("`⚔ %.2f`", raider.RaidData.Parses.BestBoss.DPS)
-						statLineWorseBoss.Value = fmt.Sprintf("`⚔ %.2f`", raider.RaidData.Parses.WorstBoss.DPS)
-					}
+			}
+		}
 
-					topString := ""
-					switch {
-					case raider.RaidData.Parses.Top1:
-						{
-							topString = "#1 🥇"
-						}
-					case raider.RaidData.Parses.Top2:
-						{
-							topString = "#2 🥈"
-						}
-					case raider.RaidData.Parses.Top3:
-						{
-							topString = "#3 🥉"
-						}
-					case raider.RaidData.Parses.Top5:
-						{
-							topString = "#5"
-						}
-					default:
-						{
-							topString = "Around top 5" //Safegaurd
-						}
-					}
-					topLine = &discordgo.MessageEmbedField{Name: "Rank in class", Value: fmt.Sprintf("`%s`", topString), Inline: true}
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent a error message from the user from slash command /raidsummary daysorweeks, during the function UseSlashCommand()", err.Error())
+		}
+	}
+}
 
-					fieldsRaiderPerformance := []*discordgo.MessageEmbedField{
-						{Name: "**▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒**\r", Value: "\u200B", Inline: false},
-						topLine,
-						{Name: "From top 1", Value: fmt.Sprintf("`%.f%%`", raider.RaidData.Parses.RelativeToTop), Inline: true},
-						{Name: "Δ vs last week", Value: fmt.Sprintf("`%.f%%`", raider.RaidData.Parses.Deviation), Inline: true},
-					}
-					scalingOrdered := SortFloat64FromMap(false, mapOfPointScaleProcent)
-					weightStringSlice := []string{}
-					mapOfUniqueWeightNames := make(map[string]bool)
-					for _, scaling := range scalingOrdered {
-						for weightName, weightProcent := range mapOfPointScaleProcent {
-							if scaling == weightProcent && !mapOfUniqueWeightNames[weightName] {
-								weightStringSlice = append(weightStringSlice, fmt.Sprintf("↳ %s → `%.f%%`", strings.Split(weightName, "/")[1], weightProcent))
-								mapOfUniqueWeightNames[weightName] = true
-							}
-						}
-					}
-					specName := ""
-					if len(raider.RaidData.LastRaid.Specs) > 0 {
-						specName = raider.RaidData.LastRaid.Specs[0].Name
-					} else {
-						specName = "Not detected..."
-					}
-					embedRaiderPerformance := &discordgo.MessageEmbed{
-						Title: fmt.Sprintf("LINK TO WARCRAFTLOGS PROFILE %s", crackedBuiltin),
-						URL:   raider.RaidData.URL,
-						Description: strings.Join([]string{
-							"**📊 Calculations (last 3 months) 📊**",
-							"\u200B",
-							fmt.Sprintf("**Raid tier:** `%s`", raider.RaidData.Parses.RaidTier),
-							"\u200B",
-							fmt.Sprintf("**Raider name:** `%s`", raider.MainCharName),
-							"\u200B",
-							fmt.Sprintf("**Raider spec:** `%s`", specName),
-							"\u200B",
-							fmt.Sprintf("**Count of raiders in calculation:** `%d`", raider.RaidData.CountOfRaidersInCalculation),
-							"\u200B",
-							"**How this works**",
-							"• Bot collects data from `all raiders` over the last 3 months",
-							"• Only raiders of the `same class` are compared",
-							"• Raiders must be present in `≥ 50%` of raids to be included",
-							"• Eligible raiders are added to the `compare pool`",
-							"• Points are now calculated for each raider in the current `compare pool`",
-							"\u200B",
-							strings.Join(weightStringSlice, "\n"),
-							"↳ Sums to 100% — higher % = higher value in calculation / more points",
-							"↳ Metrics are subject to change, and `feedback` can be given using the bot!",
-							"\u200B",
-							"• The `metrics` defined above is used to calculate the following:",
-							"↳ `Rank in class` → In terms of total points, where do you stand?",
-							"↳ `From top 1 in %` → How far performance wise, are you from top 1?",
-							"↳ `Δ vs last week` → What is your performance difference (delta) compared to the week before?",
-						}, "\n"),
-						Fields: fieldsRaiderPerformance,
-						Footer: &discordgo.MessageEmbedFooter{
-							Text: fmt.Sprintf("Source: https://fresh.warcraftlogs.com/guild/id/%d", warcraftLogsGuildID),
-						},
-					}
-					_, err = innerSession.FollowupMessageCreate(event.Interaction, false, &discordgo.WebhookParams{
-						Embeds: []*discordgo.MessageEmbed{embedRaiderPerformance},
-						Flags:  discordgo.MessageFlagsEphemeral,
-					})
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a result message to user %s using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-					}
-					splitBestBossName := strings.Split(raider.RaidData.Parses.BestBoss.Name, " ")
-					splitWorstBossName := strings.Split(raider.RaidData.Parses.WorstBoss.Name, " ")
-					bestBossNameFinal := ""
-					worstBossNameFinal := ""
-					if len(splitBestBossName) >= 2 {
-						if strings.ToLower(splitBestBossName[1]) == "the" {
-							bestBossNameFinal = splitBestBossName[0]
-						} else {
-							bestBossNameFinal = strings.Join(splitBestBossName[:2], " ")
-						}
-					} else {
-						bestBossNameFinal = raider.RaidData.Parses.BestBoss.Name
-					}
-					if len(splitWorstBossName) >= 2 {
-						if strings.ToLower(splitWorstBossName[1]) == "the" {
-							worstBossNameFinal = splitWorstBossName[0]
-						} else {
-							worstBossNameFinal = strings.Join(splitWorstBossName[:2], " ")
-						}
-					} else {
-						worstBossNameFinal = raider.RaidData.Parses.WorstBoss.Name
-					}
-					if isDataOld {
-						time.Sleep(time.Second * 5)
-					}
-					fieldsRankings := []*discordgo.MessageEmbedField{
-						{Name: "\u200B", Value: "**┌────────────────\u2003Rankings\u2003──────────────┐**", Inline: false},
-						{Name: "World", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankWorld), Inline: true},
-						{Name: "Region", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankRegion), Inline: true},
-						{Name: "Server", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankServer), Inline: true},
-						// ── Highlights bosses ──
-						{Name: "\u200B", Value: "**┌────────────────\u2003Bosses\u2003────────────────┐**", Inline: false},
-						{Name: "Best", Value: fmt.Sprintf("`%s`", bestBossNameFinal), Inline: true},
-						statLineBestBoss,
-						{Name: "Kill time", Value: fmt.Sprintf("`⏱ %s`", raider.RaidData.Parses.BestBoss.KillTime), Inline: true},
-						{Name: "Worst", Value: fmt.Sprintf("`%s`", worstBossNameFinal), Inline: true},
-						statLineWorseBoss,
-						{Name: "Kill time", Value: fmt.Sprintf("`⏱ %s`", raider.RaidData.Parses.WorstBoss.KillTime), Inline: true},
-						{Name: "\u200B", Value: "**┌────────────────\u2003Parses\u2003────────────────┐**", Inline: false},
-						{Name: "Best avg", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["bestAverage"]), Inline: true},
-						{Name: "Highest", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["highest"]), Inline: true},
-						{Name: "Lowest", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["lowest"]), Inline: true},
-					}
+func HandleMyAttendance(request *slashCommandRequest) {
+	newRaiderProfile, _ := GetRaiderProfile(request.Guild.ServerID, request.UserID)
+	attendanceSummary := NewRaidProfileAttendanceSummary(newRaiderProfile)
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("myattendance|%s", attendanceSummary))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error ocurred while trying to sent the response error to the user from slash command /myattendance, during the function UseSlashCommand()", err.Error())
+	}
+}
 
-					embedRankings := &discordgo.MessageEmbed{
-						Title: fmt.Sprintf("LINK TO WARCRAFTLOGS PROFILE %s", crackedBuiltin),
-						URL:   raider.RaidData.URL,
-						Description: strings.Join([]string{
-							"**🏆 Warcraftlogs rankings and bosses 🏆**",
-							"\u200B",
-							"**• Please see the following sections:**",
-							"\u200B",
-							"↳ Where do you place in the world?",
-							"↳ Which boss is best, which is worst?",
-							"↳ What does your raw parses look like?",
-						}, "\n"),
-						Fields: fieldsRankings,
-						Footer: &discordgo.MessageEmbedFooter{
-							Text: fmt.Sprintf("Source: https://fresh.warcraftlogs.com/guild/id/%d", warcraftLogsGuildID),
-						},
-					}
-					_, err = innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-						Embeds: []*discordgo.MessageEmbed{embedRankings},
-						Flags:  discordgo.MessageFlagsEphemeral,
-					})
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent ranking data to user %s, using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(userID, innerSession)), err.Error())
-					}
-				}
-			case "myreminder":
-				{
-					var err error
-					raiderName := ResolvePlayerID(userID, innerSession)
-					timesToNotify := 5
-					waitBeforeDeleteChannel := time.Minute * 2
-					title := interactionData.Options[0].Value.(string)
-					err = innerSession.InteractionRespond(event.Interaction, slashCommandAllUsers["myreminder"].Responses["examples"].Response)
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to sent initial response to user %s using slash command /myreminder, during the function UseSlashCommand()", raiderName), err.Error())
-						return
-					}
-					_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-						Embeds: &slashCommandAllUsers["myreminder"].Responses["examples"].Response.Data.Embeds,
-					})
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent examples response using slash command /myreminder, during the function UseSlashCommand()", err.Error())
-						return
-					}
-					timeNoneFiltered := strings.ToLower(strings.TrimSpace(interactionData.Options[1].Value.(string)))
-					duration := time.Duration(0)
-					hitError := false
-					patternDigitalClock := regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d:[0-5]\d$`)
-					patternCountdown := regexp.MustCompile(`^(?:\d+h(?:\d+m(?:\d+s)?)?|\d+m(?:\d+s)?|\d+s)$`)
-					if patternDigitalClock.MatchString(timeNoneFiltered) {
-						timePartsSlice := strings.Split(timeNoneFiltered, ":")
-						if len(timePartsSlice) != 3 {
-							WriteErrorLog("The time provided is invalid, value of %s cannot be used in slash command /myreminder, during the function UseSlashCommand()", err.Error())
-							hitError = true
-						}
-						hours, err := strconv.Atoi(timePartsSlice[0])
-						if err != nil {
-							WriteErrorLog("An eror occured while trying to perform string to int convert on value %s using the slash command /myreminder 1, during the function UseSlashCommand()", err.Error())
-							hitError = true
-						}
+func HandleMyMissedRaids(request *slashCommandRequest) {
+	newRaiderProfile, _ := GetRaiderProfile(request.Guild.ServerID, request.UserID)
+	mapOfRaidsMissed := make(map[string]bool)
+	listOfRaids := []string{}
+	for period, raiderAttendance := range newRaiderProfile.AttendanceInfo {
+		for _, missedRaid := range raiderAttendance.RaidsMissed {
+			if !mapOfRaidsMissed[missedRaid] && period != "guildStart" {
+				sliceOfRaidName := strings.Split(missedRaid, "/")
+				listOfRaids = append(listOfRaids, fmt.Sprintf("(%s) https://fresh.warcraftlogs.com/reports/%s", sliceOfRaidName[0], sliceOfRaidName[1]))
+				mapOfRaidsMissed[missedRaid] = true
+			}
+		}
+	}
+	if len(listOfRaids) == 0 {
+		interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("mymissedraids|Not 1 single raid missed the last 3 months, pumper %s", crackedBuiltin))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent the respond to user %s using command /mymissedraids, during the function UseSlashCommand()", err.Error())
+		}
+		return
+	}
+	responseString := ""
+	if len(listOfRaids) > 4 {
+		responseString = fmt.Sprintf(
+			"You have missed over 4 raids the last 3 months %s, that is 25%% of all the raids in the period.\n\n"+
+				"If it's due to vacation, all good — if it's instead due to motivation, please reach out to %s or %s, let's talk.\n\n"+
+				"The list of missed raids:\n\n%s",
+			antiCrackedBuiltin,
+			fmt.Sprintf("<@%s>", GetGuildMaster(request.Guild.ServerID).ID),
+			fmt.Sprintf("<@%s>", GetClassLeader(request.Guild.ServerID, "rogue").ID),
+			strings.Join(listOfRaids, "\n"),
+		)
+		fmt.Println("STRING:", responseString)
+	} else {
+		responseString = fmt.Sprintf("You have missed %d raids over the last 3 months, please see the list of raids:\n\n%s", len(listOfRaids), strings.Join(listOfRaids, "\n"))
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("mymissedraids|%s", responseString))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent the response to user %s using command /mymissedraids, during the function UseSlashCommand()", err.Error())
+	}
+}
+
+// Not registered until /mynewmain has a template in slashCommandAllUsers
+func HandleMyNewMain(request *slashCommandRequest) {
+	newRaiderProfile, _ := GetRaiderProfile(request.Guild.ServerID, request.UserID)
+	userInput := request.Data.Options[0].StringValue()
+	raiderProfileOld, errString := GetRaiderProfile(request.Guild.ServerID, userInput)
+	if errString != "" {
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("mynewmain|The char with name %s is not found in any raids, please contact @Arlissa", userInput))
+		err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent the response to user %s using command /mynewmain, during the function UseSlashCommand()", err.Error())
+			return
+		}
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("mynewmain|Request sent to the officers about linking old main %s with new main %s\n\nYou will recieve a response from the bot, once an officer responds to the request", raiderProfileOld.MainCharName, newRaiderProfile.MainCharName))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent the response to user %s using command /mynewmain, during the function UseSlashCommand()", err.Error())
+		return
+	}
+	_, err = request.Session.ChannelMessageSendComplex(request.Guild.Channels.Officer, &discordgo.MessageSend{
+		Content: fmt.Sprintf("The raider %s has requested to have his/hers raider attendance added from char %s", newRaiderProfile.MainCharName, raiderProfileOld.MainCharName),
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    "Yes",
+						Style:    discordgo.PrimaryButton,
+						CustomID: "button_yes",
+					},
+					discordgo.Button{
+						Label:    "No",
+						Style:    discordgo.DangerButton,
+						CustomID: "button_no",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a complex message in the officer channel from the use of command /mynewmain from raider %s, during the function UseSlashCommand()", newRaiderProfile.MainCharName), err.Error())
+	}
+}
+
+func HandleMyRaiderPerformance(request *slashCommandRequest) {
+	raider := raiderProfile{}
+	interactionResponse := NewInteractionResponseToSpecificCommand(1, "Calculating performance|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent initial response to the user %s, using the slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
+		return
+	}
+	raiderProfiles := ReadWriteRaiderProfiles(request.Guild.ServerID, []raiderProfile{}, false)
+	if len(raiderProfiles) == 0 {
+		interactionResponse = NewInteractionResponseToSpecificCommand(1, "No raider-profiles found|Please ask an officer to run command `resetraidcache`")
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog("An error ocurred while trying to send error response to user %s, using slash command /myraiderperformance, during the function UseSlashCommand", err.Error())
+		}
+		return
+	}
+	currentRaiderProfileMap := map[string]raiderProfile{}
+	for _, raider := range raiderProfiles {
+		if raider.ID == request.UserID {
+			currentRaiderProfileMap[raider.ID] = raider
+		}
+	}
+	if _, ok := currentRaiderProfileMap[request.UserID]; !ok {
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("Raider not found|This is either due to %s not being in 1 main raid yet or a bug. You can verify this by running command `/myattendance`", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		WriteErrorLog(fmt.Sprintf("An error ocurred while trying to send error response to user 2 %s , using slash command /myraiderperformance, during the function UseSlashCommand",request.UserID), "User not found")
+		return
+	}
+	timeRaidDataLastUpdated, _ := ParseGuildTime(timeLayoutLogs, currentRaiderProfileMap[request.UserID].RaidData.TimeOfData)
+	isDataOld := CheckForLaterThanDuration(timeRaidDataLastUpdated, 7)
+	if isDataOld {
+		logsLastThreeMonth, err := ReadRaidDataCache(request.Guild.ServerID, time.Now().AddDate(0, -3, 0), true)
+		logsToKeep := []logAllData{}
+		if err != nil {
+			interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myraiderperformance|%s", err.Error()))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			if err != nil {
+				WriteErrorLog("An error occured while trying to send an error message to the user, the message is %s, using slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
+			}
+			return
+		}
+		for x, log := range logsLastThreeMonth {
+			mapOfPlayers := make(map[string]logPlayer)
+			interactionResponse = NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("myraiderperformance|Retrieving Warcraftlogs data - **%.1f%% so far**", float64(x)/float64(len(logsLastThreeMonth))*100)) //interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("Progess on job|**Completed %.1f%% so far**", float64(x) / float64(len(quriesToRun)) * 100))
+			_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Embeds: &interactionResponse.Data.Embeds,
+			})
+			for _, player := range log.Players {
+				mapOfPlayers[player.Name] = player
+			}
+			raiderName := ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)
+			if _, ok := mapOfPlayers[raiderName]; ok {
+				logsToKeep = append(logsToKeep, log)
+			}
+		}
+		interactionResponse = NewInteractionResponseToSpecificCommand(1, "myraiderperformance|Calculating your raid-data, please wait...")
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog("An error occured while trying to sent initial response to the user %s, using the slash command /myraiderperformance 2, during the function UseSlashCommand()", err.Error())
+			return
+		}
+		time.Sleep(time.Second * 2)
+		messageGIF, err := request.Session.FollowupMessageCreate(request.Event.Interaction, true, NewWebhookParamGIF("the-calculator.gif", yellowColor))
+		fmt.Println("WHO IS THIS GUY", currentRaiderProfileMap[request.UserID], request.UserID, len(logsToKeep))
+		raider = CalculateRaiderPerformance(request.Guild.ServerID, currentRaiderProfileMap[request.UserID], logsToKeep)
+		if err != nil {
+			WriteErrorLog("An error occured while trying to clear last FollowUpMessageCreate using slash command /myraiderperformance, during the function UseSlashCommand()", err.Error())
+		}
+		err = request.Session.FollowupMessageDelete(request.Event.Interaction, messageGIF.ID)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to delete a follow up message from user %s using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+	} else {
+		raider = currentRaiderProfileMap[request.UserID]
+	}
+	if len(raider.RaidData.LastRaid.Specs) == 0 {
+		WriteErrorLog(fmt.Sprintf("The raider %s does not have any calculcated raider performance, please see any earlier error... during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), "Missing data")
+		interactionResponse = NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myraiderperformance|Raidata could not be calculated\nPlease consult %s for help!", GetGuildMaster(request.Guild.ServerID).Name))
+		_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Embeds: &interactionResponse.Data.Embeds,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s using slash command /myraiderperformance", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+		}
+		return
+	}
+	//rowBreak := &discordgo.MessageEmbedField{Name: "\u200B", Value: "\u200B", Inline: false}
+	statLineBestBoss := &discordgo.MessageEmbedField{}
+	statLineWorseBoss := &discordgo.MessageEmbedField{}
+	topLine := &discordgo.MessageEmbedField{}
+	statLineBestBoss.Inline = true
+	statLineWorseBoss.Inline = true
+	if raider.RaidData.LastRaid.HealingDone > raider.RaidData.LastRaid.DamageDone {
+		statLineBestBoss.Name = "HPS"
+		statLineWorseBoss.Name = "HPS"
+		statLineBestBoss.Value = fmt.Sprintf("`%.f`", raider.RaidData.Parses.BestBoss.HPS)
+		statLineWorseBoss.Value = fmt.Sprintf("`%.f`", raider.RaidData.Parses.WorstBoss.HPS)
+	} else {
+		statLineBestBoss.Name = "DPS"
+		statLineWorseBoss.Name = "DPS"
+		statLineBestBoss.Value = fmt.Sprintf("`⚔ %.2f`", raider.RaidData.Parses.BestBoss.DPS)
+		statLineWorseBoss.Value = fmt.Sprintf("`⚔ %.2f`", raider.RaidData.Parses.WorstBoss.DPS)
+	}
+
+	topString := ""
+	switch {
+	case raider.RaidData.Parses.Top1:
+		{
+			topString = "#1 🥇"
+		}
+	case raider.RaidData.Parses.Top2:
+		{
+			topString = "#2 🥈"
+		}
+	case raider.RaidData.Parses.Top3:
+		{
+			topString = "#3 🥉"
+		}
+	case raider.RaidData.Parses.Top5:
+		{
+			topString = "#5"
+		}
+	default:
+		{
+			topString = "Around top 5" //Safegaurd
+		}
+	}
+	topLine = &discordgo.MessageEmbedField{Name: "Rank in class", Value: fmt.Sprintf("`%s`", topString), Inline: true}
+
+	fieldsRaiderPerformance := []*discordgo.MessageEmbedField{
+		{Name: "**▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒**\r", Value: "\u200B", Inline: false},
+		topLine,
+		{Name: "From top 1", Value: fmt.Sprintf("`%.f%%`", raider.RaidData.Parses.RelativeToTop), Inline: true},
+		{Name: "Δ vs last week", Value: fmt.Sprintf("`%.f%%`", raider.RaidData.Parses.Deviation), Inline: true},
+	}
+	scalingOrdered := SortFloat64FromMap(false, mapOfPointScaleProcent)
+	weightStringSlice := []string{}
+	mapOfUniqueWeightNames := make(map[string]bool)
+	for _, scaling := range scalingOrdered {
+		for weightName, weightProcent := range mapOfPointScaleProcent {
+			if scaling == weightProcent && !mapOfUniqueWeightNames[weightName] {
+				weightStringSlice = append(weightStringSlice, fmt.Sprintf("↳ %s → `%.f%%`", strings.Split(weightName, "/")[1], weightProcent))
+				mapOfUniqueWeightNames[weightName] = true
+			}
+		}
+	}
+	specName := ""
+	if len(raider.RaidData.LastRaid.Specs) > 0 {
+		specName = raider.RaidData.LastRaid.Specs[0].Name
+	} else {
+		specName = "Not detected..."
+	}
+	embedRaiderPerformance := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("LINK TO WARCRAFTLOGS PROFILE %s", crackedBuiltin),
+		URL:   raider.RaidData.URL,
+		Description: strings.Join([]string{
+			"**📊 Calculations (last 3 months) 📊**",
+			"\u200B",
+			fmt.Sprintf("**Raid tier:** `%s`", raider.RaidData.Parses.RaidTier),
+			"\u200B",
+			fmt.Sprintf("**Raider name:** `%s`", raider.MainCharName),
+			"\u200B",
+			fmt.Sprintf("**Raider spec:** `%s`", specName),
+			"\u200B",
+			fmt.Sprintf("**Count of raiders in calculation:** `%d`", raider.RaidData.CountOfRaidersInCalculation),
+			"\u200B",
+			"**How this works**",
+			"• Bot collects data from `all raiders` over the last 3 months",
+			"• Only raiders of the `same class` are compared",
+			"• Raiders must be present in `≥ 50%` of raids to be included",
+			"• Eligible raiders are added to the `compare pool`",
+			"• Points are now calculated for each raider in the current `compare pool`",
+			"\u200B",
+			strings.Join(weightStringSlice, "\n"),
+			"↳ Sums to 100% — higher % = higher value in calculation / more points",
+			"↳ Metrics are subject to change, and `feedback` can be given using the bot!",
+			"\u200B",
+			"• The `metrics` defined above is used to calculate the following:",
+			"↳ `Rank in class` → In terms of total points, where do you stand?",
+			"↳ `From top 1 in %` → How far performance wise, are you from top 1?",
+			"↳ `Δ vs last week` → What is your performance difference (delta) compared to the week before?",
+		}, "\n"),
+		Fields: fieldsRaiderPerformance,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Source: https://fresh.warcraftlogs.com/guild/id/%d", request.Guild.WarcraftLogsGuildID),
+		},
+	}
+	_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, false, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embedRaiderPerformance},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a result message to user %s using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+	splitBestBossName := strings.Split(raider.RaidData.Parses.BestBoss.Name, " ")
+	splitWorstBossName := strings.Split(raider.RaidData.Parses.WorstBoss.Name, " ")
+	bestBossNameFinal := ""
+	worstBossNameFinal := ""
+	if len(splitBestBossName) >= 2 {
+		if strings.ToLower(splitBestBossName[1]) == "the" {
+			bestBossNameFinal = splitBestBossName[0]
+		} else {
+			bestBossNameFinal = strings.Join(splitBestBossName[:2], " ")
+		}
+	} else {
+		bestBossNameFinal = raider.RaidData.Parses.BestBoss.Name
+	}
+	if len(splitWorstBossName) >= 2 {
+		if strings.ToLower(splitWorstBossName[1]) == "the" {
+			worstBossNameFinal = splitWorstBossName[0]
+		} else {
+			worstBossNameFinal = strings.Join(splitWorstBossName[:2], " ")
+		}
+	} else {
+		worstBossNameFinal = raider.RaidData.Parses.WorstBoss.Name
+	}
+	if isDataOld {
+		time.Sleep(time.Second * 5)
+	}
+	fieldsRankings := []*discordgo.MessageEmbedField{
+		{Name: "\u200B", Value: "**┌────────────────\u2003Rankings\u2003──────────────┐**", Inline: false},
+		{Name: "World", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankWorld), Inline: true},
+		{Name: "Region", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankRegion), Inline: true},
+		{Name: "Server", Value: fmt.Sprintf("`#%.f`", raider.RaidData.Parses.RankServer), Inline: true},
+		// ── Highlights bosses ──
+		{Name: "\u200B", Value: "**┌────────────────\u2003Bosses\u2003────────────────┐**", Inline: false},
+		{Name: "Best", Value: fmt.Sprintf("`%s`", bestBossNameFinal), Inline: true},
+		statLineBestBoss,
+		{Name: "Kill time", Value: fmt.Sprintf("`⏱ %s`", raider.RaidData.Parses.BestBoss.KillTime), Inline: true},
+		{Name: "Worst", Value: fmt.Sprintf("`%s`", worstBossNameFinal), Inline: true},
+		statLineWorseBoss,
+		{Name: "Kill time", Value: fmt.Sprintf("`⏱ %s`", raider.RaidData.Parses.WorstBoss.KillTime), Inline: true},
+		{Name: "\u200B", Value: "**┌────────────────\u2003Parses\u2003────────────────┐**", Inline: false},
+		{Name: "Best avg", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["bestAverage"]), Inline: true},
+		{Name: "Highest", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["highest"]), Inline: true},
+		{Name: "Lowest", Value: fmt.Sprintf("`%.2f`", raider.RaidData.Parses.Parse["lowest"]), Inline: true},
+	}
+
+	embedRankings := &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("LINK TO WARCRAFTLOGS PROFILE %s", crackedBuiltin),
+		URL:   raider.RaidData.URL,
+		Description: strings.Join([]string{
+			"**🏆 Warcraftlogs rankings and bosses 🏆**",
+			"\u200B",
+			"**• Please see the following sections:**",
+			"\u200B",
+			"↳ Where do you place in the world?",
+			"↳ Which boss is best, which is worst?",
+			"↳ What does your raw parses look like?",
+		}, "\n"),
+		Fields: fieldsRankings,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("Source: https://fresh.warcraftlogs.com/guild/id/%d", request.Guild.WarcraftLogsGuildID),
+		},
+	}
+	_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embedRankings},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent ranking data to user %s, using slash command /myraiderperformance, during the function UseSlashCommand()", ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)), err.Error())
+	}
+}
+
+func HandleHowTo(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, "Information about how to use the bot|Please select one of the buttons below:")
+	interactionResponse.Data.Components = []discordgo.MessageComponent{
+		discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.Button{
+					Label:    "general",
+					Style:    discordgo.PrimaryButton,
+					CustomID: "general",
+				},
+				discordgo.Button{
+					Label:    "your raiding stats",
+					Style:    discordgo.PrimaryButton,
+					CustomID: "stats",
+				},
+			},
+		},
+	}
+	request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+}
 
-						minutes, err := strconv.Atoi(timePartsSlice[1])
-						if err != nil {
-							WriteErrorLog("An eror occured while trying to perform string to int convert on value %s using the slash command /myreminder 2, during the function UseSlashCommand()", err.Error())
-							hitError = true
-						}
-						seconds, err := strconv.Atoi(timePartsSlice[2])
-						if err != nil {
-							WriteErrorLog("An eror occured while trying to perform string to int convert on value %s using the slash command /myreminder 3, during the function UseSlashCommand()", err.Error())
-							hitError = true
-						}
-						if hitError {
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myreminder|An internal bot error occured, please contact <@%s>", SplitOfficerName(officerGMArlissa)["ID"]))
-							err = innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to send an error message to the user %s using the slash command /myreminder, during the function UseSlashCommand()", err.Error())
-								return
-							}
-						}
-						duration = time.Hour*time.Duration(hours) + time.Minute*time.Duration(minutes) + time.Second*time.Duration(seconds)
-					} else if patternCountdown.MatchString(timeNoneFiltered) {
-						duration, err = time.ParseDuration(timeNoneFiltered)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to convert time string %s to time.Duration using slash command /myreminder, during the function UseSlashCommand()", timeNoneFiltered), err.Error())
-							interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myreminder|An internal bot error occured, please contact <@%s>", SplitOfficerName(officerGMArlissa)["ID"]))
-							err = innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-							if err != nil {
-								WriteErrorLog("An error occured while trying to send error message to user %s using the slash command /myreminder, during the function UseSlashCommand()", err.Error())
-								return
-							}
-						}
-					} else {
-						interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myreminder|Your time of `%s` is not valid. Please see the examples for help.", timeNoneFiltered))
-						_, err := innerSession.FollowupMessageCreate(event.Interaction, true, &discordgo.WebhookParams{
-							Flags: discordgo.MessageFlagsEphemeral,
-							Embeds: interactionResponse.Data.Embeds,
-						})
-						if err != nil {
-							WriteErrorLog("An error occured while trying to send initial response to user %s using the slash command /myreminder 2, during the function UseSlashCommand()", err.Error())
-						}
-						return
-					}
-					convertTime := time.Now().Local().Add(duration).Format(timeLayoutLogs)
-					interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("Alert for %s|**The bot will attempt to contact you at: %s**", title, convertTime))
-					_, err = innerSession.FollowupMessageCreate(event.Interaction, false, &discordgo.WebhookParams{
-						Flags:  discordgo.MessageFlagsEphemeral,
-						Embeds: interactionResponse.Data.Embeds,
-					})
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured while trying to notify the user %s for last message using the slash command /myreminder, during the function UseSlashCommand()", raiderName), err.Error())
-						return
-					}
-					WriteInformationLog(fmt.Sprintf("User %s has requested a thread to sleep for %s due to a userdefined alert being set using the slash command /myreminder, during the function UseSlashCommand()", raiderName, duration.String()), "Sleeping thread")
-					time.Sleep(duration)
-					userChannel, err := session.UserChannelCreate(userID)
-					failed := false
-					if err != nil {
-						WriteErrorLog(fmt.Sprintf("An error occured when trying to create a DM channel to the user %s", raiderName), err.Error())
-						failed = true
-					}
-					if !failed {
-						for x := range timesToNotify {
-							x++
-							_, err := innerSession.ChannelMessageSend(userChannel.ID, fmt.Sprintf("**%s** REMINDER!\n\nMESSAGE WILL REPEAT %d/%d more times!", title, x, timesToNotify))
-							if err != nil {
-								failed = true
-								break
-							}
-							time.Sleep(time.Second * 3)
-						}
-					}
+func HandleHi(request *slashCommandRequest) {
+	responseString := ""
+	playerID := request.Event.Member.User.ID
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, "Saying hi|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent error response to user %s with slash command hi 1, during the function UseSlashCommand()", err.Error())
+	}
+	playerName := ResolvePlayerID(request.Guild.ServerID, playerID, request.Session)
+	if CheckForRaiderRank(request.Guild.ServerID, playerID, request.Session) {
+		responseString = fmt.Sprintf("Hi %s you damn pumper %s - How are you?\n\nUse **`/howto`** OR make %s do a joke with **`/joke`**", playerName, crackedBuiltin, crackedBuiltin)
+	} else {
+		responseString = fmt.Sprintf("Hi %s - Good to meet you!", playerName)
+	}
+	_, err = request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+		Content: GetStringPointer(responseString),
+	})
+	if err != nil {
+		WriteErrorLog("An error occured while trying to sent error response to user %s with slash command hi, during the function UseSlashCommand()", err.Error())
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("The user %s has said hi to the bot", playerName), "Success interaction")
+}
 
-					if failed {
-						newChannel, err := innerSession.GuildChannelCreateComplex(serverID, discordgo.GuildChannelCreateData{
-							Name:  fmt.Sprintf("alert-%s-%s", event.ID, duration.String()),
-							Type:  discordgo.ChannelTypeGuildText,
-							Topic: "This channel will close in 2min",
-							PermissionOverwrites: []*discordgo.PermissionOverwrite{
-								{
-									ID:   serverID,
-									Type: discordgo.PermissionOverwriteTypeMember,
-									Deny: permissionViewChannel,
-								},
-								{
-									ID:    userID,
-									Type:  discordgo.PermissionOverwriteTypeMember,
-									Allow: permissionViewChannel,
-								},
-							},
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to create channel: %s for user %s using slash command /myreminder, during the function UseSlashCommand()", newChannel.Name, raiderName), err.Error())
-							return
-						}
-						for x := range timesToNotify {
-							x++
-							_, err = innerSession.ChannelMessageSend(newChannel.ID, fmt.Sprintf("Hi <@%s> THIS IS YOUR REMINDER FOR: %s\n\nMESSAGE WILL REPEAT %d/%d more times!", userID, title, x, timesToNotify))
-							if err != nil {
-								WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a message in new channel created to alert user %s about a user specified alert, using the slash command /myreminder during the function UseSlashCommand()", raiderName), err.Error())
-								return
-							}
-							time.Sleep(time.Second * 3)
-						}
-						WriteInformationLog(fmt.Sprintf("Waiting 2min before deleting the channel that the bot had to create, because it could not create or sent a direct channel to the user %s, using slash command /myreminder during the function UseSlashCommand()", raiderName), "Sleeping thread")
-						time.Sleep(waitBeforeDeleteChannel)
-						_, err = innerSession.ChannelDelete(newChannel.ID)
-						if err != nil {
-							WriteErrorLog("An error occured while trying to delete channel with name %s and ID %s, using slash command /myreminder, during the function UseSlashCommand()", err.Error())
-						}
-					}
-				}
-			case "howto":
-				{
-					interactionResponse := NewInteractionResponseToSpecificCommand(3, "Information about how to use the bot|Please select one of the buttons below:")
-					interactionResponse.Data.Components = []discordgo.MessageComponent{
-						discordgo.ActionsRow{
-							Components: []discordgo.MessageComponent{
-								discordgo.Button{
-									Label:    "general",
-									Style:    discordgo.PrimaryButton,
-									CustomID: "general",
-								},
-								discordgo.Button{
-									Label:    "your raiding stats",
-									Style:    discordgo.PrimaryButton,
-									CustomID: "stats",
-								},
-							},
-						},
-					}
-					innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-				}
-			case "hi":
-				{
-					responseString := ""
-					playerID := event.Member.User.ID
-					interactionResponse := NewInteractionResponseToSpecificCommand(3, "Saying hi|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent error response to user %s with slash command hi 1, during the function UseSlashCommand()", err.Error())
-					}
-					playerName := ResolvePlayerID(playerID, innerSession)
-					if CheckForRaiderRank(playerID, innerSession) {
-						responseString = fmt.Sprintf("Hi %s you damn pumper %s - How are you?\n\nUse **`/howto`** OR make %s do a joke with **`/joke`**", playerName, crackedBuiltin, crackedBuiltin)
-					} else {
-						responseString = fmt.Sprintf("Hi %s - Good to meet you!", playerName)
-					}
-					_, err = innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-						Content: GetStringPointer(responseString),
-					})
-					if err != nil {
-						WriteErrorLog("An error occured while trying to sent error response to user %s with slash command hi, during the function UseSlashCommand()", err.Error())
-						break
-					}
-					WriteInformationLog(fmt.Sprintf("The user %s has said hi to the bot", playerName), "Success interaction")
-				}
-			case "joke":
-				{
-					interactionResponse := NewInteractionResponseToSpecificCommand(3, "Incomming joke|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
-					interactionResponse.Data.Flags &^= discordgo.MessageFlagsEphemeral
-					innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					jokeData := GetHttpResponseData("GET", "", "https://v2.jokeapi.dev/joke/Programming,Miscellaneous,Dark,Pun,Spooky,Christmas?type=twopart", nil, false)
-					if data, ok := jokeData.(map[string]any); !ok {
-						_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-							Content: GetStringPointer(fmt.Sprintf("The service does not respond, please contact <@%s>", SplitOfficerName(officerGMArlissa)["ID"])),
-						})
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s with slash command jokes 2, during the function UseSlashCommand()", userID), err.Error())
-							break
-						}
-					} else {
-						if flags, ok := data["flags"].(map[string]any); ok {
-							if racistFlag, ok := flags["racist"]; ok && racistFlag.(bool) {
-								_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-									Content: GetStringPointer(fmt.Sprintf("Wuuuuupsi..... I cannot tell this joke as it would get me in trouble! %s", antiCrackedBuiltin)),
-								})
-								if err != nil {
-									WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
-								}
-								break
-							} else if darkFlag, ok := data["category"].(string); ok && darkFlag == "Dark" {
-								_, err := innerSession.ChannelMessageSend(event.ChannelID, fmt.Sprintf("This one is going to be spicy... I apologize in advance <@%s> %s", userID, antiCrackedBuiltin))
-								if err != nil {
-									WriteErrorLog("An error occured while trying to sent a warning about a dark joke in the channel %s, using slash command /joke, during the function UseSlashCommand()", err.Error())
-								}
-								//fmt.Sprintf("This one is going to be spicy... I apologize in advance <@%s> %s", userID, antiCrackedBuiltin
-							}
-						} else {
-							WriteErrorLog(fmt.Sprintf("No data found under the joke API payload flags - object: %s, with slash command jokes, during the function UseSlashCommand()"), "Missing data for flags payload using the joke API - Flags MUST be present to check for racist content")
-						}
-						returnStringSlice := []string{}
-						if value, ok := data["setup"].(string); ok && len(value) != 0 {
-							_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Content: GetStringPointer(value),
-							})
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
-								break
-							}
-							returnStringSlice = append(returnStringSlice, value)
-							time.Sleep(5 * time.Second)
-						}
-						if value, ok := data["delivery"].(string); ok && len(value) != 0 {
-							returnStringSlice = append(returnStringSlice, value)
-							_, err := innerSession.InteractionResponseEdit(event.Interaction, &discordgo.WebhookEdit{
-								Content: GetStringPointer(strings.Join(returnStringSlice, "\n\n")),
-							})
-							if err != nil {
-								WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
-								break
-							}
-						}
-					}
-				}
-			case "feedback":
-				{
-					optionsCount := len(interactionData.Options)
-					if optionsCount < 2 {
-						interactionResponse := NewInteractionResponseToSpecificCommand(0, "feedback|There is an issue with the feedback service at this time")
-						err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-						if err != nil {
-							WriteErrorLog(fmt.Sprintf("The length of the options provided for slash command /feedback is %d, which is supposed to be 2 or more", optionsCount), "Error in template / slash-command")
-							break
-						}
-					}
-					deepCopy := *slashCommandAllUsers["feedback"].Responses["description"].Response
-					dataCopy := *deepCopy.Data
-					deepCopy.Data = &dataCopy
-					deepCopy.Data.CustomID = fmt.Sprintf("%s/%s/%s/%v", deepCopy.Data.CustomID, interactionData.Options[0].StringValue(), userID, interactionData.Options[1].BoolValue())
-					err := innerSession.InteractionRespond(event.Interaction, &deepCopy)
-					if err != nil {
-						fmt.Println("ID:", deepCopy.Data.CustomID)
-						WriteErrorLog("An error occured while trying to respond to slash command /feedback and the users welcome message, during the function UshSlashCommand()", err.Error())
-					}
+func HandleJoke(request *slashCommandRequest) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, "Incomming joke|", discordgo.InteractionResponseDeferredChannelMessageWithSource)
+	interactionResponse.Data.Flags &^= discordgo.MessageFlagsEphemeral
+	request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	jokeData := GetHttpResponseData("GET", "", "https://v2.jokeapi.dev/joke/Programming,Miscellaneous,Dark,Pun,Spooky,Christmas?type=twopart", nil, false)
+	if data, ok := jokeData.(map[string]any); !ok {
+		_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+			Content: GetStringPointer(fmt.Sprintf("The service does not respond, please contact <@%s>", GetGuildMaster(request.Guild.ServerID).ID)),
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent error response to user %s with slash command jokes 2, during the function UseSlashCommand()", request.UserID), err.Error())
+			return
+		}
+	} else {
+		if flags, ok := data["flags"].(map[string]any); ok {
+			if racistFlag, ok := flags["racist"]; ok && racistFlag.(bool) {
+				_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+					Content: GetStringPointer(fmt.Sprintf("Wuuuuupsi..... I cannot tell this joke as it would get me in trouble! %s", antiCrackedBuiltin)),
+				})
+				if err != nil {
+					WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
 				}
-			case "aboutme":
-				{
-					fmt.Println("WE DONT REACH HERE?")
-					interactionResponse := NewInteractionResponseToSpecificCommand(1, fmt.Sprintf("aboutme|This feature is not out yet 🚧 please contact <@%s> for more information", SplitOfficerName(officerGMArlissa)["ID"]))
-					err := innerSession.InteractionRespond(event.Interaction, &interactionResponse)
-					if err != nil {
-						WriteErrorLog("An error occured while trying to reply to user %s using slash command /aboutme, during function UseSlashCommand()", err.Error())
-					}
+				return
+			} else if darkFlag, ok := data["category"].(string); ok && darkFlag == "Dark" {
+				_, err := request.Session.ChannelMessageSend(request.Event.ChannelID, fmt.Sprintf("This one is going to be spicy... I apologize in advance <@%s> %s", request.UserID, antiCrackedBuiltin))
+				if err != nil {
+					WriteErrorLog("An error occured while trying to sent a warning about a dark joke in the channel %s, using slash command /joke, during the function UseSlashCommand()", err.Error())
 				}
+				//fmt.Sprintf("This one is going to be spicy... I apologize in advance <@%s> %s", userID, antiCrackedBuiltin
 			}
+		} else {
+			WriteErrorLog(fmt.Sprintf("No data found under the joke API payload flags - object: %s, with slash command jokes, during the function UseSlashCommand()", data), "Missing data for flags payload using the joke API - Flags MUST be present to check for racist content")
 		}
-	})
+		returnStringSlice := []string{}
+		if value, ok := data["setup"].(string); ok && len(value) != 0 {
+			_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Content: GetStringPointer(value),
+			})
+			if err != nil {
+				WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
+				return
+			}
+			returnStringSlice = append(returnStringSlice, value)
+			time.Sleep(5 * time.Second)
+		}
+		if value, ok := data["delivery"].(string); ok && len(value) != 0 {
+			returnStringSlice = append(returnStringSlice, value)
+			_, err := request.Session.InteractionResponseEdit(request.Event.Interaction, &discordgo.WebhookEdit{
+				Content: GetStringPointer(strings.Join(returnStringSlice, "\n\n")),
+			})
+			if err != nil {
+				WriteErrorLog("An error occured while trying to sent error response to user %s with slash command jokes, during the function UseSlashCommand()", err.Error())
+				return
+			}
+		}
+	}
+}
+
+func HandleFeedback(request *slashCommandRequest) {
+	deepCopy := *slashCommandAllUsers["feedback"].Responses["description"].Response
+	dataCopy := *deepCopy.Data
+	deepCopy.Data = &dataCopy
+	deepCopy.Data.CustomID = fmt.Sprintf("%s/%s/%s/%v", deepCopy.Data.CustomID, request.StringOption("subject"), request.UserID, request.BoolOption("anonymous"))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &deepCopy)
+	if err != nil {
+		fmt.Println("ID:", deepCopy.Data.CustomID)
+		WriteErrorLog("An error occured while trying to respond to slash command /feedback and the users welcome message, during the function UshSlashCommand()", err.Error())
+	}
 }
 
 func FindSpecificPlayerChannels(playerChannels []playerChannel, identifier string, overwriteActive ...bool) []playerChannel {
@@ -5329,9 +5397,10 @@ func NewFeedbackTopicString(playerChannels []playerChannel, playerName string) s
 	return strings.Join(sliceOfTopicString, "\n")
 }
 
-func CheckForPost(title string, channelID ...string) (bool, string) {
+func CheckForPost(guildID string, title string, channelID ...string) (bool, string) {
+	guild := GetGuildConfig(guildID)
 	messagesCount := 100
-	id := channelInfo
+	id := guild.Channels.Info
 	if len(channelID) > 0 {
 		id = channelID[0]
 	}
@@ -5445,16 +5514,16 @@ func FormatRaiderID(raiderName string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(raiderName, ">", ""), "<@", "")
 }
 
-func GetRaiderProfiles() []raiderProfile {
-	if raiders := ReadWriteRaiderProfiles(nil, false); raiders != nil {
+func GetRaiderProfiles(guildID string) []raiderProfile {
+	if raiders := ReadWriteRaiderProfiles(guildID, nil, false); raiders != nil {
 		return raiders
 	}
 	return []raiderProfile{}
 }
 
-func GetRaiderProfile(raiderName string) (raiderProfile, string) {
+func GetRaiderProfile(guildID string, raiderName string) (raiderProfile, string) {
 	raiderName = FormatRaiderID(raiderName)
-	cachedRaiders := ReadWriteRaiderProfiles(nil, false)
+	cachedRaiders := ReadWriteRaiderProfiles(guildID, nil, false)
 	match := false
 	raiderProfile := raiderProfile{}
 	for _, raider := range cachedRaiders {
@@ -5549,23 +5618,16 @@ func ReadWriteConfig(currentConfig ...config) config {
 func ReadWriteTrackPosts(post ...trackPost) map[string]trackPost {
 	postTrackMutex.Lock()
 	defer postTrackMutex.Unlock()
-	returnMapOfTrackPosts := make(map[string]trackPost)
-	if bytes := CheckForExistingCache(cacheTrackedPostsCache); len(bytes) > 0 {
-			err := json.Unmarshal(bytes, &returnMapOfTrackPosts)
-			if err != nil {
-				WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal bytes of len %d on path %s, during the function ReadWriteTrackPosts()", len(bytes), cacheTrackedPostsCache), err.Error())
-			}
+	returnMapOfTrackPosts, err := storageCurrent.ReadTrackPosts()
+	if err != nil {
+		WriteErrorLog("An error occured while trying to read the tracked posts, during the function ReadWriteTrackPosts()", err.Error())
+		returnMapOfTrackPosts = make(map[string]trackPost)
 	}
 	if len(post) == 0 {
 		return returnMapOfTrackPosts
 	}
 	returnMapOfTrackPosts[post[0].MessageID] = post[0]
-	marshal, err := json.MarshalIndent(returnMapOfTrackPosts, "", " ")
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to marshal json of returnMapOfTrackPosts, Message ID of new message to save: %s, len of total map %d, cache on path %s cannot be updated, during the function ReadWriteTrackPosts()", post[0].MessageID, len(returnMapOfTrackPosts), cacheTrackedPostsCache), err.Error())
-		return returnMapOfTrackPosts
-	}
-	err = os.WriteFile(cacheTrackedPostsCache, marshal, 0644)
+	err = storageCurrent.WriteTrackPosts(returnMapOfTrackPosts)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to write cache to the file of returnMapOfTrackPosts, Message ID of new message to save: %s, len of total map %d, cache on path %s cannot be updated, during the function ReadWriteTrackPosts()", post[0].MessageID, len(returnMapOfTrackPosts), cacheTrackedPostsCache), err.Error())
 	}
@@ -5575,25 +5637,64 @@ func ReadWriteTrackPosts(post ...trackPost) map[string]trackPost {
 	return returnMapOfTrackPosts
 }
 
-func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProfile {
+// The mutex of the raider profiles of the guild, see the function UpdateRaiderProfiles()
+func GetRaiderProfilesMutex(guildID string) *sync.Mutex {
+	mutex, _ := raiderProfilesMutexes.LoadOrStore(guildID, &sync.Mutex{})
+	return mutex.(*sync.Mutex)
+}
+
+// Reads, changes and writes the raider profiles of the guild while holding its mutex, so a slash command and a scheduled job never overwrite each other.
+// Nothing is written when the update returns an error
+func UpdateRaiderProfiles(guildID string, update func(profiles *raiderProfiles) error) error {
+	mutex := GetRaiderProfilesMutex(guildID)
+	mutex.Lock()
+	defer mutex.Unlock()
+	profiles, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		return err
+	}
+	if err := update(&profiles); err != nil {
+		return err
+	}
+	return storageCurrent.WriteRaiderProfiles(guildID, profiles)
+}
+
+// The records set by officers and raiders are not part of the logs, so they are kept when the raider profiles are built again from the logs
+func CarryOverRaiderRecords(existingRaiders []raiderProfile, newRaiders []raiderProfile) {
+	for x, newRaider := range newRaiders {
+		for _, existingRaider := range existingRaiders {
+			if (newRaider.ID != "" && existingRaider.ID == newRaider.ID) || existingRaider.MainCharName == newRaider.MainCharName {
+				newRaiders[x].AttendanceOverrides = existingRaider.AttendanceOverrides
+				newRaiders[x].Absences = existingRaider.Absences
+				newRaiders[x].SignUpHistory = existingRaider.SignUpHistory
+				break
+			}
+		}
+	}
+}
+
+func ReadWriteRaiderProfiles(guildID string, raiders []raiderProfile, initial bool) []raiderProfile {
+	mutex := GetRaiderProfilesMutex(guildID)
+	mutex.Lock()
+	defer mutex.Unlock()
 	if initial && raiders != nil { //Will overwrite any existing file as part of initial run
 		WriteInformationLog("WARNING - Reinstating raiderProfile cache, during the function ReadWriteRaiderProfiles()", "Resetting Cache")
-		marshal, err := json.MarshalIndent(raiders, "", " ")
+		err := storageCurrent.WriteRaiderProfiles(guildID, raiderProfiles{GuildName: guildName, Raiders: raiders})
 		if err != nil {
-			WriteErrorLog("An error occured while trying to marshal go-struct for []raiderProfile, during the function ReadWriteRaiderProfiles()", err.Error())
-			return nil
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to reinstate the raider profiles of server %s, during the function ReadWriteRaiderProfiles()", guildID), err.Error())
 		}
-		os.WriteFile(raiderProfilesCachePath, marshal, 0644)
 		return nil
 	}
 
-	bytes := CheckForExistingCache(raiderProfilesCachePath)
-	if len(bytes) == 0 {
-		WriteErrorLog(fmt.Sprintf("The current length of cache found for %s is 0, please reinstate all logdata by running /resetraidcache", raiderProfilesCachePath), "Cache is nil, must reinstate")
+	cachedRaiderProfiles, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raider profiles of server %s, during the function ReadWriteRaiderProfiles()", guildID), err.Error())
+		return nil
+	}
+	if len(cachedRaiderProfiles.Raiders) == 0 {
+		WriteErrorLog(fmt.Sprintf("The current length of raider profiles found for server %s is 0, please reinstate all logdata by running /resetraidcache", guildID), "Cache is nil, must reinstate")
 		return nil
 	}
-	cachedRaiderProfiles := raiderProfiles{}
-	json.Unmarshal(bytes, &cachedRaiderProfiles)
 	if raiders == nil {
 		return cachedRaiderProfiles.Raiders
 	}
@@ -5605,7 +5706,7 @@ func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProf
 			if raider.MainCharName == cachedRaider.MainCharName {
 				mapOfMissingProfiles[raider.MainCharName] = true
 				//fmt.Println("DO WE GET HERE?", "RAIDER NAME", raider.MainCharName, "CACHED:", cachedRaider.AttendanceInfo["oneMonth"].RaidCount, cachedRaider.AttendanceInfo["twoMonth"].RaidCount, cachedRaider.AttendanceInfo["threeMonth"].RaidCount, cachedRaider.AttendanceInfo["guildStart"].RaidCount, "NEW",  raider.AttendanceInfo["oneMonth"].RaidCount, raider.AttendanceInfo["twoMonth"].RaidCount, raider.AttendanceInfo["threeMonth"].RaidCount, raider.AttendanceInfo["guildStart"].RaidCount,)
-				if cachedRaider.AttendanceInfo["oneMonth"].RaidCount != raider.AttendanceInfo["oneMonth"].RaidCount || cachedRaider.AttendanceInfo["guildStart"].RaidCount != raider.AttendanceInfo["guildStart"].RaidCount || len(cachedRaider.AttendanceInfo["oneMonth"].RaidsMissed) != len(raider.AttendanceInfo["oneMonth"].RaidsMissed) || len(cachedRaider.AttendanceInfo["twoMonth"].RaidsMissed) != len(raider.AttendanceInfo["twoMonth"].RaidsMissed) || len(cachedRaider.AttendanceInfo["threeMonth"].RaidsMissed) != len(raider.AttendanceInfo["threeMonth"].RaidsMissed) {
+				if raider.AttendanceInfo != nil && !reflect.DeepEqual(cachedRaider.AttendanceInfo, raider.AttendanceInfo) {
 					WriteInformationLog("The raider: %s 's attendance will be updated, during the function ReadWriteRaiderCache()", "Updating RaiderProfile Attendance")
 					cachedRaiderProfiles.Raiders[x].AttendanceInfo = raider.AttendanceInfo
 				}
@@ -5624,12 +5725,12 @@ func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProf
 						cachedRaiderProfiles.Raiders[x].BenchInfo["lastWeek"] = raider.BenchInfo["lastWeek"]
 						mapOfTakenBenches[raider.BenchInfo["lastWeek"][0].DateString] = true
 					}
-					timeBefore, err := time.Parse(timeLayOutShort, cachedRaiderProfiles.Raiders[x].BenchInfo["lastWeek"][0].DateString)
+					timeBefore, err := ParseGuildTime(timeLayOutShort, cachedRaiderProfiles.Raiders[x].BenchInfo["lastWeek"][0].DateString)
 					if err != nil {
 						WriteErrorLog(fmt.Sprintf("An error occured while trying to update raider: %s weekly bench info, during the function ReadWriteRaiderProfiles()", raider.MainCharName), err.Error())
 						continue
 					}
-					timeAfter, err := time.Parse(timeLayOutShort, raider.BenchInfo["lastWeek"][0].DateString)
+					timeAfter, err := ParseGuildTime(timeLayOutShort, raider.BenchInfo["lastWeek"][0].DateString)
 					if err != nil {
 						WriteErrorLog(fmt.Sprintf("An error occured while trying to update raider: %s weekly bench info 2, during the function ReadWriteRaiderProfiles()", raider.MainCharName), err.Error())
 						continue
@@ -5660,7 +5761,7 @@ func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProf
 								continue
 							}
 							for _, bench := range benches {
-								raidTime, err := time.Parse(timeLayOutShort, bench.DateString)
+								raidTime, err := ParseGuildTime(timeLayOutShort, bench.DateString)
 								if err != nil {
 									WriteErrorLog(fmt.Sprintf("An error occured while trying to parse time string %s with layout %s, during the function ReadWriteRaiderProfiles()", bench.DateString, timeLayOutShort), err.Error())
 									continue
@@ -5756,16 +5857,16 @@ func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProf
 				}
 				checkForNilTime := ""
 				if len(cachedRaider.RaidData.TimeOfData) == 0 {
-					checkForNilTime = time.Now().Format(timeLayoutLogs)
+					checkForNilTime = GetGuildTime().Format(timeLayoutLogs)
 				} else {
 					checkForNilTime = cachedRaider.RaidData.TimeOfData
 				}
-				timeCacheData, err := time.Parse(timeLayoutLogs, checkForNilTime)
+				timeCacheData, err := ParseGuildTime(timeLayoutLogs, checkForNilTime)
 				if err != nil {
 					WriteErrorLog(fmt.Sprintf("An error occured while trying to parse time 2 %s using layout %s, during the function ReadWriteRaiderProfiles()", cachedRaider.RaidData.TimeOfData, timeLayoutLogs), err.Error())
 					break
 				}
-				timeNewData, err := time.Parse(timeLayoutLogs, raider.RaidData.TimeOfData)
+				timeNewData, err := ParseGuildTime(timeLayoutLogs, raider.RaidData.TimeOfData)
 				if err != nil {
 					WriteErrorLog(fmt.Sprintf("An error occured while trying to parse time 3 %s using layout %s, during the function ReadWriteRaiderProfiles()", raider.RaidData.TimeOfData, timeLayoutLogs), err.Error())
 					break
@@ -5785,24 +5886,24 @@ func ReadWriteRaiderProfiles(raiders []raiderProfile, initial bool) []raiderProf
 	cachedRaiderProfiles.Raiders = append(cachedRaiderProfiles.Raiders, allMissingProfiles...)
 	cachedRaiderProfiles.GuildName = guildName
 	//cachedRaiderProfiles.CountOfLogs = 0
-	marshal, err := json.MarshalIndent(cachedRaiderProfiles, "", " ")
+	err = storageCurrent.WriteRaiderProfiles(guildID, cachedRaiderProfiles)
 	if err != nil {
 		WriteErrorLog("An error occured while trying to write to the RaiderProfiles cache, during the function ReadWriteRaiderProfiles()", err.Error())
 		return nil
 	}
-	os.WriteFile(raiderProfilesCachePath, marshal, 0644)
 	return cachedRaiderProfiles.Raiders
 }
 
 func NewRaidProfileAttendanceSummary(raider raiderProfile) string {
-	return fmt.Sprintf("```md\n[ Hardened Member ]\n\n/ Raider Name: %s\n/ Joined Guild At: %s\n\n/ Period (Numbers Change Weekly) / Value              \n/--------------------------------/---------------------/\n/ Total Raids Done               / %d                 \n/ Last Month Attendance          / %.0f%%              \n/ Last 2 Months Attendance       / %.0f%%              \n/ Last 3 Months Attendance       / %.0f%%              \n/ Since Guild Started (OG's)     / %.0f%%              \n```",
+	return fmt.Sprintf("```md\n[ Hardened Member ]\n\n/ Raider Name: %s\n/ Joined Guild At: %s\n\n/ Period (Numbers Change Weekly) / Value              \n/--------------------------------/---------------------/\n/ Total Raids Done               / %d                 \n/ Last Month Attendance          / %.0f%%              \n/ Last 2 Months Attendance       / %.0f%%              \n/ Last 3 Months Attendance       / %.0f%%              \n/ Since Guild Started (OG's)     / %.0f%%              \n\n%s```",
 		raider.MainCharName,
 		strings.Join(strings.Split(raider.DateJoinedGuild, " ")[:len(strings.Split(raider.DateJoinedGuild, " "))-1], " "),
 		raider.AttendanceInfo["guildStart"].RaidCount,
 		raider.AttendanceInfo["oneMonth"].RaidProcent,
 		raider.AttendanceInfo["twoMonth"].RaidProcent,
 		raider.AttendanceInfo["threeMonth"].RaidProcent,
-		raider.AttendanceInfo["guildStart"].RaidProcent)
+		raider.AttendanceInfo["guildStart"].RaidProcent,
+		NewAttendanceBreakdownSummary(raider))
 }
 
 func NewRaidProfileBenchSummaries(raiders []raiderProfile, periodKey string) string {
@@ -5893,9 +5994,10 @@ func NewRaidProfileBenchSummaries(raiders []raiderProfile, periodKey string) str
 	return returnStringWriter.String()
 }
 
-func ResolvePlayerID(playerID string, innerSession *discordgo.Session) string {
+func ResolvePlayerID(guildID string, playerID string, innerSession discordSession) string {
+	guild := GetGuildConfig(guildID)
 	returnNickName := "" //Will be username if nickname is ""
-	user, err := innerSession.GuildMember(serverID, playerID)
+	user, err := innerSession.GuildMember(guild.ServerID, playerID)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the guild member: %s", playerID), err.Error())
 		return ""
@@ -5908,11 +6010,12 @@ func ResolvePlayerID(playerID string, innerSession *discordgo.Session) string {
 	return returnNickName
 }
 
-func ResolvePlayerName(playerName string, session *discordgo.Session) string {
+func ResolvePlayerName(guildID string, playerName string, session discordSession) string {
+	guild := GetGuildConfig(guildID)
 	returnID := ""
-	users, err := session.GuildMembers(serverID, "", 1000)
+	users, err := session.GuildMembers(guild.ServerID, "", 1000)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all discord users on server: %s, during the function ResolvePlayerName()", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all discord users on server: %s, during the function ResolvePlayerName()", guild.ServerID), err.Error())
 		return ""
 	}
 	for _, user := range users {
@@ -5923,12 +6026,13 @@ func ResolvePlayerName(playerName string, session *discordgo.Session) string {
 	return returnID
 }
 
-func ResolveRoleIDs(session *discordgo.Session, roleIDs ...string) []string {
-	allRoles, err := session.GuildRoles(serverID)
+func ResolveRoleIDs(guildID string, session discordSession, roleIDs ...string) []string {
+	guild := GetGuildConfig(guildID)
+	allRoles, err := session.GuildRoles(guild.ServerID)
 	returnStringSlice := []string{}
 	fmt.Println("LEN OF ALL ROLES", len(allRoles))
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all Discord roles on server %s, during the function ResolveRoleIDs", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all Discord roles on server %s, during the function ResolveRoleIDs", guild.ServerID), err.Error())
 		return returnStringSlice
 	}
 	mapOfAllRoles := make(map[string]string, len(allRoles))
@@ -5993,8 +6097,9 @@ func DeepCopyInteractionResponse(original *discordgo.InteractionResponse) *disco
 	return &copy
 }
 
-func InitializeDiscordProfiles(raiders []raiderProfile, innerSession *discordgo.Session, onlyRaiders bool) []raiderProfile {
-	discordMembers, err := innerSession.GuildMembers(serverID, "", 1000)
+func InitializeDiscordProfiles(guildID string, raiders []raiderProfile, innerSession discordSession, onlyRaiders bool) []raiderProfile {
+	guild := GetGuildConfig(guildID)
+	discordMembers, err := innerSession.GuildMembers(guild.ServerID, "", 1000)
 	if err != nil {
 		WriteErrorLog("An error occured while trying to retrieve all discord members during the function InitializeDiscordProfiles()", err.Error())
 		return nil
@@ -6006,19 +6111,19 @@ func InitializeDiscordProfiles(raiders []raiderProfile, innerSession *discordgo.
 		for _, discordMember := range discordMembers {
 			if raider.MainCharName == discordMember.Nick {
 				raiders[x].DiscordRoles = discordMember.Roles
-				if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), roleRaider) {
-					raiders[x].GuildRole.RoleID = roleRaider
-					raiders[x].GuildRole.RoleName = ResolveRoleIDs(innerSession, roleRaider)[0]
+				if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), guild.Roles.Raider) {
+					raiders[x].GuildRole.RoleID = guild.Roles.Raider
+					raiders[x].GuildRole.RoleName = ResolveRoleIDs(guildID, innerSession, guild.Roles.Raider)[0]
 
-				} else if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), roleTrial) && raiders[x].GuildRole.RoleID != roleRaider {
-					raiders[x].GuildRole.RoleID = roleTrial
-					raiders[x].GuildRole.RoleName = ResolveRoleIDs(innerSession, roleTrial)[0]
+				} else if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), guild.Roles.Trial) && raiders[x].GuildRole.RoleID != guild.Roles.Raider {
+					raiders[x].GuildRole.RoleID = guild.Roles.Trial
+					raiders[x].GuildRole.RoleName = ResolveRoleIDs(guildID, innerSession, guild.Roles.Trial)[0]
 				} else {
-					raiders[x].GuildRole.RoleID = rolePuggie
-					raiders[x].GuildRole.RoleName = ResolveRoleIDs(innerSession, rolePuggie)[0]
+					raiders[x].GuildRole.RoleID = guild.Roles.Puggie
+					raiders[x].GuildRole.RoleName = ResolveRoleIDs(guildID, innerSession, guild.Roles.Puggie)[0]
 				}
 
-				if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), roleOfficer) {
+				if strings.Contains(strings.Join(raiders[x].DiscordRoles, ","), guild.Roles.Officer) {
 					raiders[x].IsOfficer = true
 				}
 
@@ -6031,7 +6136,7 @@ func InitializeDiscordProfiles(raiders []raiderProfile, innerSession *discordgo.
 
 	if onlyRaiders {
 		for _, raider := range raiders {
-			if raider.GuildRole.RoleID == roleRaider || raider.GuildRole.RoleID == roleTrial || raider.GuildRole.RoleID != rolePuggie {
+			if raider.GuildRole.RoleID == guild.Roles.Raider || raider.GuildRole.RoleID == guild.Roles.Trial || raider.GuildRole.RoleID != guild.Roles.Puggie {
 				allRaiders = append(allRaiders, raider)
 			} else {
 				WriteInformationLog(fmt.Sprintf("Skipping discord member %s due to flag onlyRaiders is true, during the function InitializeDiscordProfiles()", raider.MainCharName), "Skipping Discord user")
@@ -6040,43 +6145,38 @@ func InitializeDiscordProfiles(raiders []raiderProfile, innerSession *discordgo.
 	} else {
 		allRaiders = raiders
 	}
-	ReadWriteRaiderProfiles(allRaiders, false)
+	ReadWriteRaiderProfiles(guildID, allRaiders, false)
 	WriteInformationLog(fmt.Sprintf("Initialized %d number of raiders - Some may be inactive...", len(allRaiders)), "Successfully initialized raiderProfiles")
 	return allRaiders
 }
 
-func AddWeeklyRaiderAttendance(botInfo ...any) string {
-	currentRaiders := raiderProfiles{}
-	currentRaids := []logAllData{}
-	raiderCacheBytes := CheckForExistingCache(raiderProfilesCachePath)
-	if raiderCacheBytes == nil {
+func AddWeeklyRaiderAttendance(guildID string, botInfo ...any) string {
+	currentRaiders, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to read the raider cache, during the function AddWeeklyRaiderAttendance()", err.Error())
+		return fmt.Sprintf("An error occured while trying to read the raider cache - %s", err.Error())
+	}
+	if len(currentRaiders.Raiders) == 0 {
 		WriteErrorLog("The raider-profiles cache is nil, which causes this function to fail, during the function AddWeeklyReaiderAttendance", "Raider-profiles cache is nil")
 		return "The raider-profiles cache is nil"
 	}
-	logsCacheBytes := CheckForExistingCache(raidAllDataPath)
-	if logsCacheBytes == nil {
-		WriteErrorLog("The raid cache is nil, which causes this function to fail, during the function AddWeeklyReaiderAttendance", "Raid cache is nil")
-		return "The raid cache is nil"
-	}
-	err := json.Unmarshal(raiderCacheBytes, &currentRaiders)
+	currentRaids, err := storageCurrent.ReadRaids(guildID, time.Time{})
 	if err != nil {
-		WriteErrorLog("An error occured while trying to unmarshal the raider cache, during the function AddWeeklyRaiderAttendance()", err.Error())
-		return fmt.Sprintf("An error occured while trying to unmarshal the raider cache - %s", err.Error())
+		WriteErrorLog("An error occured while trying to read the raid cache, during the function AddWeeklyRaiderAttendance()", err.Error())
+		return fmt.Sprintf("An error occured while trying to read the raid cache - %s", err.Error())
 	}
-	err = json.Unmarshal(logsCacheBytes, &currentRaids)
-	if err != nil {
-		WriteErrorLog("An error occured while trying to unmarshal the raid cache, during the function AddWeeklyRaiderAttendance()", err.Error())
-		return fmt.Sprintf("An error occured while trying to unmarshal the raid cache - %s", err.Error())
+	if len(currentRaids) == 0 {
+		WriteErrorLog("The raid cache is nil, which causes this function to fail, during the function AddWeeklyReaiderAttendance", "Raid cache is nil")
+		return "The raid cache is nil"
 	}
 
-	newRaiders := CalculateAttendance(currentRaiders.Raiders, currentRaids, botInfo...)
+	newRaiders := CalculateAttendance(guildID, currentRaiders.Raiders, currentRaids, botInfo...)
 
-	ReadWriteRaiderProfiles(newRaiders, false)
+	ReadWriteRaiderProfiles(guildID, newRaiders, false)
 	return fmt.Sprintf("A total of %d raider-profiles has had attendance updated", len(newRaiders)) //len(updatedRaiderProfiles))
 }
 
 func SortOnlyMainRaids(timePeriod time.Duration, raids []logAllData, mergedRaids bool) []logAllData {
-	mainRaidFilter := "ony,aq20,zg"
 	timeConverted := time.Time{}
 	filteredRaids := []logAllData{}
 	raidFilterName := "+"
@@ -6088,11 +6188,11 @@ func SortOnlyMainRaids(timePeriod time.Duration, raids []logAllData, mergedRaids
 
 	for _, raid := range raids {
 		if mergedRaids {
-			if strings.Contains(raid.RaidTitle, raidFilterName) && !strings.Contains(mainRaidFilter, raid.RaidNames[0]) && (timeConverted.After(raid.MetaData.startTime) || timeConverted.Equal(raid.MetaData.startTime)) {
+			if strings.Contains(raid.RaidTitle, raidFilterName) && IsMainRaid(raid.RaidNames) && (timeConverted.After(raid.MetaData.startTime) || timeConverted.Equal(raid.MetaData.startTime)) {
 				filteredRaids = append(filteredRaids, raid)
 			}
 		} else {
-			if !strings.Contains(raid.RaidTitle, raidFilterName) && !strings.Contains(mainRaidFilter, raid.RaidNames[0]) && (timeConverted.After(raid.MetaData.startTime) || timeConverted.Equal(raid.MetaData.startTime)) {
+			if !strings.Contains(raid.RaidTitle, raidFilterName) && IsMainRaid(raid.RaidNames) && (timeConverted.After(raid.MetaData.startTime) || timeConverted.Equal(raid.MetaData.startTime)) {
 				filteredRaids = append(filteredRaids, raid)
 			}
 		}
@@ -6110,12 +6210,13 @@ func IsRaiderTank(raider logPlayer) bool {
 	return false
 }
 
-func CalculateRaiderPerformance(raider raiderProfile, raids []logAllData) raiderProfile { //This function expects raids to ONLY be ones, where the raider parsed is part of, otherwise these calculations wont make sense
+func CalculateRaiderPerformance(guildID string, raider raiderProfile, raids []logAllData) raiderProfile { //This function expects raids to ONLY be ones, where the raider parsed is part of, otherwise these calculations wont make sense
+	guild := GetGuildConfig(guildID)
 	currentRaider := raider
 	currentRaider.RaidData.AverageRaid = make(map[string]logPlayer)
 	playersInScope := make(map[string]bool) //Identify players relevant given the parsed raiderProfile
 	playersOfSameClass := make(map[string]bool)
-	raiderProfiles := GetRaiderProfiles()
+	raiderProfiles := GetRaiderProfiles(guildID)
 	currentRaiderProfiles := []raiderProfile{}
 	returnRaiderProfile := raiderProfile{}
 
@@ -6131,7 +6232,7 @@ func CalculateRaiderPerformance(raider raiderProfile, raids []logAllData) raider
 
 	for raider := range playersOfSameClass {
 		for _, raiderProfile := range raiderProfiles {
-			if raiderProfile.MainCharName == raider && slices.Contains(raiderProfile.DiscordRoles, roleRaider) {
+			if raiderProfile.MainCharName == raider && slices.Contains(raiderProfile.DiscordRoles, guild.Roles.Raider) {
 				if IsRaiderTank(raiderProfile.RaidData.AverageRaid["lastWeek"]) && !isCurrentTank {
 					WriteInformationLog(fmt.Sprintf("The player %s has been skipped due to the raider asking for performance is NOT a tank, but the player in scope, is, during the function CalculateRaiderPerformance()", raider), "Player skipped")
 					continue
@@ -6249,13 +6350,6 @@ func CalculateRaiderPerformance(raider raiderProfile, raids []logAllData) raider
 	sortedStatAverage := SortFloat64FromMap(false, mapOfStatAverageCount)
 	mapOfCurrentRaiderPoints := make(map[string]int)
 	for x, raider := range currentRaiderProfiles {
-		mapOfName := make(map[string]string)
-		mapOfName["name"] = raider.MainCharName
-		mapOfWarcraftLogsQuery := SetWarcraftLogQueryVariables(mapOfWarcaftLogsQueries["playerRankings"], mapOfName)
-		if len(mapOfWarcraftLogsQuery) == 0 {
-			WriteErrorLog(fmt.Sprintf("The Warcraftlogs query with key playerRankins is empty, cannot look for parse for raider %s", raider.MainCharName), "Warcraftlog query is nil")
-			continue
-		}
 		playerLogs := mapOfUniquePlayerLogs[raider.MainCharName]
 
 		fmt.Println("PLAYER LOGS FOR:", raider.MainCharName)
@@ -6266,7 +6360,12 @@ func CalculateRaiderPerformance(raider raiderProfile, raids []logAllData) raider
 			WriteErrorLog(fmt.Sprintf("No playerLog data found for raider %s, this is an issue because this data is required to continue these calculations, during the function CalculateRaiderPerformance()", raider.MainCharName), "Missing data")
 			continue
 		}
-		mapOfData := GetWarcraftLogsData(mapOfWarcraftLogsQuery[0])
+		rankings, err := warcraftLogsCurrent.GetCharacterRankings(raider.MainCharName)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the rankings of raider %s, during the function CalculateRaiderPerformance()", raider.MainCharName), err.Error())
+			continue
+		}
+		mapOfData := map[string]any{"ranking": rankings}
 		mapOfCurrentRaiderPoints[raider.MainCharName] = raider.RaidData.Parses.Points
 		currentRaiderProfiles[x].RaidData = UnwrapWarcraftLogRaiderRanking(mapOfData, raider, playerLogs[0])
 	}
@@ -6397,7 +6496,7 @@ func CalculateRaiderPerformance(raider raiderProfile, raids []logAllData) raider
 			returnRaiderProfile = raiders[x]
 		}
 	}
-	ReadWriteRaiderProfiles(raiders, false)
+	ReadWriteRaiderProfiles(guildID, raiders, false)
 	return returnRaiderProfile
 }
 
@@ -6415,19 +6514,19 @@ func SortFloat64FromMap[K comparable](ascending bool, data map[K]float64) []floa
 	return values
 }
 
-func CalculateAttendance(raiders []raiderProfile, raids []logAllData, botInfo ...any) []raiderProfile {
+func CalculateAttendance(guildID string, raiders []raiderProfile, raids []logAllData, botInfo ...any) []raiderProfile {
 	mapOfAttendancePeriods := map[string]time.Time{
 		"oneMonth":   time.Now().AddDate(0, -1, 0),
 		"twoMonth":   time.Now().AddDate(0, -2, 0),
 		"threeMonth": time.Now().AddDate(0, -3, 0),
 		"guildStart": GuildStartTime,
 	}
-	innerSession := &discordgo.Session{}
+	var innerSession discordSession
 	event := &discordgo.Interaction{}
 	doStatusCount := 0
 	doStatus := false
 	if len(botInfo) > 1 {
-		if session, ok := botInfo[0].(*discordgo.Session); ok {
+		if session, ok := botInfo[0].(discordSession); ok {
 			innerSession = session
 			doStatusCount++
 		}
@@ -6452,10 +6551,9 @@ func CalculateAttendance(raiders []raiderProfile, raids []logAllData, botInfo ..
 				continue
 			}
 			raidCurrentTime := time.UnixMilli(raid.RaidStartUnixTime)
-			raidTitleSlice := strings.Split(raid.RaidTitle, " ")
-			timeParsed, _ := time.Parse(timeLayout, raid.RaidStartTimeString)
+			timeParsed, _ := ParseGuildTime(timeLayout, raid.RaidStartTimeString)
 			raidKey := timeParsed.Format(timeLayout)
-			if raidCurrentTime.After(raidTime) && !mapOfUniqueRaids[raidKey] && !strings.Contains("zg,ony,aq20", strings.ToLower(raidTitleSlice[0])) {
+			if raidCurrentTime.After(raidTime) && !mapOfUniqueRaids[raidKey] && IsMainRaid(raid.RaidNames) {
 				mainRaidsInPeriod = append(mainRaidsInPeriod, raid)
 				mapOfUniqueRaids[raidKey] = true
 			} else if raidCurrentTime.After(raidTime) && !mapOfUniqueRaids[raidKey] {
@@ -6467,71 +6565,16 @@ func CalculateAttendance(raiders []raiderProfile, raids []logAllData, botInfo ..
 		mapOfPeriodsAndRaidsTotal[periodName] = len(mainRaidsInPeriod)
 		mapOfMainRaidPeriods[periodName] = mainRaidsInPeriod
 	}
-	mapOfMainRaidersAttendance := make(map[string]map[string]int)
-	mapOfMissingRaids := make(map[string]map[string][]string)
-	for _, raider := range raiders {
-		mainSwitchSlice := []string{}
-		if raider.MainSwitch != nil {
-			for mainSwitchName := range raider.MainSwitch {
-				mainSwitchSlice = append(mainSwitchSlice, mainSwitchName)
-			}
-		}
-		raiderAttendance := make(map[string]int)
-		for period, logs := range mapOfMainRaidPeriods {
-			for _, log := range logs {
-				mapOfUniquePlayers := make(map[string]bool)
-				playerSeen := false
-				for x, player := range log.Players {
-					if player.Name == raider.MainCharName || strings.Contains(strings.Join(mainSwitchSlice, ","), player.Name) {
-						raiderAttendance[period]++
-						playerSeen = true
-					}
-					if !playerSeen && x == len(log.Players)-1 && !mapOfUniquePlayers[raider.MainCharName] {
-						if _, ok := mapOfMissingRaids[raider.MainCharName]; !ok {
-							mapOfMissingRaids[raider.MainCharName] = make(map[string][]string)
-						}
-						mapOfMissingRaids[raider.MainCharName][period] = append(mapOfMissingRaids[raider.MainCharName][period], fmt.Sprintf("%s/%s", log.RaidTitle, log.MetaData.Code))
-					}
-				}
-			}
-			mapOfMainRaidersAttendance[raider.MainCharName] = raiderAttendance
-		}
-	}
-
-	for raiderName, mapOfCount := range mapOfMainRaidersAttendance {
-		mapOfAttendance := make(map[string]attendance)
-		for period, count := range mapOfCount {
-			amountOfRaids := mapOfPeriodsAndRaidsTotal[period]
-			attendance := attendance{
-				RaidCount:   count,
-				RaidProcent: math.Floor(float64(count) / float64(amountOfRaids) * 100),
-				MainRaid:    true,
-				RaidsMissed: mapOfMissingRaids[raiderName][period],
+	policy := GetGuildConfig(guildID).AttendancePolicy
+	for x, raider := range raiders { //The first raid the raider is seen in, raids before it are not counted
+		var firstRaid *logAllData
+		for y := range raids {
+			if (firstRaid == nil || raids[y].RaidStartUnixTime < firstRaid.RaidStartUnixTime) && IsRaiderInRaid(raider, raids[y]) {
+				firstRaid = &raids[y]
 			}
-			mapOfAttendance[period] = attendance
 		}
-		for x, raiderProfile := range raiders {
-			if raiderProfile.MainCharName == raiderName {
-				raiders[x].AttendanceInfo = mapOfAttendance
-			}
-		}
-	}
-
-	mapOfRaiders := make(map[string]bool)
-	for x, raider := range raiders {
-		match := false
-		for y := len(raids) - 1; y >= 0; y-- {
-			for _, player := range raids[y].Players {
-				if raider.MainCharName == player.Name && !mapOfRaiders[player.Name] {
-					raiders[x].DateJoinedGuild = raids[y].RaidStartTimeString
-					match = true
-					mapOfRaiders[player.Name] = true
-					break
-				}
-			}
-			if match {
-				break
-			}
+		if firstRaid != nil {
+			raiders[x].DateJoinedGuild = firstRaid.RaidStartTimeString
 		}
 	}
 	for x, raider := range raiders {
@@ -6544,42 +6587,56 @@ func CalculateAttendance(raiders []raiderProfile, raids []logAllData, botInfo ..
 				WriteErrorLog("An error occured while trying to call for new progress status during the function CalculateAttendance()", err.Error())
 			}
 		}
-		mapOfRaidsToKeep := make(map[string]bool)
-		raidsToKeep := []string{}
-		joinedGuild, err := time.Parse(timeLayout, raider.DateJoinedGuild)
-		if err != nil {
-			WriteErrorLog("An error occured while trying to convert string %s to time, during the function CalculateAttenance()", err.Error())
-			continue
+		joinedGuild, err := ParseGuildTime(timeLayout, raiders[x].DateJoinedGuild)
+		if err != nil { //Never seen in a raid, so every raid counts
+			joinedGuild = time.Time{}
 		}
-		for period, attendance := range raider.AttendanceInfo {
-			raidsToRemoveCounter := 0
-			if period == "guildStart" {
-				continue
-			}
-			for _, raidMissed := range attendance.RaidsMissed {
-				raidTime, err := ConvertMissedRaidToTime(raidMissed)
-				if err != nil {
-					WriteErrorLog(fmt.Sprintf("An error occured while trying to convert string to %s to time 2, during the function CalculateAttendance()", raider.MainCharName), err.Error())
-					continue
-				}
-				if raidTime.Before(joinedGuild) {
-					WriteInformationLog(fmt.Sprintf("The raider %s was not in guild when this raid was missed => %s, will not be used in any calculations, during the function CalculateAttendance()", raider.MainCharName, raidMissed), "Recalculating raider attendance")
-					raidsToRemoveCounter++
-				} else if raidTime.After(joinedGuild) && !mapOfRaidsToKeep[raidMissed] {
-					raidsToKeep = append(raidsToKeep, raidMissed)
-					mapOfRaidsToKeep[raidMissed] = true
+		mapOfAttendance := make(map[string]attendance)
+		for period, logs := range mapOfMainRaidPeriods {
+			currentAttendance := attendance{MainRaid: true}
+			credit := 0.0
+			countOfRaids := 0
+			countOfLateNotices := 0
+			for _, log := range logs {
+				switch GetRaidAttendanceStatus(guildID, raider, log, joinedGuild, policy) {
+				case attendanceAttended:
+					{
+						currentAttendance.RaidCount++
+						credit++
+						countOfRaids++
+					}
+				case attendanceBenched:
+					{
+						currentAttendance.Benched++
+						credit += policy.BenchCredit
+						countOfRaids++
+					}
+				case attendanceExcused:
+					{
+						currentAttendance.Excused++
+					}
+				case attendanceUnexcused:
+					{
+						currentAttendance.Unexcused++
+						countOfRaids++
+						currentAttendance.RaidsMissed = append(currentAttendance.RaidsMissed, fmt.Sprintf("%s/%s", log.RaidTitle, log.MetaData.Code))
+						if !IsAbsenceReportedInTime(guildID, raider, time.UnixMilli(log.RaidStartUnixTime), policy) { //No absence at all counts as a late notice
+							countOfLateNotices++
+						}
+					}
 				}
 			}
-
-			if raidsToRemoveCounter > 0 {
-				allRaidCount := float64(attendance.RaidCount) * 100.0 / attendance.RaidProcent
-				allRaidCountInt := int(allRaidCount)
-				currentAttendance := attendance
-				currentAttendance.RaidsMissed = raidsToKeep
-				currentAttendance.RaidProcent = math.Floor(float64(currentAttendance.RaidCount+raidsToRemoveCounter) / float64(allRaidCountInt) * 100)
-				raiders[x].AttendanceInfo[period] = currentAttendance
+			if countOfAbsences := currentAttendance.Excused + currentAttendance.Unexcused; countOfAbsences > 0 {
+				currentAttendance.LateNoticeProcent = math.Floor(float64(countOfLateNotices) / float64(countOfAbsences) * 100)
 			}
+			if countOfRaids > 0 {
+				currentAttendance.RaidProcent = math.Floor(credit / float64(countOfRaids) * 100)
+			} else if currentAttendance.Excused > 0 {
+				currentAttendance.RaidProcent = 100
+			}
+			mapOfAttendance[period] = currentAttendance
 		}
+		raiders[x].AttendanceInfo = mapOfAttendance
 	}
 
 	return raiders
@@ -6588,25 +6645,23 @@ func CalculateAttendance(raiders []raiderProfile, raids []logAllData, botInfo ..
 func ConvertMissedRaidToTime(raidTitle string) (time.Time, error) {
 	raidTimeWithTitle := strings.Split(raidTitle, "/")[0]
 	raidTimeTitleSlice := strings.Split(raidTimeWithTitle, " ")
-	raidTime, err := time.Parse(timeLayoutLogs, raidTimeTitleSlice[len(raidTimeTitleSlice)-1]+" 15:00:00")
+	raidTime, err := ParseGuildTime(timeLayoutLogs, raidTimeTitleSlice[len(raidTimeTitleSlice)-1]+" 15:00:00")
 	if err != nil {
 		WriteErrorLog("An error occured while trying to translate raid title %s to time.Time, during the funcion ConvertMissedRaidToTime()", err.Error())
 	}
 	return raidTime, err
 }
 
-func InitializeRaiderProfiles() []raiderProfile {
+func InitializeRaiderProfiles(guildID string) []raiderProfile {
 	mapOfPlayers := make(map[string]bool)
 	newRaiderProfiles := []raiderProfile{}
-	allRaidLogs := []logAllData{}
-	if data := CheckForExistingCache(raidAllDataPath); len(data) == 0 {
-		allRaidLogs = GetAllWarcraftLogsRaidData(false, false, "")
-	} else {
-		err := json.Unmarshal(data, &allRaidLogs)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to marshal json from file path %s, during the function InitializeRaiderProfiles()", raidAllDataPath), err.Error())
-			return nil
-		}
+	allRaidLogs, err := storageCurrent.ReadRaids(guildID, time.Time{})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raids for server %s, during the function InitializeRaiderProfiles()", guildID), err.Error())
+		return nil
+	}
+	if len(allRaidLogs) == 0 {
+		allRaidLogs = GetAllWarcraftLogsRaidData(guildID, false, false, "")
 	}
 
 	for _, log := range allRaidLogs {
@@ -6630,137 +6685,121 @@ func InitializeRaiderProfiles() []raiderProfile {
 		}
 	}
 
-	ReadWriteRaiderProfiles(CalculateAttendance(newRaiderProfiles, allRaidLogs), true)
+	err = UpdateRaiderProfiles(guildID, func(profiles *raiderProfiles) error { //Will overwrite any existing profiles as part of initial run
+		WriteInformationLog("WARNING - Reinstating raiderProfile cache, during the function InitializeRaiderProfiles()", "Resetting Cache")
+		CarryOverRaiderRecords(profiles.Raiders, newRaiderProfiles)
+		profiles.GuildName = guildName
+		profiles.Raiders = CalculateAttendance(guildID, newRaiderProfiles, allRaidLogs)
+		return nil
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to reinstate the raider profiles of server %s, during the function InitializeRaiderProfiles()", guildID), err.Error())
+	}
 	return newRaiderProfiles
 }
 
-func ReadRaidDataCache(firstPossibleTime time.Time, onlyMainRaid bool) ([]logAllData, error) {
-	allLogDataFromCache := []logAllData{}
+func ReadRaidDataCache(guildID string, firstPossibleTime time.Time, onlyMainRaid bool) ([]logAllData, error) {
 	returnLogData := []logAllData{}
-	if raidDataBytes := CheckForExistingCache(raidAllDataPath); raidDataBytes != nil {
-		if err := json.Unmarshal(raidDataBytes, &allLogDataFromCache); err != nil {
-			return []logAllData{}, err
-		}
-	} else {
-		return []logAllData{}, errors.New("No logAllData found in cache")
+	allLogDataFromCache, err := storageCurrent.ReadRaids(guildID, firstPossibleTime)
+	if err != nil {
+		return []logAllData{}, err
 	}
 	for _, logData := range allLogDataFromCache {
-		timeOfRaid, _ := time.Parse(timeLayout, logData.RaidStartTimeString)
-		if !timeOfRaid.Before(firstPossibleTime) {
-			if len(logData.RaidNames) == 1 && (strings.Contains(strings.ToLower(logData.RaidNames[0]), "ony") || strings.Contains(strings.ToLower(logData.RaidNames[0]), "zul")) && onlyMainRaid {
-				continue
-			}
-			returnLogData = append(returnLogData, logData)
+		if !IsMainRaid(logData.RaidNames) && onlyMainRaid {
+			continue
 		}
+		returnLogData = append(returnLogData, logData)
 	}
 
 	if len(returnLogData) == 0 {
-		return []logAllData{}, errors.New(fmt.Sprintf("The length of logs found from path %s is 0", raidAllDataPath))
+		return []logAllData{}, errors.New(fmt.Sprintf("The length of logs found for server %s is 0", guildID))
 	}
 	return returnLogData, nil
 }
 
-func DetermineNextSecondaryRaid(session *discordgo.Session) []commingRaid { //E.g. ONY, ZG and AQ20 - This function must be run using the function RunAtSpecificTime()
-	currentTime, _ := time.ParseInLocation(timeLayout, GetTimeString(), time.Local)
-	cacheRaidDates := ReadWriteRaidCache([]commingRaid{})
+func DetermineNextSecondaryRaid(guildID string, session discordSession) []commingRaid { //The secondary raids of the raid catalog, e.g. ONY, ZG and AQ20 - This function is run by the scheduled job rotatesecondarylogger
+	currentTime, _ := ParseGuildTime(timeLayout, GetTimeString())
+	cachedRaidDates := ReadWriteRaidCache(guildID, []commingRaid{})
 	newCachedRaidDates := []commingRaid{}
-	if len(cacheRaidDates) == 0 {
-		mapOfSecondaryRaidNames := make(map[int]string) //key = name/next reset date
-		mapOfSecondaryRaidNames[0] = fmt.Sprintf("ony/%s", currentTime.AddDate(0, 0, 5).Format(timeLayout))
-		mapOfSecondaryRaidNames[1] = fmt.Sprintf("zg/%s", currentTime.AddDate(0, 0, 3).Format(timeLayout))
-
-		for x := 0; x <= 1; x++ {
-			lastRaid := commingRaid{
-				Name:      strings.Split(mapOfSecondaryRaidNames[x], "/")[0],
-				NextReset: strings.Split(mapOfSecondaryRaidNames[x], "/")[1],
-			}
-			cacheRaidDates = append(cacheRaidDates, lastRaid)
+	cacheRaidDates := []commingRaid{}
+	newSecondaryRaids := false
+	for _, secondaryRaid := range GetSecondaryRaidInstances() { //Raids added to the catalog start rotating and raids removed from it stop
+		index := slices.IndexFunc(cachedRaidDates, func(cachedRaid commingRaid) bool {
+			return RaidNameLongHandConversion(cachedRaid.Name) == secondaryRaid.Name
+		})
+		if index == -1 {
+			cacheRaidDates = append(cacheRaidDates, commingRaid{
+				Name:        secondaryRaid.ShortName,
+				NextReset:   currentTime.AddDate(0, 0, secondaryRaid.ResetDays).Format(timeLayout),
+				ResetLength: secondaryRaid.ResetDays,
+			})
+			newSecondaryRaids = true
+			continue
 		}
-		ReadWriteRaidCache(cacheRaidDates)
+		cachedRaidDates[index].ResetLength = secondaryRaid.ResetDays
+		cacheRaidDates = append(cacheRaidDates, cachedRaidDates[index])
+	}
+	if len(cachedRaidDates) == 0 {
+		ReadWriteRaidCache(guildID, cacheRaidDates)
 		return cacheRaidDates
 	}
-	DetermineNewLogger(cacheRaidDates, session)
+	if newSecondaryRaids {
+		ReadWriteRaidCache(guildID, cacheRaidDates)
+	}
+	DetermineNewLogger(guildID, cacheRaidDates, session)
 	for x, cachedRaid := range cacheRaidDates {
-		currentReset, err := time.ParseInLocation(timeLayout, cachedRaid.NextReset, time.Local)
+		currentReset, err := ParseGuildTime(timeLayout, cachedRaid.NextReset)
 		if err != nil {
 			WriteErrorLog(fmt.Sprintf("Was not possible to convert the cached raid time: %s in format: %s", cachedRaid.NextReset, timeLayout), err.Error())
 			return []commingRaid{}
 		}
 		if currentTime.Before(currentReset) {
-			WriteInformationLog(fmt.Sprintf("The current time of: %s has not passed thursday, no need to check new", GetTimeString()), "Skipping check for raid reset")
+			WriteInformationLog(fmt.Sprintf("The current time of: %s has not passed the reset of %s on %s, no need to check new", GetTimeString(), cachedRaid.Name, cachedRaid.NextReset), "Skipping check for raid reset")
 			return []commingRaid{}
 		}
 		nextReset := currentReset.AddDate(0, 0, cachedRaid.ResetLength)
-		daysUntilThursday := (4 - int(currentTime.Weekday()) + 7) % 7
-		if daysUntilThursday == 0 {
-			daysUntilThursday = 7 // If today is Thursday, move to next week
-		}
-		nextThursday := currentTime.AddDate(0, 0, daysUntilThursday)
-		nextMainReset := time.Date(nextThursday.Year(), nextThursday.Month(), nextThursday.Day(), currentTime.Hour(), currentTime.Minute(), 0, 0, currentTime.Location())
-		if nextReset.Before(nextMainReset) {
+		if nextReset.Before(GetNextMainRaidReset(currentTime)) {
 			newCachedRaidDates = append(newCachedRaidDates, cachedRaid)
 		}
 		cacheRaidDates[x].NextReset = nextReset.Format(timeLayout)
 	}
-	ReadWriteRaidCache(cacheRaidDates)
+	ReadWriteRaidCache(guildID, cacheRaidDates)
 	return newCachedRaidDates
 }
 
-func ReadWriteRaidCache(newcommingRaid []commingRaid) []commingRaid {
-	cachedRaids := []commingRaid{}
-	// Check if the cache file exists
-	if _, err := os.Stat(belowRaidersCachePath); err != nil {
-		WriteErrorLog("raid cache does not exist yet, creating file...", err.Error())
-		return []commingRaid{}
+// Raid catalogs without a resetWeekday reset thursday, the time of day is kept from the current time
+func GetNextMainReset(currentTime time.Time) time.Time {
+	daysUntilThursday := (4 - int(currentTime.Weekday()) + 7) % 7
+	if daysUntilThursday == 0 {
+		daysUntilThursday = 7 // If today is Thursday, move to next week
 	}
+	nextThursday := currentTime.AddDate(0, 0, daysUntilThursday)
+	return time.Date(nextThursday.Year(), nextThursday.Month(), nextThursday.Day(), currentTime.Hour(), currentTime.Minute(), 0, 0, currentTime.Location())
+}
 
+func ReadWriteRaidCache(guildID string, newcommingRaid []commingRaid) []commingRaid {
 	if len(newcommingRaid) == 0 {
-		raidCacheFile, err := os.OpenFile(raidCachePath, os.O_RDONLY, 0644)
+		cachedRaids, err := storageCurrent.ReadSecondaryRaids(guildID)
 		if err != nil {
-			WriteErrorLog("Error opening cache file:", err.Error())
-			return []commingRaid{}
-		}
-		defer raidCacheFile.Close() // Always close the file
-		// Decode JSON directly from file
-		err = json.NewDecoder(raidCacheFile).Decode(&cachedRaids)
-		if err != nil {
-			WriteErrorLog("Error decoding JSON:", err.Error())
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to read the secondary raids of server %s, during the function ReadWriteRaidCache()", guildID), err.Error())
 			return []commingRaid{}
 		}
 		return cachedRaids
 	} else {
-		commingRaidJson, err := json.MarshalIndent(newcommingRaid, "", " ")
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to marshal json: %s", commingRaidJson), err.Error())
+		if err := storageCurrent.WriteSecondaryRaids(guildID, newcommingRaid); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to save the secondary raids of server %s, during the function ReadWriteRaidCache()", guildID), err.Error())
+			return []commingRaid{}
 		}
-		os.WriteFile(raidCachePath, commingRaidJson, 0644)
-		WriteInformationLog("Raid cache has been updated", "Update cache")
+		WriteInformationLog(fmt.Sprintf("Raid cache has been updated for server %s", guildID), "Update cache")
 		return []commingRaid{}
 	}
 
-}
-
-func ReadBelowRaiderCache(userID string) raiderProfile {
-	cachedRaiderProfiles := []raiderProfile{}
-
-	// Check if the cache file exists
-	if _, err := os.Stat(belowRaidersCachePath); err != nil {
-		WriteInformationLog("The function DetrermineNewLogger could not find any present cache, therefor the function will return", "Function return")
-		return raiderProfile{}
-	}
-
-	// Open the cache file
-	raiderCacheFile, err := os.OpenFile(belowRaidersCachePath, os.O_RDONLY, 0644)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("Error opening cache file: %s", belowRaidersCachePath), err.Error())
-		return raiderProfile{}
-	}
-	defer raiderCacheFile.Close() // Always close the file
+}
 
-	// Decode JSON directly from file
-	err = json.NewDecoder(raiderCacheFile).Decode(&cachedRaiderProfiles)
+func ReadBelowRaiderCache(userID string) raiderProfile {
+	cachedRaiderProfiles, err := storageCurrent.ReadMemberProfiles(belowRaidersCachePath)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("Error decoding JSON: Inside function ReadBelowRaiderCache %v", cachedRaiderProfiles), err.Error())
+		WriteErrorLog(fmt.Sprintf("Error reading cache: %s Inside function ReadBelowRaiderCache()", belowRaidersCachePath), err.Error())
 		return raiderProfile{}
 	}
 
@@ -6786,7 +6825,8 @@ func CheckForExistingCache(cachePath string) []byte {
 	return cacheRaidersBytes
 }
 
-func UpdateRaidHelperCache(raidHelperDump any) {
+func UpdateRaidHelperCache(guildID string, raidHelperDump any) {
+	raidHelperPath := GetGuildCachePath(guildID, raidHelperCachePath)
 	raidHelperMap, ok := raidHelperDump.(map[string]any)
 	if !ok {
 		WriteErrorLog(fmt.Sprintf("It was not possible to format the raid to a map function is returning %s", raidHelperDump), "During function UpdateRaidHelperCache()")
@@ -6795,7 +6835,7 @@ func UpdateRaidHelperCache(raidHelperDump any) {
 	var cachedRaidHelper []map[string]any
 
 	// Read existing cache
-	if cacheRaidHelperBytes := CheckForExistingCache(raidHelperCachePath); cacheRaidHelperBytes != nil { // Check if file exists
+	if cacheRaidHelperBytes := CheckForExistingCache(raidHelperPath); cacheRaidHelperBytes != nil { // Check if file exists
 		err := json.Unmarshal(cacheRaidHelperBytes, &cachedRaidHelper)
 		if err != nil {
 			WriteErrorLog("Error deconstructing JSON: Inside function UpdateRaidHelperCahce()", err.Error())
@@ -6811,9 +6851,9 @@ func UpdateRaidHelperCache(raidHelperDump any) {
 	}
 
 	// Open file with truncate mode
-	cacheFile, err := os.OpenFile(raidHelperCachePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	cacheFile, err := os.OpenFile(raidHelperPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("Error opening file: %s inside function UpdateRaidHelperCache()", raidHelperCachePath), err.Error())
+		WriteErrorLog(fmt.Sprintf("Error opening file: %s inside function UpdateRaidHelperCache()", raidHelperPath), err.Error())
 	}
 	defer cacheFile.Close()
 
@@ -6827,24 +6867,21 @@ func UpdateRaiderCache(raider raiderProfile, cachePath string) {
 	raiderCacheMutex.Lock()
 	defer raiderCacheMutex.Unlock()
 
-	cachedRaiderProfiles := []raiderProfile{}
 	uniqueRaiderProfile := raider
 	countOfMatches := 0
-	timeConvertNewCache := time.Now().Local()
+	timeConvertNewCache := GetGuildTime()
 
 	// Read existing cache
-	if cacheRaidersBytes := CheckForExistingCache(cachePath); cacheRaidersBytes != nil { // Check if file exists
-		err := json.Unmarshal(cacheRaidersBytes, &cachedRaiderProfiles)
-		if err != nil {
-			WriteErrorLog("Error deconstructing JSON (RAIDER CHACHE) Inside function UpdateRaiderCache()", err.Error())
-		}
+	cachedRaiderProfiles, err := storageCurrent.ReadMemberProfiles(cachePath)
+	if err != nil {
+		WriteErrorLog("Error reading (RAIDER CHACHE) Inside function UpdateRaiderCache()", err.Error())
 	}
 
 	// Update existing profiles
 	for i, raider := range cachedRaiderProfiles {
 		if raider.ID == uniqueRaiderProfile.ID {
 			countOfMatches++
-			timeConvertOldCache, err := time.ParseInLocation(timeLayout, raider.LastTimeChangedString, time.Local)
+			timeConvertOldCache, err := ParseGuildTime(timeLayout, raider.LastTimeChangedString)
 			if err != nil {
 				WriteErrorLog("Error parsing old cache time: Inside function UpdateRiaderCache()", err.Error())
 			}
@@ -6888,23 +6925,10 @@ func UpdateRaiderCache(raider raiderProfile, cachePath string) {
 		uniqueRaiderProfile.LastTimeChangedString = timeConvertNewCache.Format(timeLayout)
 		cachedRaiderProfiles = append(cachedRaiderProfiles, uniqueRaiderProfile)
 	}
-	// Write updated JSON back to file
-	jsonRaiderProfiles, err := json.MarshalIndent(cachedRaiderProfiles, "", "    ")
-	if err != nil {
-		WriteErrorLog("Error marshaling JSON: Inside function UpdateRaiderCache()", err.Error())
-	}
-
-	// Open file with truncate mode
-
-	cacheFile, err := os.OpenFile(cachePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("Error opening file: %s inside function UpdateRaiderCache()", cachePath), err.Error())
-	}
-	defer cacheFile.Close()
-
-	_, err = cacheFile.Write(jsonRaiderProfiles)
+	// Write updated profiles back to the cache
+	err = storageCurrent.WriteMemberProfiles(cachePath, cachedRaiderProfiles)
 	if err != nil {
-		WriteErrorLog("Error writing to file: Inside function UpdateRaiderCache()", err.Error())
+		WriteErrorLog(fmt.Sprintf("Error writing cache: %s inside function UpdateRaiderCache()", cachePath), err.Error())
 	}
 }
 
@@ -6954,7 +6978,7 @@ func GetEmojies(typeEmoji int, nickNames []string) []emojies {
 	return returnEmojies
 }
 
-func NewDiscordSession(debug bool) *discordgo.Session {
+func NewDiscordSession(debug bool) discordSession {
 	botSession, err := discordgo.New("Bot " + mapOfTokens["botToken"])
 	if debug {
 		botSession.LogLevel = discordgo.LogDebug
@@ -6962,21 +6986,21 @@ func NewDiscordSession(debug bool) *discordgo.Session {
 	botSession.Identify.Intents = discordgo.IntentGuildMessages | discordgo.IntentGuildMessageReactions | discordgo.IntentDirectMessageReactions | discordgo.IntentsGuildMembers | discordgo.IntentsDirectMessages | discordgo.IntentGuilds
 
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to open a new discord server session to server with id: %s", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to open a new discord server session to servers with ids: %s", strings.Join(GetGuildIDs(), ", ")), err.Error())
 	}
 	err = botSession.Open()
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to open a new web socket to the discord server with id: %s", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to open a new web socket to the discord servers with ids: %s", strings.Join(GetGuildIDs(), ", ")), err.Error())
 	}
 
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to connect the bot to the discord server %s, during the function NewDiscordSession()", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to connect the bot to the discord servers %s, during the function NewDiscordSession()", strings.Join(GetGuildIDs(), ", ")), err.Error())
 		log.Fatal("An error occured while trying to establish a new discord connection. Please check the keyvault config")
 	}
-	return botSession
+	return NewDiscordgoSession(botSession)
 }
 
-func BotMultiReaction(emojiesToUse []emojies, event *discordgo.Message, botSession *discordgo.Session) {
+func BotMultiReaction(emojiesToUse []emojies, event *discordgo.Message, botSession discordSession) {
 	mapOfAlreadyReactedEmojie := make(map[string]bool)
 	for _, emojie := range emojiesToUse {
 		if !mapOfAlreadyReactedEmojie[emojie.ID] {
@@ -7005,6 +7029,7 @@ func GetSpecificRaidEvent(allCurrentRaids []trackRaid, messageID string) trackRa
 	return trackRaid{}
 }
 
+// Reads the benches from the embed of the event, only used when the raid-helper API cannot be reached. See the function GetWeeklyBenchFromEvent()
 func GetWeeklyBench(event *discordgo.MessageUpdate) map[string]bench {
 	benchRaidersSlice := []string{}
 	benchRaidersMap := make(map[string]bench)
@@ -7030,7 +7055,7 @@ func GetWeeklyBench(event *discordgo.MessageUpdate) map[string]bench {
 		if err != nil {
 			fmt.Println("THE FOLLOWING ERROR OCVC", err.Error())
 		}
-		raidTime = time.Unix(timeInSeconds, 0).Format(timeLayOutShort)
+		raidTime = time.Unix(timeInSeconds, 0).In(GetGuildLocation(event.GuildID)).Format(timeLayOutShort)
 		fmt.Println("RAID TIME:", raidTime)
 	}
 
@@ -7045,7 +7070,7 @@ func GetWeeklyBench(event *discordgo.MessageUpdate) map[string]bench {
 	for _, benchedRaider := range benchRaidersSlice {
 		currentMap := benchRaidersMap[benchedRaider]
 		currentMap.RaidLeaderName = raidLeaderName
-		currentMap.RaidLeaderDiscordID = ResolvePlayerName(raidLeaderName, BotSessionMain)
+		currentMap.RaidLeaderDiscordID = ResolvePlayerName(event.GuildID, raidLeaderName, BotSessionMain)
 		currentMap.DateString = raidTime
 		benchRaidersMap[benchedRaider] = currentMap
 	}
@@ -7054,14 +7079,10 @@ func GetWeeklyBench(event *discordgo.MessageUpdate) map[string]bench {
 }
 
 func ReadWritePlayerChannels(channelWithPlayer ...playerChannel) []playerChannel {
-	bytes := CheckForExistingCache(cachePlayerFeedbackChannels)
-	cachePlayerChannels := []playerChannel{}
-	if len(bytes) != 0 {
-		err := json.Unmarshal(bytes, &cachePlayerChannels)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal json for cache %s, returning early, during the function ReadWritePlayerChannels()", cachePlayerFeedbackChannels), err.Error())
-			return nil
-		}
+	cachePlayerChannels, err := storageCurrent.ReadFeedbackChannels()
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the cache %s, returning early, during the function ReadWritePlayerChannels()", cachePlayerFeedbackChannels), err.Error())
+		return nil
 	}
 	if len(channelWithPlayer) == 0 {
 		return cachePlayerChannels
@@ -7086,44 +7107,47 @@ func ReadWritePlayerChannels(channelWithPlayer ...playerChannel) []playerChannel
 		cachePlayerChannels = append(cachePlayerChannels, currentPlayerChannel)
 	}
 	
-	marshal, err := json.MarshalIndent(cachePlayerChannels, "", " ")
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to write to cache %s, player with name %s and id %s could not be updated in cache", cachePlayerFeedbackChannels, currentPlayerChannel.RaiderName, currentPlayerChannel.RaiderDiscordID), err.Error())
-		return nil
-	}
-	err = os.WriteFile(cachePlayerFeedbackChannels, marshal, 0644)
+	err = storageCurrent.WriteFeedbackChannels(cachePlayerChannels)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to write to file %s, during the function ReadWritePlayerChannels()", cachePlayerFeedbackChannels), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write to cache %s, player with name %s and id %s could not be updated in cache, during the function ReadWritePlayerChannels()", cachePlayerFeedbackChannels, currentPlayerChannel.RaiderName, currentPlayerChannel.RaiderDiscordID), err.Error())
 	}
 	return cachePlayerChannels
 }
 
-func ReadWriteRaidHelperCache(trackedRaids ...map[string]trackRaid) map[string]trackRaid {
+// Reads, changes and writes the raid-helper cache of the guild while holding its mutex, so the changes of others made since the cache was last read are kept.
+// Nothing is written when the update returns an error
+func UpdateTrackedRaids(guildID string, update func(trackedRaids map[string]trackRaid) error) error {
 	raidHelperCascheMutex.Lock()
 	defer raidHelperCascheMutex.Unlock()
-	raids := make(map[string]trackRaid)
-	currentRaidCacheBytes := CheckForExistingCache(raidHelperCachePath)
-	if len(currentRaidCacheBytes) > 0 {
-		err := json.Unmarshal(currentRaidCacheBytes, &raids)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal the raid-helper cache on path %s, during the function ReadWriteRaidHelperCache()", raidHelperCachePath), err.Error())
-			return raids
-		}
+	trackedRaids, err := storageCurrent.ReadBenches(guildID)
+	if err != nil {
+		return err
+	}
+	if trackedRaids == nil {
+		trackedRaids = make(map[string]trackRaid)
+	}
+	if err := update(trackedRaids); err != nil {
+		return err
 	}
+	return storageCurrent.WriteBenches(guildID, trackedRaids)
+}
 
+func ReadWriteRaidHelperCache(guildID string, trackedRaids ...map[string]trackRaid) map[string]trackRaid {
+	raidHelperCascheMutex.Lock()
+	defer raidHelperCascheMutex.Unlock()
 	if len(trackedRaids) > 0 {
-		marshal, err := json.MarshalIndent(trackedRaids[0], "", " ")
+		err := storageCurrent.WriteBenches(guildID, trackedRaids[0])
 		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to marshalindent cache on path %s, during the function ReadWriteRaidHelperCache()", raidHelperCachePath), err.Error())
-			return make(map[string]trackRaid)
-		}
-		err = os.WriteFile(raidHelperCachePath, marshal, 0644)
-		if err != nil {
-			WriteErrorLog(fmt.Sprintf("An error occured while trying to write cache file %s, during the function ReadWriteRaidHelperCache()", raidHelperCachePath), err.Error())
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to write the raid-helper cache for server %s, during the function ReadWriteRaidHelperCache()", guildID), err.Error())
 			return make(map[string]trackRaid)
 		}
 		return trackedRaids[0]
 	}
+	raids, err := storageCurrent.ReadBenches(guildID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the raid-helper cache for server %s, during the function ReadWriteRaidHelperCache()", guildID), err.Error())
+		return make(map[string]trackRaid)
+	}
 	return raids
 }
 
@@ -7235,6 +7259,7 @@ func AutoTrackPosts() {
 }
 
 func UpdateAnnouncePost(post trackPost, channel <-chan struct{}, interval time.Duration) {
+	guild := GetGuildConfig("") //The announce tracker is driven by config.json, which only covers the primary guild
 ticker := time.NewTicker(interval)
 timeToWaitInLoop := time.Second * 5
 	defer ticker.Stop()
@@ -7271,9 +7296,9 @@ timeToWaitInLoop := time.Second * 5
 			oldID := ""
 			for x := range 5 {
 				x = x + 1
-				channels, err := BotSessionMain.GuildChannels(serverID)
+				channels, err := BotSessionMain.GuildChannels(guild.ServerID)
 				if err != nil {
-					WriteErrorLog(fmt.Sprintf("An error occured while trying to get all discord channels on server %s, this is crusial for the tracking of a post, skipping, during the function UpdateAnnounceBot()", serverID), err.Error())
+					WriteErrorLog(fmt.Sprintf("An error occured while trying to get all discord channels on server %s, this is crusial for the tracking of a post, skipping, during the function UpdateAnnounceBot()", guild.ServerID), err.Error())
 					continue
 				}
 				for _, channel := range channels {
@@ -7326,13 +7351,14 @@ timeToWaitInLoop := time.Second * 5
 	}
 }
 
-func AutoChangeAnnounceChannel(botSession *discordgo.Session) {
+func AutoChangeAnnounceChannel(botSession discordSession) {
+	guild := GetGuildConfig("")
 	createChannel := false
 	var err error
 
-	channels, err := botSession.GuildChannels(serverID)
+	channels, err := botSession.GuildChannels(guild.ServerID)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels on server with ID %s, returning early, during the function AutoChangeAnnounceChannel()", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all guild channels on server with ID %s, returning early, during the function AutoChangeAnnounceChannel()", guild.ServerID), err.Error())
 		return
 	}
 
@@ -7357,27 +7383,27 @@ func AutoChangeAnnounceChannel(botSession *discordgo.Session) {
 	}
 
 	if createChannel {
-		automaticAnnounceDiscordChannel, err = botSession.GuildChannelCreateComplex(serverID, discordgo.GuildChannelCreateData{
+		automaticAnnounceDiscordChannel, err = botSession.GuildChannelCreateComplex(guild.ServerID, discordgo.GuildChannelCreateData{
 			Name:     channelNameAnnouncement,
 			Type:     discordgo.ChannelTypeGuildText,
-			ParentID: categoryAssistance,
+			ParentID: guild.Categories.Assistance,
 			PermissionOverwrites: []*discordgo.PermissionOverwrite{
 				{
-					ID:   roleRaider,
+					ID:   guild.Roles.Raider,
 					Type: discordgo.PermissionOverwriteTypeRole,
 					Allow: discordgo.PermissionViewChannel |
 						discordgo.PermissionSendMessagesInThreads,
 					Deny: discordgo.PermissionSendMessages,
 				},
 				{
-					ID: roleTrial,
+					ID: guild.Roles.Trial,
 					Type: discordgo.PermissionOverwriteTypeRole,
 					Allow: discordgo.PermissionViewChannel |
 						discordgo.PermissionSendMessagesInThreads,
 					Deny: discordgo.PermissionSendMessages,
 				},
 				{
-					ID:   serverID, // @everyone
+					ID:   guild.ServerID, // @everyone
 					Type: discordgo.PermissionOverwriteTypeRole,
 					Deny: discordgo.PermissionViewChannel,
 				},
@@ -7388,9 +7414,9 @@ func AutoChangeAnnounceChannel(botSession *discordgo.Session) {
 	configCurrent.ChannelID = automaticAnnounceDiscordChannel.ID
 	ReadWriteConfig(configCurrent)
 
-	threadList, err := botSession.GuildThreadsActive(serverID)
+	threadList, err := botSession.GuildThreadsActive(guild.ServerID)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all threads active from server %s, returning early, during the function AutoChangeAnnounceChannel()", serverID), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve all threads active from server %s, returning early, during the function AutoChangeAnnounceChannel()", guild.ServerID), err.Error())
 		return
 	}
 	for _, thread := range threadList.Threads {
@@ -7409,7 +7435,7 @@ func AutoChangeAnnounceChannel(botSession *discordgo.Session) {
 	for threadName := range configCurrent.Announce { //Keep threads alive if they are already present
 		for _, thread := range threadList.Threads {
 			if thread.ParentID != configCurrent.ChannelID {
-				//WriteInformationLog(fmt.Sprintf("Thread with name %s has been skipped before it is not contained below category %s", threadName, categoryAssistance), "Thread skipped")
+				//WriteInformationLog(fmt.Sprintf("Thread with name %s has been skipped before it is not contained below category %s", threadName, guild.Categories.Assistance), "Thread skipped")
 				continue
 			}
 			if threadName == thread.Name {
@@ -7533,11 +7559,12 @@ func AutoChangeAnnounceChannel(botSession *discordgo.Session) {
 	}
 }
 
-func AutoAnnounceTracker(interval time.Duration, botSession *discordgo.Session) {
+func AutoAnnounceTracker(interval time.Duration, botSession discordSession) {
+	guild := GetGuildConfig("")
 	staticConfig := configCurrent
-	botSession.AddHandler(func(innerSession *discordgo.Session, message *discordgo.MessageCreate) {
-		if automaticAnnounceDiscordChannel != nil && message.Author.ID != innerSession.State.User.ID && message != nil {
-			ch, err := innerSession.State.Channel(message.ChannelID)
+	botSession.AddHandler(func(innerSession discordSession, message *discordgo.MessageCreate) {
+		if automaticAnnounceDiscordChannel != nil && message.Author.ID != innerSession.BotUserID() && message != nil {
+			ch, err := innerSession.StateChannel(message.ChannelID)
 			if err == nil && ch.IsThread() && ch.ParentID == configCurrent.ChannelID {
 				err = botSession.ChannelMessageDelete(ch.ID, message.ID)
 				if err != nil {
@@ -7566,9 +7593,9 @@ func AutoAnnounceTracker(interval time.Duration, botSession *discordgo.Session)
 		lengthBytesAfter := len(configBytesAfter)
 		if lengthBytesAfter != lengthBytesBefore {
 			WriteInformationLog(fmt.Sprintf("The config found on path %s has been altered, length before in bytes %d, length after %d, checking if channel exists, during the function AutoAnnounceTracker()", configPath, lengthBytesBefore, lengthBytesAfter), "Config changed")
-			channels, err := botSession.GuildChannels(serverID)
+			channels, err := botSession.GuildChannels(guild.ServerID)
 			if err != nil {
-				WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all channels on server with ID %s, this is crucial for the function and will return early, during the function AutoAnnnounceTracker()", serverID), err.Error())
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to retrive all channels on server with ID %s, this is crucial for the function and will return early, during the function AutoAnnnounceTracker()", guild.ServerID), err.Error())
 				continue
 			}
 			for _, channel := range channels {
@@ -7591,86 +7618,66 @@ func AutoAnnounceTracker(interval time.Duration, botSession *discordgo.Session)
 	}
 }
 
-func AutoTrackRaidEvents(session *discordgo.Session) {
-	session.AddHandler(func(session *discordgo.Session, event *discordgo.MessageUpdate) {
+func AutoTrackRaidEvents(session discordSession) {
+	session.AddHandler(func(session discordSession, event *discordgo.MessageUpdate) {
+		guild := GetGuildConfig(event.GuildID)
 		if event.Author.ID == raidHelperId {
 			start := time.Now()
-			raidHelperCascheMutex.Lock()
-			defer raidHelperCascheMutex.Unlock()
-			allTrackedRaids := make(map[string]trackRaid)
-			raidHelperMap := RetriveRaidHelperEvent(time.Now().Add(time.Hour * 24 * -7))
 			messageID := event.Message.ID
-			raidTitle := ""
-
-			if raidMap, ok := raidHelperMap[messageID]; ok {
-				if mapInner, ok := raidMap.(map[string]any); ok {
-					if title, ok := mapInner["title"]; ok {
-						raidTitle = title.(string)
-					}
-				}
-			}
-			mapOfBenchedPlayers := GetWeeklyBench(event)
-			currentTrackRaid := trackRaid{
-				DiscordMessageID:      messageID,
-				ChannelID:             event.Message.ChannelID,
-				RaidDiscordTitle:      raidTitle,
-				PlayersAlreadyTracked: mapOfBenchedPlayers,
-			}
-			allTrackedRaids[currentTrackRaid.DiscordMessageID] = currentTrackRaid
-			if currentCache := CheckForExistingCache(raidHelperCachePath); len(currentCache) > 0 {
-				trackRaidsCache := make(map[string]trackRaid)
-				err := json.Unmarshal(currentCache, &trackRaidsCache)
-				if err != nil {
-					WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal file %s to type map[string]trackRaid, during the function AutoAtrackRaidEvents", raidHelperCachePath), err.Error())
-					return
-				}
-				for discordMessageID, raid := range trackRaidsCache {
-					if _, ok := allTrackedRaids[discordMessageID]; !ok {
-						allTrackedRaids[discordMessageID] = raid
-					}
-				}
-			}
-
-			convertBytes, err := json.MarshalIndent(allTrackedRaids, "", " ")
-			if err != nil {
-				WriteErrorLog("An error occured while trying to marshalindent data of type map[string]trackRaid{}, during the function AutoTrackRaidEvents()", err.Error())
-				return
-			}
-			err = os.WriteFile(raidHelperCachePath, convertBytes, 0644)
+			raidHelperEvent, eventErr := raidHelperCurrent.GetEvent(messageID) //Retrieved before the cache is locked
+			var benchedPlayers map[string]bench
+			if eventErr == nil {
+				benchedPlayers = GetWeeklyBenchFromEvent(raidHelperEvent)
+			} else {
+				WriteWarningLog(fmt.Sprintf("The raid-helper event %s could not be retrieved, the benches are read from the embed of the event instead: %s, during the function AutoTrackRaidEvents()", messageID, eventErr.Error()), "Raid-helper fallback", LogField("guildID", guild.ServerID))
+				benchedPlayers = GetWeeklyBench(event)
+			}
+			err := UpdateTrackedRaids(guild.ServerID, func(allTrackedRaids map[string]trackRaid) error {
+				currentTrackRaid := allTrackedRaids[messageID] //Keeps what the scheduled jobs has stored about the event
+				currentTrackRaid.DiscordMessageID = messageID
+				currentTrackRaid.ChannelID = event.Message.ChannelID
+				if eventErr == nil {
+					currentTrackRaid.RaidDiscordTitle = raidHelperEvent.Title
+					currentTrackRaid.RaidStartUnixTime = raidHelperEvent.StartTime
+				}
+				currentTrackRaid.PlayersAlreadyTracked = benchedPlayers
+				allTrackedRaids[messageID] = currentTrackRaid
+				return nil
+			})
 			if err != nil {
-				WriteErrorLog(fmt.Sprintf("An error occured while trying to create cache file: %s, during the function AutoTrackRaidEvents()", raidHelperCachePath), err.Error())
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to track the raid-helper event %s, during the function AutoTrackRaidEvents()", messageID), err.Error(), LogField("guildID", guild.ServerID))
 			}
 			elapsed := time.Since(start)
 			WriteInformationLog(fmt.Sprintf("It took the event handler discordMessageUpdate %s time to finish", elapsed.String()), "Stopwatch")
 		}
 	})
 
-	session.AddHandler(func(session *discordgo.Session, event *discordgo.MessageDelete) {
+	session.AddHandler(func(session discordSession, event *discordgo.MessageDelete) {
+		guild := GetGuildConfig(event.GuildID)
 		messageID := event.ID //Must not use author or user struct on MessageDelete
 
-		raidHelperCache := ReadWriteRaidHelperCache()
-		if len(raidHelperCache) == 0 {
-			WriteInformationLog(fmt.Sprintf("The following messageID %s was deleted by the raid-helper bot, but the raid-helper cache is len 0 and therefor nothing to remove, during the function AutoTrackRaidEvents()", messageID), "Cache is len 0")
+		errNotTracked := fmt.Errorf("the message %s is not a tracked raid", messageID) //Leaves the cache untouched for every other message deleted
+		err := UpdateTrackedRaids(guild.ServerID, func(trackedRaids map[string]trackRaid) error {
+			if _, ok := trackedRaids[messageID]; !ok {
+				return errNotTracked
+			}
+			delete(trackedRaids, messageID)
+			return nil
+		})
+		if err == errNotTracked {
+			WriteDebugLog(fmt.Sprintf("A message was deleted from the server %s, but this is either not a raid or at least not a raid in cache %s, during the function AutoTrackRaidEvents()", guild.ServerID, event.ChannelID), "Ignoring event")
 			return
-		}
-
-		if _, ok := raidHelperCache[messageID]; !ok {
-			WriteInformationLog(fmt.Sprintf("A message was deleted from the server %s, but this is either not a raid or at least not a raid in cache %s, during the function AutoTrackRaidEvents()", serverID, event.ChannelID), "Ignoring event")
+		} else if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to remove the deleted message %s from the raid-helper cache of server %s, during the function AutoTrackRaidEvents()", messageID, guild.ServerID), err.Error(), LogField("guildID", guild.ServerID))
 			return
 		}
-
-		removeDeletedRaid := make(map[string]trackRaid)
-		for ID, raid := range raidHelperCache {
-			if messageID != ID {
-				removeDeletedRaid[ID] = raid
-			}
-		}
-		ReadWriteRaidHelperCache(removeDeletedRaid)
+		WriteInformationLog(fmt.Sprintf("The raid-helper event %s was deleted and has been removed from the raid-helper cache of server %s, during the function AutoTrackRaidEvents()", messageID, guild.ServerID), "Raid-helper event removed", LogField("guildID", guild.ServerID))
 	})
 } //This handler function triggers when a raid-helper event on the discord server is updated
-func AutoUpdateRaidLogCache(session *discordgo.Session, sliceOfLoggers []string) {
-	session.AddHandler(func(session *discordgo.Session, event *discordgo.MessageCreate) {
-		if event.Author.ID == warcraftLogsNativeID && event.ChannelID == channelLog {
+func AutoUpdateRaidLogCache(session discordSession, sliceOfLoggers []string) {
+	session.AddHandler(func(session discordSession, event *discordgo.MessageCreate) {
+		guild := GetGuildConfig(event.GuildID)
+		if event.Author.ID == warcraftLogsNativeID && event.ChannelID == guild.Channels.Log {
 			raidLogID := ""
 			for _, embed := range event.Embeds {
 				sliceOfURL := strings.Split(embed.URL, "/")
@@ -7683,21 +7690,15 @@ func AutoUpdateRaidLogCache(session *discordgo.Session, sliceOfLoggers []string)
 					WriteErrorLog("No raid-log code found in the URL from the embed message sent by the warcraftlogs app", "During function AutoUpdateRaidLogCache()")
 					break
 				}
-				WriteInformationLog("New raid has been detected, therefor a new log will be retrieved using function GetAllWarcraftLogsRaidData() inside of the function AutoUpdateRaidLogCache()", "Sleeping threat")
-				fmt.Println("DO WE REACH HERE AT UPDATE?")
-				time.Sleep(60 * time.Second)
-				logs := GetAllWarcraftLogsRaidData(false, true, raidLogID)
-				if len(logs) == 0 {
-					WriteErrorLog("An error occured while trying to Retrieve the log posted by a valid discord logger, len of logs is 0 of return on function GetAllWarcraftLogsRaidData()", "During function AutoUpdateRaidLogCache()")
-				} else {
-					WriteInformationLog(fmt.Sprintf("The following log was found %s and the len of the return slice is %d during the function AutoUpdateRaidLogCache()", logs[0].MetaData.Code, len(logs)), "Warcraftlog retrieved")
+				if StartLiveRaidTracking(session, guild.ServerID, raidLogID) {
+					WriteInformationLog("New raid has been detected, therefor the log will be tracked live using function TrackLiveRaid() inside of the function AutoUpdateRaidLogCache()", "Live raid tracking", LogField("guildID", guild.ServerID), LogField("logCode", raidLogID))
 				}
 			}
 		}
 	})
 }
 
-func GetChannelName(channelID string, session *discordgo.Session) string {
+func GetChannelName(channelID string, session discordSession) string {
 	channelName, err := session.Channel(channelID)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to find the channel name for id: %s", channelID), err.Error())
@@ -7708,7 +7709,7 @@ func GetChannelName(channelID string, session *discordgo.Session) string {
 	return ""
 }
 
-func NewPlayerJoin(botSession *discordgo.Session) {
+func NewPlayerJoin(botSession discordSession) {
 	mapOfMessageReactions := make(map[string]bool) //MessageID -> bool
 	mapOfUsedConnections := make(map[string]bool)
 	mapOfNewUsers := make(map[string]raiderProfile)
@@ -7725,43 +7726,44 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 	stage9 := "In-game name set to"
 
 	// Handler for when a new user joins
-	botSession.AddHandler(func(session *discordgo.Session, eventOuter *discordgo.GuildMemberAdd) {
+	botSession.AddHandler(func(session discordSession, eventOuter *discordgo.GuildMemberAdd) {
+		guild := GetGuildConfig(eventOuter.GuildID)
 		raidProfile := raiderProfile{
 			Username: eventOuter.User.Username,
 			ID:       eventOuter.User.ID,
 		}
-		err := botSession.GuildMemberRoleAdd(serverID, raidProfile.ID, roleTemp)
+		err := botSession.GuildMemberRoleAdd(guild.ServerID, raidProfile.ID, guild.Roles.Temp)
 		if err != nil {
 			WriteErrorLog("An error occured while trying to add user %s to roleTemp", err.Error())
 		}
 
-		botSession.ChannelMessageSend(channelBot, fmt.Sprintf("%s <@%s> %s", stage0, raidProfile.ID, crackedBuiltin))
+		botSession.ChannelMessageSend(guild.Channels.Bot, fmt.Sprintf("%s <@%s> %s", stage0, raidProfile.ID, crackedBuiltin))
 		// Create a new channel for the user
 		channelName := fmt.Sprintf("automatic-%s", raidProfile.ID)
 		newChannelTemplate := discordgo.GuildChannelCreateData{
 			Name:     channelName,
 			Type:     discordgo.ChannelTypeGuildText,
 			Topic:    "Set roles / raid status for user",
-			ParentID: categoryBot,
+			ParentID: guild.Categories.Bot,
 			PermissionOverwrites: []*discordgo.PermissionOverwrite{
 				{
-					ID:   serverID,
+					ID:   guild.ServerID,
 					Type: discordgo.PermissionOverwriteTypeRole,
 					Deny: permissionViewChannel | permissionReadMessages,
 				},
 				{
-					ID:    botSession.State.User.ID,
+					ID:    botSession.BotUserID(),
 					Type:  discordgo.PermissionOverwriteTypeMember,
 					Allow: permissionViewChannel | permissionManageMessages | permissionSendMessages | permissionReadMessages,
 				},
 				{
-					ID:    roleTemp, // The role you want to add
+					ID:    guild.Roles.Temp, // The role you want to add
 					Type:  discordgo.PermissionOverwriteTypeRole,
 					Allow: permissionViewChannel | permissionReadMessages, // Grant role view & send permissions
 				},
 			},
 		}
-		newChannelWithUser, err := botSession.GuildChannelCreateComplex(serverID, newChannelTemplate)
+		newChannelWithUser, err := botSession.GuildChannelCreateComplex(guild.ServerID, newChannelTemplate)
 
 		if err != nil {
 			WriteErrorLog(fmt.Sprintf("Error creating channel %s", newChannelTemplate.Name), err.Error())
@@ -7775,7 +7777,7 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 
 		// Notify in bot channel if user setup is required
 		if !mapOfUsedConnections[newChannelWithUser.ID] {
-			botSession.ChannelMessageSend(channelBot, fmt.Sprintf("<@%s> %s VISIT <#%s> TO GET SETUP", raidProfile.ID, crackedBuiltin, newChannelWithUser.ID))
+			botSession.ChannelMessageSend(guild.Channels.Bot, fmt.Sprintf("<@%s> %s VISIT <#%s> TO GET SETUP", raidProfile.ID, crackedBuiltin, newChannelWithUser.ID))
 			mapOfUsedConnections[newChannelWithUser.ID] = true
 			raidProfile.ChannelID = newChannelWithUser.ID
 			raidProfile.LastTimeChangedString = GetTimeString()
@@ -7786,9 +7788,10 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 	//CREATE AN IF FOR LOOKING AT WARCRAFT LOGS, FIND THE PLAYER, LINK THE USER THIS URL AND LET THEM REACT!!
 
 	// Separate Message Handler (Fixes multiple registrations)
-	botSession.AddHandler(func(session *discordgo.Session, event *discordgo.MessageCreate) {
+	botSession.AddHandler(func(session discordSession, event *discordgo.MessageCreate) {
+		guild := GetGuildConfig(event.GuildID)
 		if event.Content != "" && strings.Contains(GetChannelName(event.ChannelID, session), "bot-chat") || strings.Contains(GetChannelName(event.ChannelID, session), "automatic-") {
-			/*channel, err := session.State.Channel(event.ChannelID)
+			/*channel, err := session.StateChannel(event.ChannelID)
 			if err != nil {
 				WriteErrorLog("An error occured while trying to retrieve the channel of which a message was sent from by user %s, during the function UseSlashCommand()", err.Error())
 				return
@@ -7796,7 +7799,7 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 
 			*/
 			fmt.Println("DO WE REACh", event.Content)
-			if event.Author.ID == session.State.User.ID {
+			if event.Author.ID == session.BotUserID() {
 				if strings.Contains(event.Content, " VISIT ") {
 					patternUserID := regexp.MustCompile(`<@(\d+)>`) //strings.Contains(event.Content, " VISIT ") &&
 					patternChannelID := regexp.MustCompile(`<#(\d+)>`)
@@ -7813,14 +7816,14 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 					if newUser != "" {
 						botSession.ChannelMessageSend(newChannel, stage3)
 					} else {
-						WriteInformationLog(fmt.Sprintf("Was not possible to find the userID of whom joined the server: %s", serverID), "During function NewPlayerJoin()")
+						WriteInformationLog(fmt.Sprintf("Was not possible to find the userID of whom joined the server: %s", guild.ServerID), "During function NewPlayerJoin()")
 					}
 					return
 				} else if strings.Contains(event.Content, "Please define the amount of time to set for the alarm") {
 					fmt.Println("YEP NOW WE HERE!")
 				}
 			}
-			if currentClassSlice := strings.Split(event.Content, " "); len(currentClassSlice) > 2 && !strings.Contains(event.Content, stage3) && event.Author.ID == session.State.User.ID && !strings.Contains(event.Content, stage4) && !strings.Contains(event.Content, stage5) && !strings.Contains(event.Content, stage6) && !strings.Contains(event.Content, stage7) && !strings.Contains(event.Content, stage9) && !strings.Contains(event.Content, stage8a) {
+			if currentClassSlice := strings.Split(event.Content, " "); len(currentClassSlice) > 2 && !strings.Contains(event.Content, stage3) && event.Author.ID == session.BotUserID() && !strings.Contains(event.Content, stage4) && !strings.Contains(event.Content, stage5) && !strings.Contains(event.Content, stage6) && !strings.Contains(event.Content, stage7) && !strings.Contains(event.Content, stage9) && !strings.Contains(event.Content, stage8a) {
 				raceEmojie := emojies{}
 				raceEmojieString := ""
 				if len(currentClassSlice) > 2 && !strings.Contains(strings.Join(currentClassSlice, " "), "Please") {
@@ -8054,7 +8057,7 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 					for _, typeEmojie := range roleTypeEmojiesSpecific {
 						emojieTypeSlice = append(emojieTypeSlice, fmt.Sprintf("%s %s", typeEmojie.Wrapper, typeEmojie.ShortName))
 					}
-					botSession.GuildMemberNickname(serverID, raidProfile.ID, raidProfile.Username)
+					botSession.GuildMemberNickname(guild.ServerID, raidProfile.ID, raidProfile.Username)
 					mapOfMessageReactions[event.ID] = true
 					botSession.ChannelMessageSend(event.ChannelID, fmt.Sprintf("%s %s\n\n%s\n\n%s", stage9, event.Content, stage8, strings.Join(emojieTypeSlice, "\n\n")))
 				}
@@ -8077,7 +8080,8 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 		}
 	})
 
-	botSession.AddHandler(func(session *discordgo.Session, event *discordgo.MessageReactionAdd) {
+	botSession.AddHandler(func(session discordSession, event *discordgo.MessageReactionAdd) {
+		guild := GetGuildConfig(event.GuildID)
 		channel, err := session.Channel(event.ChannelID)
 		if err != nil {
 			WriteErrorLog("An error occured while trying to retrieve the current channel of where the event came from, during the function NewPlayerJoin()", err.Error())
@@ -8091,7 +8095,7 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 		raidProfile.ChannelID = event.ChannelID
 		raidProfile.ID = event.Member.User.ID
 		mapOfNewUsers[event.ChannelID] = raidProfile
-		if event.UserID != session.State.User.ID && strings.Contains(GetChannelName(event.ChannelID, session), "automatic") {
+		if event.UserID != session.BotUserID() && strings.Contains(GetChannelName(event.ChannelID, session), "automatic") {
 			if raider, exist := mapOfNewUsers[event.ChannelID]; exist {
 				if emojie := DetermineEmoji(event.Emoji.Name); emojie.TypeInt == 1 {
 					allEmojiesClass := GetEmojies(1, []string{"class"})
@@ -8180,43 +8184,30 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 					raidProfile.LastTimeChangedString = GetTimeString()
 
 					finalMessageSlice := []string{}
-					signUpChannels := fmt.Sprintf("\n%s", strings.Join(FormatChannelSubsetResponseString("Signup at", raidChannelIDs), "\n"))
+					signUpChannels := fmt.Sprintf("\n%s", strings.Join(FormatChannelSubsetResponseString("Signup at", mapOfRaidChannelIDs[guild.ServerID]), "\n"))
 					switch emojie.ShortName {
 					case "puggie":
 						{
-							botSession.GuildMemberRoleAdd(serverID, raidProfile.ID, rolePuggie)
+							botSession.GuildMemberRoleAdd(guild.ServerID, raidProfile.ID, guild.Roles.Puggie)
 							
-							finalMessageSlice = append(finalMessageSlice, fmt.Sprintf("Server role puggie assigned, thank you for joining <Hardened> as a pug\n\nBefore signing up, please add your toon to the Gear-check channel:\n\n <#%s>\n\n%s", channelGearCheck, signUpChannels)) //Must be changed when we run pug raids
+							finalMessageSlice = append(finalMessageSlice, fmt.Sprintf("Server role puggie assigned, thank you for joining <Hardened> as a pug\n\nBefore signing up, please add your toon to the Gear-check channel:\n\n <#%s>\n\n%s", guild.Channels.GearCheck, signUpChannels)) //Must be changed when we run pug raids
 						}
 					case "trial":
 						{
-							botSession.GuildMemberRoleAdd(serverID, raidProfile.ID, roleTrial)
-							botSession.GuildMemberRoleAdd(serverID, raidProfile.ID, roleGuildMember)
+							botSession.GuildMemberRoleAdd(guild.ServerID, raidProfile.ID, guild.Roles.Trial)
+							botSession.GuildMemberRoleAdd(guild.ServerID, raidProfile.ID, guild.Roles.GuildMember)
 							classDiscordRole := ""
-							classLeader := ""
+							classLeader := guildOfficer{}
 							classChannel := ""
-							for roleName, roleValue := range mapOfConstantRoles {
-								if emojieNameSplit := strings.Split(raidProfile.ClassInfo.IngameClass, "_"); len(emojieNameSplit) > 1 {
-									if emojieNameSplit[1] == strings.ToLower(strings.Replace(roleName, "role", "", -1)) {
-										classDiscordRole = roleValue
-										for roleLeaderName, roleLeaderValue := range mapOfConstantOfficers {
-											if strings.Contains(strings.ToLower(roleLeaderName), emojieNameSplit[1]) {
-												classLeader = roleLeaderValue
-												break
-											}
-										}
-
-										for channelRoleName, channelRoleValue := range mapOfConstantClasses {
-											if strings.ToLower(strings.Replace(channelRoleName, "channel", "", -1)) == emojieNameSplit[1] {
-												classChannel = channelRoleValue
-												break
-											}
-										}
+							if emojieNameSplit := strings.Split(raidProfile.ClassInfo.IngameClass, "_"); len(emojieNameSplit) > 1 {
+								classDiscordRole = guild.ClassRoles[emojieNameSplit[1]]
+								classChannel = guild.ClassChannels[emojieNameSplit[1]]
+								for _, officer := range guild.Officers {
+									if officer.ClassLeader == emojieNameSplit[1] {
+										classLeader = officer
+										break
 									}
 								}
-								if classDiscordRole != "" {
-									break
-								}
 							}
 							if classDiscordRole == "" {
 								WriteInformationLog(fmt.Sprintf("Discord class role not found for player with username: %s id: %s nick: %s", event.Member.User.Username, event.Member.User.ID, event.Member.Nick), "Final message to new player")
@@ -8226,17 +8217,17 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 								WriteInformationLog(fmt.Sprintf("Discord class channel not found for player with username: %s id: %s nick: %s", event.Member.User.Username, event.Member.User.ID, event.Member.Nick), "Final message to new player")
 							}
 
-							if classLeader == "" {
+							if classLeader.ID == "" {
 								WriteInformationLog(fmt.Sprintf("Discord classleader not found for player with username: %s id: %s nick: %s", event.Member.User.Username, event.Member.User.ID, event.Member.Nick), "Final message to new player")
-								classLeader = officerGMArlissa
+								classLeader = GetGuildMaster(guild.ServerID)
 							}
-							botSession.GuildMemberRoleAdd(serverID, raidProfile.ID, classDiscordRole)
-							finalMessageSlice = append(finalMessageSlice, fmt.Sprintf("Server role trial assigned, welcome to the <Hardened> Team! %s\n\n**Loot rules are different for trials** \n\nYour new class leader: @ %s\n\nRaid-leader: %s\n\nGet familiar with your class channel: <#%s>\n\nRaid sign-ups channels: %s\n\nGuild general chat channel: <#%s>", crackedBuiltin, strings.Split(classLeader, "/")[1], SplitOfficerName(officerGMArlissa)["Name"], classChannel, signUpChannels, channelGeneral))
+							botSession.GuildMemberRoleAdd(guild.ServerID, raidProfile.ID, classDiscordRole)
+							finalMessageSlice = append(finalMessageSlice, fmt.Sprintf("Server role trial assigned, welcome to the <Hardened> Team! %s\n\n**Loot rules are different for trials** \n\nYour new class leader: @ %s\n\nRaid-leader: %s\n\nGet familiar with your class channel: <#%s>\n\nRaid sign-ups channels: %s\n\nGuild general chat channel: <#%s>", crackedBuiltin, classLeader.Name, GetGuildMaster(guild.ServerID).Name, classChannel, signUpChannels, guild.Channels.General))
 
 						}
 					}
-					botSession.GuildMemberRoleRemove(serverID, raidProfile.ID, roleTemp)
-					botSession.ChannelMessageSend(event.ChannelID, fmt.Sprintf("%s\n\nServer-rules channel: <#%s> %s", strings.Join(finalMessageSlice, "\n\n"), channelServerRules, crackedBuiltin))
+					botSession.GuildMemberRoleRemove(guild.ServerID, raidProfile.ID, guild.Roles.Temp)
+					botSession.ChannelMessageSend(event.ChannelID, fmt.Sprintf("%s\n\nServer-rules channel: <#%s> %s", strings.Join(finalMessageSlice, "\n\n"), guild.Channels.ServerRules, crackedBuiltin))
 					UpdateRaiderCache(raidProfile, belowRaidersCachePath)
 					time.Sleep(1 * time.Minute)
 					botSession.ChannelDelete(event.ChannelID)
@@ -8247,47 +8238,6 @@ func NewPlayerJoin(botSession *discordgo.Session) {
 	})
 }
 
-func SetWarcraftLogQueryVariables(query map[string]any, variableData any) []map[string]any {
-	returnWarcraftQueries := []map[string]any{}
-	if logBases, ok := variableData.([]logsBase); ok {
-		for _, log := range logBases {
-			copyQuery := deepCopyMap(query)
-			copyQuery["variables"].(map[string]any)["code"] = log.Code
-			returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-		}
-	} else if mapOfVariableData, ok := variableData.(map[string]any); ok {
-		if fightIDs, ok := mapOfVariableData["IDs"].([]int64); ok {
-			copyQuery := query
-			copyQuery["variables"].(map[string]any)["fightIDs"] = fightIDs
-			returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-		} else if encounterID, ok := mapOfVariableData["encounterID"].(int64); ok {
-			copyQuery := query
-			copyQuery["variables"].(map[string]any)["encounterID"] = encounterID
-			returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-		} else if actorID, ok := mapOfVariableData["actorID"].(int); ok {
-			copyQuery := query
-			copyQuery["variables"].(map[string]any)["actorID"] = actorID
-			returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-		}
-	} else if number, ok := variableData.(int); ok {
-		copyQuery := query
-		copyQuery["variables"].(map[string]any)["page"] = number
-		returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-	} else if name, ok := variableData.(map[string]string); ok {
-		copyQuery := query
-		copyQuery["variables"].(map[string]string)["name"] = name["name"]
-		returnWarcraftQueries = append(returnWarcraftQueries, copyQuery)
-	}
-	return returnWarcraftQueries
-}
-
-func deepCopyMap(original map[string]any) map[string]any {
-	copyBytes, _ := json.Marshal(original) // Serialize to JSON
-	var copyMap map[string]any
-	json.Unmarshal(copyBytes, &copyMap) // Deserialize back to a new map
-	return copyMap
-}
-
 func GetHttpResponseData(httpMethod string, token string, URL string, customHeaders []string, OAuth2 bool) any { //Must be parsed as key = app id, value = secret
 	var returnJson any
 	var httpRequest *http.Request
@@ -8351,81 +8301,9 @@ func GetHttpResponseData(httpMethod string, token string, URL string, customHead
 
 } //Only supports JSON data
 
-func GetWarcraftLogsData(query map[string]any) map[string]any {
-	var returnMap map[string]any
-	url := "https://fresh.warcraftlogs.com/api/v2/client"
-
-	jsonData, err := json.Marshal(query)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to marshal the warcraft logs query %s during function GetWarcraftLogsData()", query), err.Error())
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		WriteErrorLog("An error occured while trying to create the http request struct and allocate a new buffer during function GetWarcraftLogsData()", err.Error())
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+mapOfTokens["warcraftLogsRefreshToken"])
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		WriteErrorLog("An error occured while trying to serve the request to warcraftLogs during function GetWarcraftLogsData()", err.Error())
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		WriteErrorLog("An error occured while trying to read the iostream of the response body during function GetWarcraftLogsData()", err.Error())
-	}
-	defer resp.Body.Close()
-	if err := json.Unmarshal(body, &returnMap); err != nil {
-		WriteErrorLog("An error occured while trying to unmarshal the bytes of the body into a map during function GetWarcraftLogsData()", err.Error())
-	}
-	contentOfQuery := query["query"].(string)
-
-	if returnMap == nil {
-		WriteInformationLog(fmt.Sprintf("The return map from warcraftlogs API is nill during query %s during function GetWarcraftLogsData()", query), "Warcraft log return")
-		return nil
-	}
-	if errors, ok := returnMap["error"].(string); ok {
-		WriteErrorLog(fmt.Sprintf("The warcraftlogs query %s did present an error from return %s", query, errors), "During function GetWarcraftLogsData()")
-		fmt.Println("RESPONSE:", returnMap["error"], "MORE")
-		fmt.Println("header", req.Header.Get("Authorization"))
-		return nil
-	}
-
-	if strings.Contains(contentOfQuery, "GetFights") {
-		mapOfFightIDs := map[string]any{
-			"IDs": UnwrapBaseLogFightIDs(returnMap),
-		}
-		return mapOfFightIDs
-	} else if strings.Contains(contentOfQuery, "GuildLogs") {
-		mapOfLogs := map[string]any{
-			"logs": UnwrapBaseWarcraftLogRaids(returnMap),
-		}
-		return mapOfLogs
-	} else if strings.Contains(contentOfQuery, "GetLogByCode") {
-		mapOfLogs := map[string]any{
-			"logs": returnMap,
-		}
-		return mapOfLogs
-	} else if strings.Contains(contentOfQuery, "GetEncounterInfo") {
-		mapOfEncounter := map[string]any{
-			"encounter": returnMap,
-		}
-		return mapOfEncounter
-	} else if strings.Contains(contentOfQuery, "GetCharacter") {
-		mapOfRankings := map[string]any{
-			"ranking": returnMap,
-		}
-		return mapOfRankings
-	}
-	return nil
-}
-
 func UnwrapWarcraftLogRaiderRanking(mapToUnwrap map[string]any, raider raiderProfile, logs ...logPlayer) logsRaider {
 	raiderData := logsRaider{}
-	raiderData.TimeOfData = time.Now().Format(timeLayoutLogs)
+	raiderData.TimeOfData = GetGuildTime().Format(timeLayoutLogs)
 	if len(logs) > 0 {
 		raiderData.LastRaid = logs[0]
 	}
@@ -8680,12 +8558,12 @@ func UnwrapBaseWarcraftLogRaids(mapToUnwrap map[string]any) []logsBase {
 
 			case "startTime":
 				if ts, ok := value.(float64); ok {
-					newLog.startTime = time.Unix(int64(ts)/1000, 0).Local()
+					newLog.startTime = time.Unix(int64(ts)/1000, 0).In(GetGuildLocation(""))
 				}
 
 			case "endTime":
 				if ts, ok := value.(float64); ok {
-					newLog.endTime = time.Unix(int64(ts)/1000, 0).Local()
+					newLog.endTime = time.Unix(int64(ts)/1000, 0).In(GetGuildLocation(""))
 				}
 			}
 		}
@@ -8697,29 +8575,10 @@ func UnwrapBaseWarcraftLogRaids(mapToUnwrap map[string]any) []logsBase {
 	return sliceOfLogs
 }
 
-func UnwrapBaseLogFightIDs(mapToUnwrap map[string]any) []int64 {
-	fightIDs := []int64{}
-	if reportData, ok := mapToUnwrap["data"].(map[string]any)["reportData"].(map[string]any)["report"].(map[string]any)["fights"]; ok {
-		if fights, ok := reportData.([]any); ok {
-			for _, fight := range fights {
-				for name, value := range fight.(map[string]any) {
-					if name == "id" {
-						if id, ok := value.(float64); ok {
-							fightIDs = append(fightIDs, int64(id))
-						}
-					}
-				}
-			}
-			return fightIDs
-		}
-	}
-	return nil
-}
-
 func UnwrapLogRaid(mapToUnwrap map[string]any) {}
 
 func GetTimeString() string {
-	logCurrentTime := time.Now().Local()
+	logCurrentTime := GetGuildTime()
 	return logCurrentTime.Format(timeLayout)
 }
 
@@ -8771,87 +8630,28 @@ func DetermineEmoji(nickName string) emojies {
 	return returnEmojie
 }
 
-func SplitScheduleTime(schedule schedule) (time.Time, int, int) {
-	timeNow := time.Now().Local()
-
-	// Parse hour and minute from the schedule
-	parts := strings.Split(schedule.HourMinute, ":")
-	if len(parts) != 2 {
-		WriteErrorLog("Invalid time format. Please use HH:MM (e.g., 19:30) Inside function SplitScheduleTime()", "Wrong time format string for input in function 'RunAtSpecificTime'")
-	}
-	hour, err := strconv.Atoi(parts[0])
-	if err != nil {
-		WriteErrorLog("Invalid hour in schedule: Inside function SplitScheduleTime()", err.Error())
-	}
-	minute, err := strconv.Atoi(parts[1])
-	if err != nil {
-		WriteErrorLog("Invalid minute in schedule: Inside function SplitScheduleTime()", err.Error())
-	}
-	return timeNow, hour, minute
-}
-
-func RunAtSpecificTime(taskToRun func(), schedule schedule, runToday bool) {
-	go func() {
-		if runToday {
-			timeNow, hour, minute := SplitScheduleTime(schedule)
-			targetTime := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), hour, minute, 0, 0, timeNow.Location())
-			timeLeft := time.Until(targetTime)
-			if timeLeft < 0 {
-				WriteInformationLog(fmt.Sprintf("The time parsed in the custom schedule: %s is in the past, and wont run...", schedule.Name), "Skipping custom schedule")
-				return
-			}
-
-			time.Sleep(timeLeft)
-			WriteInformationLog(fmt.Sprintf("Executing function with name: %s", runtime.FuncForPC(reflect.ValueOf(taskToRun).Pointer()).Name()), "Scheduled task")
-			// Execute the task
-			taskToRun()
-		} else {
-			for {
-				timeNow, hour, minute := SplitScheduleTime(schedule)
-				// Find the next occurrence of the scheduled day
-				daysUntilNextOccurrence := (int(schedule.Weekday) - int(timeNow.Weekday()) + 7) % 7
-				if daysUntilNextOccurrence == 0 && (timeNow.Hour() > hour || (timeNow.Hour() == hour && timeNow.Minute() >= minute)) {
-					// If today is the scheduled day but the time has already passed, schedule for next week
-					daysUntilNextOccurrence = 7
-				}
-
-				// Compute target time
-				targetTime := timeNow.AddDate(0, 0, daysUntilNextOccurrence)
-				targetTime = time.Date(targetTime.Year(), targetTime.Month(), targetTime.Day(), hour, minute, 0, 0, targetTime.Location())
-
-				// Wait until the target time
-				timeLeft := time.Until(targetTime)
-				fmt.Printf("Task scheduled for: %s\n", targetTime.Format(time.RFC1123))
-				time.Sleep(timeLeft)
-				WriteInformationLog(fmt.Sprintf("Executing function with name: %s", runtime.FuncForPC(reflect.ValueOf(taskToRun).Pointer()).Name()), "Scheduled task")
-				// Execute the task
-				taskToRun()
-			}
-		}
-	}()
-}
-
 func ConvertUnixTime(unixTime float64) string {
 	// Convert milliseconds to seconds
 	sec := int64(unixTime / 1000)            // Get whole seconds
 	nsec := int64(unixTime*1e6) % int64(1e9) // Get nanoseconds (remaining fraction)
 
 	// Convert to time.Time and return formatted string
-	return time.Unix(sec, nsec).Local().Format(timeLayout)
+	return time.Unix(sec, nsec).In(GetGuildLocation("")).Format(timeLayout)
 }
 
-func NotifyPlayerRaidPlan(session *discordgo.Session) {
-	session.AddHandler(func(innerSession *discordgo.Session, message *discordgo.MessageCreate) {
-		if message.ChannelID == channelSignUp {
+func NotifyPlayerRaidPlan(session discordSession) {
+	session.AddHandler(func(innerSession discordSession, message *discordgo.MessageCreate) {
+		guild := GetGuildConfig(message.GuildID)
+		if message.ChannelID == guild.Channels.SignUp {
 			officerIDs := []string{}
-			for _, value := range mapOfConstantOfficers {
-				officerIDs = append(officerIDs, strings.Split(value, "/")[0])
+			for _, officer := range guild.Officers {
+				officerIDs = append(officerIDs, officer.ID)
 			}
 			if strings.Contains(strings.Join(officerIDs, ","), message.Author.ID) && strings.Contains(message.Content, googleSheetBaseURL) {
-				usersToNotify := RetrieveUsersInRole([]string{roleTrial, roleRaider}, innerSession)
+				usersToNotify := RetrieveUsersInRole(guild.ServerID, []string{guild.Roles.Trial, guild.Roles.Raider}, innerSession)
 				for _, id := range usersToNotify {
 					WriteInformationLog(fmt.Sprintf("Message sent directly to user %s", id), "Notifying player")
-					InformPlayerDirectly(fmt.Sprintf("Hi the raid plan has been published for next main raid %s\n\nPlease make sure you look at your specific assignments\n\nIts also VERY important that you FOLLOW them\n\nLink => %s", crackedBuiltin, fmt.Sprintf("https://discordapp.com/channels/%s/%s/%s", serverID, channelSignUp, message.ID)), id, innerSession)
+					InformPlayerDirectly(fmt.Sprintf("Hi the raid plan has been published for next main raid %s\n\nPlease make sure you look at your specific assignments\n\nIts also VERY important that you FOLLOW them\n\nLink => %s", crackedBuiltin, fmt.Sprintf("https://discordapp.com/channels/%s/%s/%s", guild.ServerID, guild.Channels.SignUp, message.ID)), id, innerSession)
 				}
 			}
 		}
@@ -8876,9 +8676,9 @@ func PrepareTemplateWithEmojie(template messageTemplate) messageTemplate {
 	return template
 }
 
-func NotifyPlayerRaidQuestion(template messageTemplate, session *discordgo.Session) {
+func NotifyPlayerRaidQuestion(template messageTemplate, session discordSession) {
 	//currentRaiders := []string{}
-	//currentRaiders = RetrieveUsersInRole([]string{roleTrial, roleRaider}, session)
+	//currentRaiders = RetrieveUsersInRole(guild.ServerID, []string{guild.Roles.Trial, guild.Roles.Raider}, session)
 	mapOfUsesDone := make(map[string]int)
 	test := []string{"340477324258705419"}
 	for _, raider := range test {
@@ -8902,9 +8702,9 @@ func NotifyPlayerRaidQuestion(template messageTemplate, session *discordgo.Sessi
 		}
 	}
 
-	session.AddHandler(func(innerSession *discordgo.Session, message *discordgo.MessageCreate) {
+	session.AddHandler(func(innerSession discordSession, message *discordgo.MessageCreate) {
 		contentLower := strings.ToLower(message.Content)
-		if contentLower == "yes" || contentLower == "no" && message.GuildID == "" && session.State.User.ID != message.Author.ID && mapOfUsesDone[message.ChannelID] == 0 && message.Author.ID != crackedAppID {
+		if contentLower == "yes" || contentLower == "no" && message.GuildID == "" && session.BotUserID() != message.Author.ID && mapOfUsesDone[message.ChannelID] == 0 && message.Author.ID != crackedAppID {
 			for _, emojie := range template.EmojiesCaptured {
 				if emojie.ShortName == "yes" || emojie.ShortName == "no" {
 					raiderProfile := raiderProfile{}
@@ -8932,7 +8732,7 @@ func NotifyPlayerRaidQuestion(template messageTemplate, session *discordgo.Sessi
 					}
 				}
 			}
-		} else if message.GuildID == "" && session.State.User.ID != message.Author.ID && mapOfUsesDone[message.ChannelID] == 0 && crackedAppID != message.Author.ID {
+		} else if message.GuildID == "" && session.BotUserID() != message.Author.ID && mapOfUsesDone[message.ChannelID] == 0 && crackedAppID != message.Author.ID {
 			WriteInformationLog(fmt.Sprintf("The message: %s recieved does not match 'yes' or 'no' user: %s and channel: %s", message.Content, message.Author.ID, message.ChannelID), "User gave incorrect feedback during direct message")
 			_, err := innerSession.ChannelMessageSend(message.ChannelID, fmt.Sprintf("The input given: '**%s**' is invalid. The bot only accepts '**yes**' OR '**no**", message.Content))
 			if err != nil {
@@ -8942,27 +8742,29 @@ func NotifyPlayerRaidQuestion(template messageTemplate, session *discordgo.Sessi
 	})
 }
 
-func CheckForOfficerRank(playerID string, botSession *discordgo.Session) bool {
-	playerRoles, err := botSession.GuildMember(serverID, playerID)
+func CheckForOfficerRank(guildID string, playerID string, botSession discordSession) bool {
+	guild := GetGuildConfig(guildID)
+	playerRoles, err := botSession.GuildMember(guild.ServerID, playerID)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve discord stats about player: %s inside function CheckForOfficerRank()", playerID), err.Error())
 	}
-	if strings.Contains(strings.Join(playerRoles.Roles, ","), roleOfficer) || strings.Contains(strings.Join(playerRoles.Roles, ","), roleRaidLeader) {
-		WriteInformationLog(fmt.Sprintf("The player with ID: %s has been verifified as having the officer role on discord: %s", playerID, roleOfficer), "Checking for Officer rank")
+	if strings.Contains(strings.Join(playerRoles.Roles, ","), guild.Roles.Officer) || strings.Contains(strings.Join(playerRoles.Roles, ","), guild.Roles.RaidLeader) {
+		WriteInformationLog(fmt.Sprintf("The player with ID: %s has been verifified as having the officer role on discord: %s", playerID, guild.Roles.Officer), "Checking for Officer rank")
 		return true
 	} else {
-		WriteInformationLog(fmt.Sprintf("Player with ID: %s does not have role: %s", playerID, roleOfficer), "Check for Officer rank")
+		WriteInformationLog(fmt.Sprintf("Player with ID: %s does not have role: %s", playerID, guild.Roles.Officer), "Check for Officer rank")
 	}
 	return false
 }
 
-func CheckForRaiderRank(playerID string, botSession *discordgo.Session) bool {
-	player, err := botSession.GuildMember(serverID, playerID)
+func CheckForRaiderRank(guildID string, playerID string, botSession discordSession) bool {
+	guild := GetGuildConfig(guildID)
+	player, err := botSession.GuildMember(guild.ServerID, playerID)
 	if err != nil {
 		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve discord stats about player: %s inside function CheckForRaiderRank()", playerID), err.Error())
 	}
 	for _, playerRole := range player.Roles {
-		if strings.Contains(strings.Join([]string{roleRaider, roleTrial}, ","), playerRole) {
+		if strings.Contains(strings.Join([]string{guild.Roles.Raider, guild.Roles.Trial}, ","), playerRole) {
 			return true
 		}
 	}
@@ -8970,7 +8772,8 @@ func CheckForRaiderRank(playerID string, botSession *discordgo.Session) bool {
 	return false
 }
 
-func DetermineNewLogger(commingRaids []commingRaid, session *discordgo.Session) {
+func DetermineNewLogger(guildID string, commingRaids []commingRaid, session discordSession) {
+	guild := GetGuildConfig(guildID)
 	mapOfSeenLoggers := make(map[string]bool)
 	userID := ""
 	if len(commingRaids) == 0 {
@@ -8978,7 +8781,7 @@ func DetermineNewLogger(commingRaids []commingRaid, session *discordgo.Session)
 		return
 	}
 
-	for _, value := range mapOfLoggers {
+	for _, value := range guild.Loggers {
 		userID = ""
 		if userIDSlice := strings.Split(value, "/"); len(userIDSlice) > 0 {
 			userID = userIDSlice[0]
@@ -8999,7 +8802,7 @@ func DetermineNewLogger(commingRaids []commingRaid, session *discordgo.Session)
 
 	for name, isSeen := range mapOfSeenLoggers {
 		if !isSeen {
-			for loggerName, loggerValue := range mapOfLoggers {
+			for loggerName, loggerValue := range guild.Loggers {
 				userID = ""
 				if strings.Contains(loggerValue, "/") {
 					userID = strings.Split(loggerValue, "/")[0]
@@ -9010,7 +8813,7 @@ func DetermineNewLogger(commingRaids []commingRaid, session *discordgo.Session)
 					InformPlayerDirectly(fmt.Sprintf("Hi %s\n\nThis is simply to inform you that our discord bot has defined you as an offical <Hardened> Warcraft-logger! %s\n\nYou will not recieve this message again.. Thank you! %s", loggerName, crackedBuiltin, crackedBuiltin), userID, session)
 					commingRaids[0].Logger = append(commingRaids[0].Logger, raidLogger{UserID: userID})
 					WriteInformationLog(fmt.Sprintf("Direct-message sent to user: %s", userID), "Notify about being an official logger")
-					ReadWriteRaidCache(commingRaids)
+					ReadWriteRaidCache(guildID, commingRaids)
 					break
 				}
 			}
@@ -9032,7 +8835,7 @@ func SeperateAnyTagsInMessage(messageValue string) []string {
 	return returnMessageTagsSlice
 }
 
-func InformPlayerDirectly(message string, userID string, session *discordgo.Session) {
+func InformPlayerDirectly(message string, userID string, session discordSession) {
 	channel, err := session.UserChannelCreate(userID)
 	if err != nil {
 		WriteErrorLog("An error occured while trying to create a direct channel with the user inside function InformPlayerDirectly()", err.Error())
@@ -9044,7 +8847,7 @@ func InformPlayerDirectly(message string, userID string, session *discordgo.Sess
 	}
 }
 
-func DeleteMessagesInBulk(channelID string, botSession *discordgo.Session) {
+func DeleteMessagesInBulk(channelID string, botSession discordSession) {
 	messagesToDelete, err := botSession.ChannelMessages(channelID, 25, "", "", "")
 	messageIDsToDelete := []string{}
 	for _, messageString := range messagesToDelete {
@@ -9067,11 +8870,12 @@ func RetrieveChannelID(channelWithTag string) string {
 	return ""
 }
 
-func RetrieveUsersInRole(roleIDs []string, session *discordgo.Session) []string {
-	guildMembers, err := session.GuildMembers(serverID, "", 500)
+func RetrieveUsersInRole(guildID string, roleIDs []string, session discordSession) []string {
+	guild := GetGuildConfig(guildID)
+	guildMembers, err := session.GuildMembers(guild.ServerID, "", 500)
 	guildMembersInCorrectRoles := []string{}
 	if err != nil {
-		fmt.Println("An error occured while trying to retrieve all guild members of the server:", serverID, err)
+		fmt.Println("An error occured while trying to retrieve all guild members of the server:", guild.ServerID, err)
 	}
 	for _, guildMember := range guildMembers {
 		for _, role := range guildMember.Roles {
@@ -9085,57 +8889,23 @@ func RetrieveUsersInRole(roleIDs []string, session *discordgo.Session) []string
 	return guildMembersInCorrectRoles
 }
 
-func RetriveRaidHelperEvent(periodBack time.Time) map[string]any {
-	newRaidURL := fmt.Sprintf("https://raid-helper.dev/api/v3/servers/%s/events", serverID)
-	raidEvents := make(map[string]any)
-	var response any
-	getSignupData, _ := http.NewRequest("GET", newRaidURL, nil)
-	getSignupData.Header = http.Header{
-		"Authorization": {mapOfTokens["raidHelperToken"]},
-		"Content-Type":  {"application/json"},
-	}
-	client := &http.Client{}
-	data, err := client.Do(getSignupData)
+// The events posted after periodBack, keyed by the ID of their discord message. Nil when raid-helper cannot be reached
+func RetriveRaidHelperEvent(guildID string, periodBack time.Time) map[string]raidHelperPostedEvent {
+	guild := GetGuildConfig(guildID)
+	postedEvents, err := raidHelperCurrent.GetServerEvents(guild.ServerID)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a HTTP GET to URI: %s, during the function RetrieveRaidHelperEvent()", newRaidURL), err.Error())
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the raid-helper events of server %s, during the function RetrieveRaidHelperEvent()", guild.ServerID), err.Error())
 		return nil
 	}
-	// Read and print the response body
-	body, err := io.ReadAll(data.Body)
-	if err != nil {
-		log.Fatalf("Failed to read response body: %v", err)
-	}
-	err = json.Unmarshal(body, &response)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal json body data from URI: %s, during the function RetrieveRaidHelperEvent()", newRaidURL), err.Error())
-		return nil
-	}
-
-	if value, ok := response.(map[string]any); !ok {
-		WriteErrorLog("The format of the raidHelperResponse is invalid, it must be map of any, during the function RetrieveRaidHelperEvent()", "Wrong format")
-		return nil
-	} else {
-		for attributeName, value := range value {
-			if attributeName == "postedEvents" {
-				if events, ok := value.([]any); ok {
-					for _, event := range events {
-						if currentEvent, ok := event.(map[string]any); ok {
-							messageID := currentEvent["id"].(string)
-							startF := currentEvent["startTime"].(float64)
-							startUnix := int64(startF)
-							eventTime := time.Unix(startUnix, 0)
-							if eventTime.After(periodBack) {
-								raidEvents[messageID] = currentEvent
-							}
-						}
-					}
-				}
-			}
-		}
-		if len(raidEvents) == 0 {
-			WriteErrorLog("The length of raid-helper events is 0 looking back to time %s, during the function RetrieveRaidHelperEvent()", "Length 0")
+	raidEvents := make(map[string]raidHelperPostedEvent)
+	for _, event := range postedEvents {
+		if time.Unix(event.StartTime, 0).After(periodBack) {
+			raidEvents[event.ID] = event
 		}
 	}
+	if len(raidEvents) == 0 {
+		WriteDebugLog(fmt.Sprintf("No raid-helper events found for server %s looking back to time %s, during the function RetrieveRaidHelperEvent()", guild.ServerID, periodBack.Format(timeLayout)), "No raid-helper events", LogField("guildID", guild.ServerID))
+	}
 	return raidEvents
 }
 
@@ -9154,7 +8924,7 @@ func ImportServerJoinConfig() {
 	} else {
 		err := json.Unmarshal(configImportBytes, &ServerJoinQuestionnaireImport)
 		if err != nil {
-			log.Fatal(fmt.Sprintf("An error occured while trying to unmarshal the saved file on path %s:\n"), err.Error())
+			log.Fatal(fmt.Sprintf("An error occured while trying to unmarshal the saved file on path %s:\n", configServerJoin), err.Error())
 		}
 		if ServerJoinQuestionnaireImport.Version == "0.0.0" && len(ServerJoinQuestionnaireImport.Questions) > 0 {
 			WriteInformationLog("It is highly recommended to bumb the version property from 0.0.0 when the config file is actually defined, because otherwise you risk the bot rejecting the file, during the function ImportServerJoinConfig()", "Bad version")
@@ -9163,102 +8933,244 @@ func ImportServerJoinConfig() {
 	}
 }
 
-func ImportClasses() {
-	classesImportBytes, err := os.ReadFile(classesPath)
-	if err != nil {
-		log.Fatal("the following error occured while trying to load the classes config file:\n", err)
+func ImportGuildConfig() error {
+	guildConfigMutex.Lock()
+	defer guildConfigMutex.Unlock()
+	guildConfigsImport := []guildConfig{}
+	if guildConfigBytes := CheckForExistingCache(guildConfigPath); len(guildConfigBytes) == 0 {
+		guildConfigsImport = append(guildConfigsImport, guildConfigDefault)
+		marshal, err := json.MarshalIndent(guildConfigsImport, "", " ")
+		if err != nil {
+			return fmt.Errorf("the default guild config could not be marshaled: %s", err.Error())
+		}
+		err = os.WriteFile(guildConfigPath, marshal, 0644)
+		if err != nil {
+			return fmt.Errorf("the default guild config could not be written to path %s: %s", guildConfigPath, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("No guild config found on disc - The default guild config has been written to path %s, during the function ImportGuildConfig()", guildConfigPath), "No config found")
+	} else if err := json.Unmarshal(guildConfigBytes, &guildConfigsImport); err != nil {
+		guildConfigSingle := guildConfig{} //Files written before the bot supported more than 1 guild only contain a single object
+		if errSingle := json.Unmarshal(guildConfigBytes, &guildConfigSingle); errSingle != nil {
+			return fmt.Errorf("the guild config on path %s could not be unmarshaled: %s", guildConfigPath, err.Error())
+		}
+		guildConfigsImport = append(guildConfigsImport, guildConfigSingle)
 	}
-	if err := json.Unmarshal(classesImportBytes, &classesImport); err != nil {
-		log.Fatal("the following error occured while trying to convert bytes to maps:\n", err)
+
+	if len(guildConfigsImport) == 0 {
+		return fmt.Errorf("the guild config on path %s must contain at least 1 guild", guildConfigPath)
+	}
+	mapOfGuildConfigsImport := make(map[string]guildConfig)
+	guildIDsImport := []string{}
+	for _, guildConfigImport := range guildConfigsImport {
+		if err := ValidateGuildConfig(guildConfigImport); err != nil {
+			return err
+		}
+		if _, ok := mapOfGuildConfigsImport[guildConfigImport.ServerID]; ok {
+			return fmt.Errorf("the guild config on path %s contains the server %s more than once", guildConfigPath, guildConfigImport.ServerID)
+		}
+		mapOfGuildConfigsImport[guildConfigImport.ServerID] = guildConfigImport
+		guildIDsImport = append(guildIDsImport, guildConfigImport.ServerID)
+	}
+
+	for _, guildID := range guildIDsImport {
+		err := os.MkdirAll(baseCachePath+guildCacheFolder+guildID, 0755)
+		if err != nil {
+			return fmt.Errorf("the cache folder for server %s could not be created: %s", guildID, err.Error())
+		}
 	}
+	MigrateLegacyGuildCache(guildIDsImport[0])
+
+	mapOfGuildConfigs = mapOfGuildConfigsImport
+	guildIDs = guildIDsImport
+	primaryGuild := mapOfGuildConfigs[guildIDs[0]]
+	SetPrimaryGuildLocation(primaryGuild.TimeZone)
+	configCurrent.ServerID = primaryGuild.ServerID
+	configCurrent.WarcraftLogsGuildID = strconv.Itoa(primaryGuild.WarcraftLogsGuildID)
+	WriteInformationLog(fmt.Sprintf("Guild config on path %s has been imported with %d servers, primary server is %s, during the function ImportGuildConfig()", guildConfigPath, len(guildIDs), primaryGuild.ServerID), "Import successful")
+	return nil
 }
 
-func ImportEmojies() {
-	emojiesImportBytes, err := os.ReadFile(emojiesPath)
-	if err != nil {
-		log.Fatal("the following error occured while trying to load the classes config file:\n", err)
+// An empty guildID returns the primary guild, which is the first one in the guild config. This is used for DM's and jobs not bound to a server
+func GetGuildConfig(guildID string) guildConfig {
+	guildConfigMutex.RLock()
+	defer guildConfigMutex.RUnlock()
+	if guildID == "" && len(guildIDs) > 0 {
+		guildID = guildIDs[0]
 	}
-	if err := json.Unmarshal(emojiesImportBytes, &emojiesImport); err != nil {
-		log.Fatal("the following error occured while trying to convert bytes to maps:\n", err)
+	if currentGuildConfig, ok := mapOfGuildConfigs[guildID]; ok {
+		return currentGuildConfig
 	}
+	WriteInformationLog(fmt.Sprintf("The server %s is not part of the guild config on path %s, during the function GetGuildConfig()", guildID, guildConfigPath), "Unknown server")
+	return guildConfig{}
 }
 
-func ImportKeyvaultConfig() {
-	keyvaultImportBytes, err := os.ReadFile(keyvaultPath)
-	if err != nil {
-		log.Fatal("An error occured while trying to load the keyvault config: Inside function ImportKeyVaultConfig()", err.Error())
+// A copy of the server ids in the order of the guild config, the guild config can be reloaded while the copy is in use
+func GetGuildIDs() []string {
+	guildConfigMutex.RLock()
+	defer guildConfigMutex.RUnlock()
+	return slices.Clone(guildIDs)
+}
+
+func GetAllGuildConfigs() []guildConfig {
+	guildConfigMutex.RLock()
+	defer guildConfigMutex.RUnlock()
+	returnGuildConfigs := []guildConfig{}
+	for _, guildID := range guildIDs {
+		returnGuildConfigs = append(returnGuildConfigs, mapOfGuildConfigs[guildID])
 	}
-	json.Unmarshal(keyvaultImportBytes, &KeyvaultConfig)
+	return returnGuildConfigs
+}
 
-	if len(KeyvaultConfig.Tokens) < 1 {
-		log.Fatal("An error occured while counting the amount of secrets available: Less than 1 token definitions are present")
+func GetGuildCachePath(guildID string, cachePath string) string {
+	serverID := GetGuildConfig(guildID).ServerID
+	if serverID == "" {
+		return ""
 	}
+	return baseCachePath + guildCacheFolder + serverID + "/" + strings.TrimPrefix(cachePath, baseCachePath)
+}
 
-	KeyvaultConfig = keyvault{
-		Name:   fmt.Sprintf("https://%s.vault.azure.net", KeyvaultConfig.Name),
-		Tokens: KeyvaultConfig.Tokens,
+func MigrateLegacyGuildCache(guildID string) {
+	for _, cachePath := range []string{raiderProfilesCachePath, raidAllDataPath, raidHelperCachePath, raidCachePath} {
+		guildCachePath := baseCachePath + guildCacheFolder + guildID + "/" + strings.TrimPrefix(cachePath, baseCachePath)
+		if _, err := os.Stat(cachePath); err != nil {
+			continue
+		}
+		if _, err := os.Stat(guildCachePath); err == nil {
+			continue
+		}
+		err := os.Rename(cachePath, guildCachePath)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to move the cache %s to %s, during the function MigrateLegacyGuildCache()", cachePath, guildCachePath), err.Error())
+			continue
+		}
+		WriteInformationLog(fmt.Sprintf("The cache %s has been moved to %s for the primary server %s, during the function MigrateLegacyGuildCache()", cachePath, guildCachePath, guildID), "Moved cache")
 	}
 }
 
-// Any errors occruing within this function will kill this program
-func WriteErrorLog(message string, errorMessage string) {
-	errorLogMutex.Lock()
-	defer errorLogMutex.Unlock()
-	cachedErrors := []errorLog{}
-	errStruct := errorLog{
-		Message:   message,
-		Error:     errorMessage,
-		TimeStamp: GetTimeString(),
+func ValidateGuildConfig(guildConfigToValidate guildConfig) error {
+	patternDiscordID := regexp.MustCompile(`^\d{17,20}$`)
+	problems := []string{}
+	checkID := func(name string, value string) {
+		if !patternDiscordID.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s has invalid discord ID '%s'", name, value))
+		}
 	}
-	if currentCache := CheckForExistingCache(errorLogPath); len(currentCache) > 0 {
-		err := json.Unmarshal(currentCache, &cachedErrors)
-		if err != nil {
-			log.Fatal("An error occured while trying to unmarshal json from information log cache: inside function WriteErrorLog()", err)
+
+	checkID("serverID", guildConfigToValidate.ServerID)
+	if guildConfigToValidate.WarcraftLogsGuildID <= 0 {
+		problems = append(problems, "warcraftLogsGuildID must be larger than 0")
+	}
+	if guildConfigToValidate.TimeZone != "" {
+		if _, err := LoadTimeZone(guildConfigToValidate.TimeZone); err != nil {
+			problems = append(problems, fmt.Sprintf("timeZone %s", err.Error()))
+		}
+	}
+	if guildConfigToValidate.AttendancePolicy.BenchCredit < 0 || guildConfigToValidate.AttendancePolicy.BenchCredit > 1 {
+		problems = append(problems, "attendancePolicy.benchCredit must be between 0 and 1")
+	}
+	if guildConfigToValidate.AttendancePolicy.NoticeHours < 0 {
+		problems = append(problems, "attendancePolicy.noticeHours cannot be negative")
+	}
+	for _, leadHours := range guildConfigToValidate.SignUpNags.LeadHours {
+		if leadHours <= 0 {
+			problems = append(problems, fmt.Sprintf("signUpNags.leadHours must be above 0, got %d", leadHours))
 		}
 	}
-	cachedErrors = append(cachedErrors, errStruct)
+	rotation := guildConfigToValidate.BenchRotation
+	if rotation.TankSlots < 0 || rotation.HealerSlots < 0 || rotation.DPSSlots < 0 {
+		problems = append(problems, "benchRotation slots cannot be negative")
+	}
+	if rotation.BenchWeight < 0 || rotation.AttendanceWeight < 0 || rotation.PerformanceWeight < 0 || rotation.CoverageWeight < 0 {
+		problems = append(problems, "benchRotation weights cannot be negative")
+	}
 
-	errJson, err := json.MarshalIndent(cachedErrors, "", " ")
-	if err != nil {
-		log.Fatal("An error occured while trying to marshal information log to json: inside function WriteErrorLog()", err)
+	for prefix, group := range map[string]any{"channels": guildConfigToValidate.Channels, "categories": guildConfigToValidate.Categories, "roles": guildConfigToValidate.Roles} {
+		groupValue := reflect.ValueOf(group)
+		for x := 0; x < groupValue.NumField(); x++ {
+			jsonTag := groupValue.Type().Field(x).Tag.Get("json")
+			if strings.HasSuffix(jsonTag, ",omitempty") && groupValue.Field(x).String() == "" { //Optional
+				continue
+			}
+			checkID(fmt.Sprintf("%s.%s", prefix, strings.TrimSuffix(jsonTag, ",omitempty")), groupValue.Field(x).String())
+		}
 	}
 
-	cacheFile, err := os.OpenFile(errorLogPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		log.Fatal(fmt.Sprintf("Error opening file: Inside function WriteErrorLog() %s", errorLogPath), err.Error())
+	for className, channelID := range guildConfigToValidate.ClassChannels {
+		checkID(fmt.Sprintf("classChannels.%s", className), channelID)
 	}
-	defer cacheFile.Close()
-	cacheFile.Write(errJson)
-}
 
-func WriteInformationLog(message string, action string) {
-	cachedLogs := []informationLog{}
-	information := informationLog{
-		Action:    action,
-		Message:   message,
-		TimeStamp: GetTimeString(),
+	for className, roleID := range guildConfigToValidate.ClassRoles {
+		checkID(fmt.Sprintf("classRoles.%s", className), roleID)
 	}
 
-	if currentCache := CheckForExistingCache(informationLogPath); currentCache != nil {
-		err := json.Unmarshal(currentCache, &cachedLogs)
-		if err != nil {
-			WriteErrorLog("An error occured while trying to unmarshal json from information log cache: Inside function WriteInformationLog()", err.Error())
+	countGuildMasters := 0
+	for _, officer := range guildConfigToValidate.Officers {
+		checkID(fmt.Sprintf("officers.%s", officer.Name), officer.ID)
+		if officer.Name == "" {
+			problems = append(problems, fmt.Sprintf("officer with ID %s is missing a name", officer.ID))
 		}
+		if officer.GuildMaster {
+			countGuildMasters++
+		}
+	}
+	if countGuildMasters != 1 {
+		problems = append(problems, fmt.Sprintf("exactly 1 officer must have guildMaster set to true but found %d", countGuildMasters))
+	}
+
+	for loggerName, loggerID := range guildConfigToValidate.Loggers {
+		checkID(fmt.Sprintf("loggers.%s", loggerName), loggerID)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("the guild config on path %s is invalid: %s", guildConfigPath, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func ImportClasses() {
+	classesImportBytes, err := os.ReadFile(classesPath)
+	if err != nil {
+		log.Fatal("the following error occured while trying to load the classes config file:\n", err)
+	}
+	if err := json.Unmarshal(classesImportBytes, &classesImport); err != nil {
+		log.Fatal("the following error occured while trying to convert bytes to maps:\n", err)
 	}
-	cachedLogs = append(cachedLogs, information)
+}
 
-	informationJson, err := json.MarshalIndent(cachedLogs, "", " ")
+func ImportEmojies() {
+	emojiesImportBytes, err := os.ReadFile(emojiesPath)
 	if err != nil {
-		WriteErrorLog("An error occured while trying to marshal information log to json: Inside function WriteInformationLog()", err.Error())
+		log.Fatal("the following error occured while trying to load the classes config file:\n", err)
+	}
+	if err := json.Unmarshal(emojiesImportBytes, &emojiesImport); err != nil {
+		log.Fatal("the following error occured while trying to convert bytes to maps:\n", err)
 	}
+}
 
-	cacheFile, err := os.OpenFile(informationLogPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+func ImportKeyvaultConfig() {
+	keyvaultImportBytes, err := os.ReadFile(keyvaultPath)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("Error opening file: %s inside function WriteInformationLog()", informationLogPath), err.Error())
+		log.Fatal("An error occured while trying to load the keyvault config: Inside function ImportKeyVaultConfig()", err.Error())
+	}
+	if err := json.Unmarshal(keyvaultImportBytes, &KeyvaultConfig); err != nil {
+		log.Fatal("An error occured while trying to unmarshal the keyvault config: Inside function ImportKeyVaultConfig()", err.Error())
 	}
-	defer cacheFile.Close()
 
-	cacheFile.Write(informationJson)
+	for _, secretName := range requiredSecretNames {
+		if !slices.ContainsFunc(KeyvaultConfig.Tokens, func(token keyvaultToken) bool { return token.Name == secretName }) {
+			KeyvaultConfig.Tokens = append(KeyvaultConfig.Tokens, keyvaultToken{Name: secretName})
+		}
+	}
+	if len(KeyvaultConfig.Providers) == 0 {
+		KeyvaultConfig.Providers = []string{secretProviderKeyvault, secretProviderEnv, secretProviderFile}
+	}
+	if KeyvaultConfig.SecretsFilePath == "" {
+		KeyvaultConfig.SecretsFilePath = secretsFileDefaultPath
+	}
+	if KeyvaultConfig.Name != "" && !strings.HasPrefix(KeyvaultConfig.Name, "https://") {
+		KeyvaultConfig.Name = fmt.Sprintf("https://%s.vault.azure.net", KeyvaultConfig.Name)
+	}
 }
 
 func PrepareRaidResponse(raids []logAllData, summary bool) (string, error) {
@@ -9280,6 +9192,9 @@ func StorageAccountClient(storageAccountURI string) *azblob.Client {
 		WriteErrorLog("An error occured while trying to create a azidentity cred:", err.Error())
 		return nil
 	}
+	if !strings.HasPrefix(storageAccountURI, "https://") {
+		storageAccountURI = "https://" + storageAccountURI
+	}
 	if storageClient, err := azblob.NewClient(storageAccountURI, cred, &azblob.ClientOptions{}); err == nil {
 		return storageClient
 	} else {
@@ -9288,22 +9203,25 @@ func StorageAccountClient(storageAccountURI string) *azblob.Client {
 	return nil
 }
 
-func StorageAccountAppendBlob(blobName string, containerName string, logName string, storageClient *azblob.Client, context context.Context) error {
-	//Runs first time as part of app starting when being deployed (The appendblob wont be there yet)
-	storageClient.CreateContainer(context, containerName, nil)
-
-	logFile, err := os.Open(logName)
-	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to open the log file %s, during the function StorageAccountAppendBlob()", logName), err.Error())
-		return err
+// Appends the data as a single block, the container and the append blob are created the first time they are missing
+// Returns the error without logging it, the log shipper logs a failed batch once instead of every attempt
+func StorageAccountAppendBlob(blobName string, containerName string, data []byte, storageClient *azblob.Client, context context.Context) error {
+	appendBlobClient := storageClient.ServiceClient().NewContainerClient(containerName).NewAppendBlobClient(blobName)
+	_, err := appendBlobClient.AppendBlock(context, streaming.NopCloser(bytes.NewReader(data)), nil)
+	if bloberror.HasCode(err, bloberror.ContainerNotFound, bloberror.BlobNotFound) {
+		//Runs first time as part of app starting when being deployed, and once a day when the blob of a new day is created
+		_, err = storageClient.CreateContainer(context, containerName, nil)
+		if err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+			return fmt.Errorf("the container %s could not be created in %s: %s", containerName, storageClient.URL(), err.Error())
+		}
+		_, err = appendBlobClient.Create(context, nil)
+		if err != nil && !bloberror.HasCode(err, bloberror.BlobAlreadyExists) {
+			return fmt.Errorf("the append blob %s could not be created in %s: %s", blobName, storageClient.URL(), err.Error())
+		}
+		_, err = appendBlobClient.AppendBlock(context, streaming.NopCloser(bytes.NewReader(data)), nil)
 	}
-
-	defer logFile.Close()
-
-	_, err = storageClient.UploadFile(context, containerName, blobName, logFile, nil)
 	if err != nil {
-		WriteErrorLog(fmt.Sprintf("An error occured while trying to upload the log file %s to %s, during the function StorageAccountAppendBlob()", logName, storageClient.URL()), err.Error())
-		return err
+		return fmt.Errorf("%d bytes could not be appended to the blob %s in %s: %s", len(data), blobName, storageClient.URL(), err.Error())
 	}
 	return nil
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type raidInstance struct {
+	Name          string   `json:"name"`          //As warcraftlogs names the zone, e.g. "Molten Core"
+	ShortName     string   `json:"shortName"`     //Used in the titles of the logs and the raid cache, e.g. "mc"
+	Aliases       []string `json:"aliases"`       //Other names found in the titles of the logs, e.g. "molten"
+	Emoji         string   `json:"emoji"`         //Shown in front of the name, may be empty
+	ZoneID        int      `json:"zoneID"`        //Warcraftlogs zone, used when the title of the log does not name the raid
+	ResetDays     int      `json:"resetDays"`     //Days between the resets of the instance
+	ResetWeekday  string   `json:"resetWeekday"`  //The day the instance resets, e.g. "wednesday", only for resetDays of whole weeks. Empty for thursday
+	Main          bool     `json:"main"`          //Main raids count towards the attendance, secondary raids rotate their logger
+	SkippedBosses []string `json:"skippedBosses"` //Left out of the encounters, the live feed and /raidsummary boss
+}
+
+var (
+	raidCatalogMutex   sync.RWMutex
+	raidCatalogDefault = []raidInstance{
+		{Name: "Onyxia", ShortName: "ony", Aliases: []string{"onyxia", "onyxia's lair"}, Emoji: "🐉", ZoneID: 1001, ResetDays: 5},
+		{Name: "Molten Core", ShortName: "mc", Aliases: []string{"molten"}, Emoji: "🌋", ZoneID: 1000, ResetDays: 7, ResetWeekday: "thursday", Main: true},
+		{Name: "Blackwing Lair", ShortName: "bwl", Aliases: []string{"blackwing"}, Emoji: "🐲", ZoneID: 1002, ResetDays: 7, ResetWeekday: "thursday", Main: true},
+		{Name: "Zul'Gurub", ShortName: "zg", Aliases: []string{"zulgurub", "zul'gurub"}, Emoji: "🐍", ZoneID: 1003, ResetDays: 3},
+		{Name: "Ruins of Ahn'Qiraj", ShortName: "aq20", Aliases: []string{"ruins"}, Emoji: "🦂", ZoneID: 1004, ResetDays: 3},
+		{Name: "Temple of Ahn'Qiraj", ShortName: "aq40", Aliases: []string{"aq", "temple"}, Emoji: "🪲", ZoneID: 1005, ResetDays: 7, ResetWeekday: "thursday", Main: true},
+		{Name: "Naxxramas", ShortName: "naxx", Aliases: []string{"naxxramas"}, Emoji: "💀", ZoneID: 1006, ResetDays: 7, ResetWeekday: "thursday", Main: true, SkippedBosses: []string{"Gothik the Harvester"}},
+	}
+	raidCatalogCurrent = raidCatalogDefault //Replaced by the function ImportRaidCatalog() during start-up
+)
+
+// Reads the raid catalog, the default catalog is written to disc when no catalog exists yet
+func ImportRaidCatalog() error {
+	raidCatalogImport := []raidInstance{}
+	if raidCatalogBytes := CheckForExistingCache(raidCatalogPath); len(raidCatalogBytes) == 0 {
+		raidCatalogImport = raidCatalogDefault
+		marshal, err := json.MarshalIndent(raidCatalogImport, "", " ")
+		if err != nil {
+			return fmt.Errorf("the default raid catalog could not be marshaled: %s", err.Error())
+		}
+		if err := os.WriteFile(raidCatalogPath, marshal, 0644); err != nil {
+			return fmt.Errorf("the default raid catalog could not be written to path %s: %s", raidCatalogPath, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("No raid catalog found on disc - The default raid catalog has been written to path %s, during the function ImportRaidCatalog()", raidCatalogPath), "No config found")
+	} else if err := json.Unmarshal(raidCatalogBytes, &raidCatalogImport); err != nil {
+		return fmt.Errorf("the raid catalog on path %s could not be unmarshaled: %s", raidCatalogPath, err.Error())
+	}
+	if err := ValidateRaidCatalog(raidCatalogImport); err != nil {
+		return err
+	}
+	raidCatalogMutex.Lock()
+	raidCatalogCurrent = raidCatalogImport
+	raidCatalogMutex.Unlock()
+	WriteInformationLog(fmt.Sprintf("Raid catalog on path %s has been imported with %d raids, during the function ImportRaidCatalog()", raidCatalogPath, len(raidCatalogImport)), "Import successful")
+	return nil
+}
+
+func ValidateRaidCatalog(raidCatalog []raidInstance) error {
+	problems := []string{}
+	mapOfNames := make(map[string]string) //Every name, short name and alias in lower case to the raid using it
+	for x, raid := range raidCatalog {
+		if raid.Name == "" || raid.ShortName == "" {
+			problems = append(problems, fmt.Sprintf("raid number %d must have both a name and a shortName", x+1))
+			continue
+		}
+		if raid.ResetDays <= 0 {
+			problems = append(problems, fmt.Sprintf("%s must have resetDays above 0", raid.Name))
+		}
+		if raid.ResetWeekday != "" {
+			if _, ok := ParseWeekday(raid.ResetWeekday); !ok {
+				problems = append(problems, fmt.Sprintf("%s has the unknown resetWeekday %s, use e.g. wednesday", raid.Name, raid.ResetWeekday))
+			}
+			if raid.ResetDays%7 != 0 {
+				problems = append(problems, fmt.Sprintf("%s can only have a resetWeekday when resetDays is a whole number of weeks", raid.Name))
+			}
+		}
+		for _, name := range append([]string{raid.Name, raid.ShortName}, raid.Aliases...) {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if otherRaid, ok := mapOfNames[name]; ok && otherRaid != raid.Name {
+				problems = append(problems, fmt.Sprintf("the name %s is used by both %s and %s", name, otherRaid, raid.Name))
+			}
+			mapOfNames[name] = raid.Name
+		}
+	}
+	if len(raidCatalog) == 0 {
+		problems = append(problems, "it must contain at least 1 raid")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("the raid catalog on path %s is invalid: %s", raidCatalogPath, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func GetRaidCatalog() []raidInstance {
+	raidCatalogMutex.RLock()
+	defer raidCatalogMutex.RUnlock()
+	return raidCatalogCurrent
+}
+
+// Matches the name, short name and aliases without caring about case
+func GetRaidInstance(name string) (raidInstance, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return raidInstance{}, false
+	}
+	for _, raid := range GetRaidCatalog() {
+		if strings.ToLower(raid.Name) == name || strings.ToLower(raid.ShortName) == name {
+			return raid, true
+		}
+		for _, alias := range raid.Aliases {
+			if strings.ToLower(strings.TrimSpace(alias)) == name {
+				return raid, true
+			}
+		}
+	}
+	return raidInstance{}, false
+}
+
+func GetRaidInstanceByZone(zoneID int) (raidInstance, bool) {
+	for _, raid := range GetRaidCatalog() {
+		if raid.ZoneID == zoneID && zoneID != 0 {
+			return raid, true
+		}
+	}
+	return raidInstance{}, false
+}
+
+// The first reset of the instance after the current time, the time of day is kept from the current time
+func GetNextRaidReset(raid raidInstance, currentTime time.Time) time.Time {
+	resetWeekday, ok := ParseWeekday(raid.ResetWeekday)
+	if !ok { //Raid catalogs written before the resetWeekday existed
+		return GetNextMainReset(currentTime)
+	}
+	daysUntilReset := (int(resetWeekday) - int(currentTime.Weekday()) + 7) % 7
+	if daysUntilReset == 0 {
+		daysUntilReset = 7
+	}
+	return currentTime.AddDate(0, 0, daysUntilReset)
+}
+
+// The first reset of the main raids of the catalog, a catalog without main raids resets thursday
+func GetNextMainRaidReset(currentTime time.Time) time.Time {
+	nextReset := time.Time{}
+	for _, raid := range GetRaidCatalog() {
+		if raidReset := GetNextRaidReset(raid, currentTime); raid.Main && (nextReset.IsZero() || raidReset.Before(nextReset)) {
+			nextReset = raidReset
+		}
+	}
+	if nextReset.IsZero() {
+		return GetNextMainReset(currentTime)
+	}
+	return nextReset
+}
+
+// A raid of several instances is a main raid when just one of them is, names missing from the catalog count as main raids
+func IsMainRaid(raidNames []string) bool {
+	if len(raidNames) == 0 {
+		return true
+	}
+	for _, raidName := range raidNames {
+		if raid, ok := GetRaidInstance(raidName); !ok || raid.Main {
+			return true
+		}
+	}
+	return false
+}
+
+func GetSecondaryRaidInstances() []raidInstance {
+	secondaryRaids := []raidInstance{}
+	for _, raid := range GetRaidCatalog() {
+		if !raid.Main {
+			secondaryRaids = append(secondaryRaids, raid)
+		}
+	}
+	return secondaryRaids
+}
+
+// Puts the emoji of the raid in front of the name when the catalog has one
+func FormatRaidName(raidName string) string {
+	if raid, ok := GetRaidInstance(raidName); ok && raid.Emoji != "" {
+		return fmt.Sprintf("%s %s", raid.Emoji, raidName)
+	}
+	return raidName
+}
+
+func FormatRaidNames(raidNames []string) string {
+	formattedNames := []string{}
+	for _, raidName := range raidNames {
+		formattedNames = append(formattedNames, FormatRaidName(raidName))
+	}
+	return strings.Join(formattedNames, " & ")
+}
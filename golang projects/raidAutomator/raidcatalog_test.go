@@ -0,0 +1,95 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func SetUpTestRaidCatalog(t *testing.T, raidCatalog []raidInstance) {
+	t.Helper()
+	if err := ValidateRaidCatalog(raidCatalog); err != nil {
+		t.Fatal(err)
+	}
+	raidCatalogMutex.Lock()
+	importedCatalog := raidCatalogCurrent
+	raidCatalogCurrent = raidCatalog
+	raidCatalogMutex.Unlock()
+	t.Cleanup(func() {
+		raidCatalogMutex.Lock()
+		raidCatalogCurrent = importedCatalog
+		raidCatalogMutex.Unlock()
+	})
+}
+
+func TestGetNextRaidReset(t *testing.T) {
+	wednesday := time.Date(2025, time.January, 8, 18, 30, 0, 0, time.UTC)
+	for _, testCase := range []struct {
+		resetWeekday string
+		currentTime  time.Time
+		expected     time.Time
+	}{
+		{"", wednesday, time.Date(2025, time.January, 9, 18, 30, 0, 0, time.UTC)}, //Thursday without a reset weekday
+		{"thursday", wednesday, time.Date(2025, time.January, 9, 18, 30, 0, 0, time.UTC)},
+		{"Tuesday", wednesday, time.Date(2025, time.January, 14, 18, 30, 0, 0, time.UTC)},
+		{"wednesday", wednesday, time.Date(2025, time.January, 15, 18, 30, 0, 0, time.UTC)}, //The reset of today has passed
+		{"sunday", time.Date(2025, time.January, 31, 9, 0, 0, 0, time.UTC), time.Date(2025, time.February, 2, 9, 0, 0, 0, time.UTC)},
+	} {
+		nextReset := GetNextRaidReset(raidInstance{Name: "Molten Core", ResetDays: 7, ResetWeekday: testCase.resetWeekday}, testCase.currentTime)
+		if !nextReset.Equal(testCase.expected) {
+			t.Errorf("expected the reset weekday %q after %s to give %s, got %s", testCase.resetWeekday, testCase.currentTime, testCase.expected, nextReset)
+		}
+	}
+}
+
+func TestGetNextMainRaidReset(t *testing.T) {
+	wednesday := time.Date(2025, time.January, 8, 18, 30, 0, 0, time.UTC)
+	SetUpTestRaidCatalog(t, []raidInstance{
+		{Name: "Onyxia's Lair", ShortName: "ony", ResetDays: 5},
+		{Name: "Molten Core", ShortName: "mc", ResetDays: 7, ResetWeekday: "tuesday", Main: true},
+		{Name: "Blackwing Lair", ShortName: "bwl", ResetDays: 7, ResetWeekday: "saturday", Main: true},
+	})
+	if nextReset, expected := GetNextMainRaidReset(wednesday), time.Date(2025, time.January, 11, 18, 30, 0, 0, time.UTC); !nextReset.Equal(expected) {
+		t.Errorf("expected the first reset of the main raids to be %s, got %s", expected, nextReset)
+	}
+
+	SetUpTestRaidCatalog(t, []raidInstance{{Name: "Onyxia's Lair", ShortName: "ony", ResetDays: 5}})
+	if nextReset, expected := GetNextMainRaidReset(wednesday), time.Date(2025, time.January, 9, 18, 30, 0, 0, time.UTC); !nextReset.Equal(expected) {
+		t.Errorf("expected a catalog without main raids to reset thursday %s, got %s", expected, nextReset)
+	}
+}
+
+func TestIsMainRaid(t *testing.T) {
+	SetUpTestRaidCatalog(t, []raidInstance{
+		{Name: "Molten Core", ShortName: "mc", ResetDays: 7, Main: true},
+		{Name: "Onyxia's Lair", ShortName: "ony", Aliases: []string{"onyxia"}, ResetDays: 5},
+		{Name: "Zul'Gurub", ShortName: "zg", ResetDays: 3},
+	})
+	for _, testCase := range []struct {
+		raidNames []string
+		expected  bool
+	}{
+		{[]string{}, true},
+		{[]string{"Molten Core"}, true},
+		{[]string{"mc", "onyxia"}, true}, //Onyxia cleared together with the main raid
+		{[]string{"Onyxia's Lair"}, false},
+		{[]string{"ZG", "ony"}, false},
+		{[]string{"Naxxramas"}, true}, //Missing from the catalog
+	} {
+		if isMainRaid := IsMainRaid(testCase.raidNames); isMainRaid != testCase.expected {
+			t.Errorf("expected the raids %v to be a main raid %t, got %t", testCase.raidNames, testCase.expected, isMainRaid)
+		}
+	}
+}
+
+func TestIsBossSkipped(t *testing.T) {
+	SetUpTestRaidCatalog(t, []raidInstance{{Name: "Naxxramas", ShortName: "naxx", ResetDays: 7, Main: true, SkippedBosses: []string{"gothik the harvester"}}})
+	for bossName, expected := range map[string]bool{
+		"Gothik the Harvester": true,
+		"gothik the harvester": true,
+		"Patchwerk":            false,
+	} {
+		if isSkipped := IsBossSkipped(bossName); isSkipped != expected {
+			t.Errorf("expected %s to be skipped %t, got %t", bossName, expected, isSkipped)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type raidHelperClient struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+}
+
+type raidHelperError struct {
+	Endpoint   string
+	StatusCode int
+	Message    string
+}
+
+// Must follow the API structure of the official documentation https://raid-helper.dev/documentation/api
+type raidHelperPostedEvent struct {
+	ID         string `json:"id"` //The ID of the discord message of the event
+	ChannelID  string `json:"channelId"`
+	LeaderID   string `json:"leaderId"`
+	LeaderName string `json:"leaderName"`
+	Title      string `json:"title"`
+	StartTime  int64  `json:"startTime"` //Unix seconds
+	EndTime    int64  `json:"endTime"`
+}
+
+// Must follow the API structure of the official documentation https://raid-helper.dev/documentation/api
+type raidHelperEventDetails struct {
+	raidHelperPostedEvent
+	ServerID    string             `json:"serverId"`
+	Description string             `json:"description"`
+	SignUps     []raidHelperSignUp `json:"signUps"`
+}
+
+type raidHelperSignUp struct {
+	Name      string `json:"name"`      //Name on discord, often the name of the main char
+	UserID    string `json:"userId"`    //Discord ID of the user
+	ClassName string `json:"className"` //The class, or Absence, Bench, Tentative or Late
+	SpecName  string `json:"specName"`
+	RoleName  string `json:"roleName"` //Tanks, Healers, Melee or Ranged
+	Status    string `json:"status"`   //primary when the sign-up is in the raid, queued when the raid is full
+}
+
+type raidHelperServerEvents struct {
+	PostedEvents []raidHelperPostedEvent `json:"postedEvents"`
+}
+
+type raidHelperCreatedEvent struct {
+	ID    string `json:"id"`
+	Event struct {
+		ID string `json:"id"`
+	} `json:"event"`
+}
+
+const (
+	raidHelperAPIURL        = "https://raid-helper.dev/api"
+	raidHelperClientTimeout = 30 * time.Second
+)
+
+var raidHelperCurrent *raidHelperClient //Set by the function CheckRuntime() during start-up
+
+func NewRaidHelperClient(token string) *raidHelperClient {
+	return &raidHelperClient{
+		httpClient: &http.Client{Timeout: raidHelperClientTimeout},
+		apiURL:     raidHelperAPIURL,
+		token:      token,
+	}
+}
+
+func (err *raidHelperError) Error() string {
+	return fmt.Sprintf("the raid-helper endpoint %s returned status %d: %s", err.Endpoint, err.StatusCode, err.Message)
+}
+
+// Only the events posted on discord are returned, the events that are still drafts are left out
+func (client *raidHelperClient) GetServerEvents(serverID string) ([]raidHelperPostedEvent, error) {
+	serverEvents := raidHelperServerEvents{}
+	if err := client.Do("GET", fmt.Sprintf("/v3/servers/%s/events", serverID), nil, &serverEvents); err != nil {
+		return nil, err
+	}
+	return serverEvents.PostedEvents, nil
+}
+
+func (client *raidHelperClient) GetEvent(messageID string) (raidHelperEventDetails, error) {
+	event := raidHelperEventDetails{}
+	if err := client.Do("GET", "/v2/events/"+messageID, nil, &event); err != nil {
+		return raidHelperEventDetails{}, err
+	}
+	if event.ID == "" {
+		event.ID = messageID
+	}
+	return event, nil
+}
+
+func (client *raidHelperClient) GetEventSignUps(messageID string) ([]raidHelperSignUp, error) {
+	event, err := client.GetEvent(messageID)
+	if err != nil {
+		return nil, err
+	}
+	return event.SignUps, nil
+}
+
+// Returns the ID of the discord message of the new event
+func (client *raidHelperClient) CreateEvent(serverID string, channelID string, event raidHelperEvent) (string, error) {
+	createdEvent := raidHelperCreatedEvent{}
+	if err := client.Do("POST", fmt.Sprintf("/v2/servers/%s/channels/%s/event", serverID, channelID), event, &createdEvent); err != nil {
+		return "", err
+	}
+	if createdEvent.Event.ID != "" {
+		return createdEvent.Event.ID, nil
+	}
+	if createdEvent.ID != "" {
+		return createdEvent.ID, nil
+	}
+	return "", fmt.Errorf("raid-helper did not return the ID of the event %s", event.Title)
+}
+
+// The body is marshaled when given and the response is unmarshaled into the target
+func (client *raidHelperClient) Do(method string, endpoint string, body any, target any) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("the body for the raid-helper endpoint %s could not be marshaled: %s", endpoint, err.Error())
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+	request, err := http.NewRequest(method, client.apiURL+endpoint, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", client.token)
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("the HTTP %s to the raid-helper endpoint %s failed: %s", method, endpoint, err.Error())
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("the body from the raid-helper endpoint %s could not be read: %s", endpoint, err.Error())
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return &raidHelperError{Endpoint: endpoint, StatusCode: response.StatusCode, Message: strings.TrimSpace(string(responseBody))}
+	}
+	if err := json.Unmarshal(responseBody, target); err != nil {
+		return fmt.Errorf("the body from the raid-helper endpoint %s could not be unmarshaled: %s", endpoint, err.Error())
+	}
+	return nil
+}
+
+func (event raidHelperEventDetails) GetBench() []raidHelperSignUp {
+	benchedSignUps := []raidHelperSignUp{}
+	for _, signUp := range event.SignUps {
+		if ClassifyRaidHelperSignUp(signUp.ClassName) == signUpBench {
+			benchedSignUps = append(benchedSignUps, signUp)
+		}
+	}
+	return benchedSignUps
+}
+
+// Builds the benches of the event the same way as the function GetWeeklyBench() does from the embed
+func GetWeeklyBenchFromEvent(event raidHelperEventDetails) map[string]bench {
+	benchRaidersMap := make(map[string]bench)
+	raidDate := time.Unix(event.StartTime, 0).In(GetGuildLocation(event.ServerID)).Format(timeLayOutShort)
+	for _, signUp := range event.GetBench() {
+		benchRaidersMap[signUp.Name] = bench{
+			RaidLeaderName:      event.LeaderName,
+			RaidLeaderDiscordID: event.LeaderID,
+			DateString:          raidDate,
+			RaidTitle:           event.Title,
+		}
+	}
+	return benchRaidersMap
+}
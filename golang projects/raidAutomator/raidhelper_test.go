@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Points a raid-helper client at a server answering every request with the status and body given
+func NewTestRaidHelperClient(t *testing.T, statusCode int, responseBody string, onRequest func(request *http.Request, body []byte)) *raidHelperClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		if onRequest != nil {
+			onRequest(request, body)
+		}
+		writer.WriteHeader(statusCode)
+		io.WriteString(writer, responseBody)
+	}))
+	t.Cleanup(server.Close)
+	client := NewRaidHelperClient("fake-token")
+	client.apiURL = server.URL
+	return client
+}
+
+func TestRaidHelperClientDo(t *testing.T) {
+	for _, testCase := range []struct {
+		name           string
+		statusCode     int
+		responseBody   string
+		expectedStatus int //0 when no raidHelperError is expected
+		expectError    bool
+	}{
+		{"ok", http.StatusOK, `{"id": "1"}`, 0, false},
+		{"created", http.StatusCreated, `{"id": "1"}`, 0, false},
+		{"not found", http.StatusNotFound, "Event not found\n", http.StatusNotFound, true},
+		{"unauthorized", http.StatusUnauthorized, "Invalid token", http.StatusUnauthorized, true},
+		{"server error", http.StatusInternalServerError, "", http.StatusInternalServerError, true},
+		{"no content is not ok", http.StatusNoContent, "", http.StatusNoContent, true},
+		{"malformed body", http.StatusOK, `{"id": `, 0, true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := NewTestRaidHelperClient(t, testCase.statusCode, testCase.responseBody, func(request *http.Request, body []byte) {
+				if request.Method != "POST" || request.URL.Path != "/v2/test" {
+					t.Errorf("expected POST /v2/test, got %s %s", request.Method, request.URL.Path)
+				}
+				if request.Header.Get("Authorization") != "fake-token" || request.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("expected the token and the content type in the headers, got %v", request.Header)
+				}
+				if string(body) != `{"title":"Molten Core"}` {
+					t.Errorf("expected the body to be marshaled, got %s", body)
+				}
+			})
+			target := raidHelperCreatedEvent{}
+			err := client.Do("POST", "/v2/test", map[string]string{"title": "Molten Core"}, &target)
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("expected an error %t, got %v", testCase.expectError, err)
+			}
+			var responseErr *raidHelperError
+			if isResponseErr := errors.As(err, &responseErr); isResponseErr != (testCase.expectedStatus != 0) {
+				t.Fatalf("expected a raid-helper error with status %d, got %v", testCase.expectedStatus, err)
+			} else if isResponseErr && (responseErr.StatusCode != testCase.expectedStatus || responseErr.Endpoint != "/v2/test") {
+				t.Errorf("expected the status %d of /v2/test, got %+v", testCase.expectedStatus, responseErr)
+			}
+			if testCase.name == "not found" && responseErr.Message != "Event not found" {
+				t.Errorf("expected the message of raid-helper without the trailing new line, got %q", responseErr.Message)
+			}
+			if !testCase.expectError && target.ID != "1" {
+				t.Errorf("expected the response to be unmarshaled into the target, got %+v", target)
+			}
+		})
+	}
+}
+
+func TestRaidHelperClientGetEvent(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		responseBody string
+		expectedID   string
+	}{
+		{"id of the response", `{"id": "700000000000000002", "title": "Molten Core", "signUps": [{"name": "Frostbolt", "className": "Bench"}]}`, "700000000000000002"},
+		{"id of the message when the response has none", `{"title": "Molten Core", "signUps": [{"name": "Frostbolt", "className": "Bench"}]}`, "700000000000000001"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := NewTestRaidHelperClient(t, http.StatusOK, testCase.responseBody, func(request *http.Request, body []byte) {
+				if request.Method != "GET" || request.URL.Path != "/v2/events/700000000000000001" {
+					t.Errorf("expected GET /v2/events/700000000000000001, got %s %s", request.Method, request.URL.Path)
+				}
+			})
+			event, err := client.GetEvent("700000000000000001")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if event.ID != testCase.expectedID || event.Title != "Molten Core" || len(event.GetBench()) != 1 {
+				t.Errorf("expected the event %s with a single bench, got %+v", testCase.expectedID, event)
+			}
+		})
+	}
+
+	client := NewTestRaidHelperClient(t, http.StatusNotFound, "Event not found", nil)
+	if _, err := client.GetEvent("700000000000000001"); err == nil {
+		t.Error("expected an event that is not found to return an error")
+	}
+}
+
+func TestRaidHelperClientCreateEvent(t *testing.T) {
+	for _, testCase := range []struct {
+		name         string
+		statusCode   int
+		responseBody string
+		expectedID   string
+		expectError  bool
+	}{
+		{"event.id wins over id", http.StatusOK, `{"id": "1", "event": {"id": "700000000000000002"}}`, "700000000000000002", false},
+		{"id without event.id", http.StatusCreated, `{"id": "700000000000000003"}`, "700000000000000003", false},
+		{"no id at all", http.StatusOK, `{"status": "success"}`, "", true},
+		{"rejected", http.StatusBadRequest, "Invalid date", "", true},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := NewTestRaidHelperClient(t, testCase.statusCode, testCase.responseBody, func(request *http.Request, body []byte) {
+				if request.Method != "POST" || request.URL.Path != "/v2/servers/600000000000000001/channels/600000000000000002/event" {
+					t.Errorf("expected POST to the channel of the server, got %s %s", request.Method, request.URL.Path)
+				}
+				event := raidHelperEvent{}
+				if err := json.Unmarshal(body, &event); err != nil || event.Title != "Molten Core" || event.Date != "12-03-2025" {
+					t.Errorf("expected the event to be sent as the body, got %s", body)
+				}
+			})
+			messageID, err := client.CreateEvent("600000000000000001", "600000000000000002", raidHelperEvent{LeaderID: "346353264461217795", Title: "Molten Core", Date: "12-03-2025", Time: "19:30"})
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("expected an error %t, got %v", testCase.expectError, err)
+			}
+			if messageID != testCase.expectedID {
+				t.Errorf("expected the message ID %q, got %q", testCase.expectedID, messageID)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type signUpResult struct {
+	Name         string //Main char of the raider, or the name in raid-helper or the log when no raider profile matches
+	MainCharName string //Empty when no raider profile matches
+	Record       signUpRecord
+}
+
+const (
+	signUpAccepted  = "accepted"
+	signUpTentative = "tentative"
+	signUpAbsence   = "absence"
+	signUpBench     = "bench"
+
+	signUpOutcomeShowed       = "showed"
+	signUpOutcomeNoShow       = "noshow"
+	signUpOutcomeUnsigned     = "unsigned"
+	signUpOutcomeSignedAbsent = "signedabsent"
+	signUpOutcomeBenched      = "benched"
+
+	signUpReconcileDays = 7             //Main raids older than this are not compared with their sign-ups anymore
+	signUpMatchWindow   = 3 * time.Hour //Longest time between the start of the raid-helper event and the log of the raid
+)
+
+// Late sign-ups and every class count as accepted
+func ClassifyRaidHelperSignUp(className string) string {
+	switch strings.ToLower(className) {
+	case "absence":
+		{
+			return signUpAbsence
+		}
+	case "bench":
+		{
+			return signUpBench
+		}
+	case "tentative":
+		{
+			return signUpTentative
+		}
+	}
+	return signUpAccepted
+}
+
+// Compares the sign-ups with the players seen in the log, players in the log without a sign-up are returned as unsigned
+func ReconcileSignUps(guildID string, raiders []raiderProfile, raid logAllData, signUps []raidHelperSignUp) []signUpResult {
+	results := []signUpResult{}
+	raidDate := time.UnixMilli(raid.RaidStartUnixTime).In(GetGuildLocation(guildID)).Format(timeLayOutShort)
+	mapOfPlayersSeen := make(map[string]bool) //Lower case name of every player in the log that is covered by a sign-up
+	newResult := func(name string, mainCharName string, signUp string, outcome string) signUpResult {
+		return signUpResult{
+			Name:         name,
+			MainCharName: mainCharName,
+			Record: signUpRecord{
+				RaidDate:  raidDate,
+				RaidTitle: raid.RaidTitle,
+				LogCode:   raid.MetaData.Code,
+				SignUp:    signUp,
+				Outcome:   outcome,
+			},
+		}
+	}
+	mapOfRaidersSignedUp := make(map[string]bool)
+	for _, signUp := range signUps {
+		status := ClassifyRaidHelperSignUp(signUp.ClassName)
+		name, mainCharName := signUp.Name, ""
+		inLog := false
+		raiderIndex := slices.IndexFunc(raiders, func(raider raiderProfile) bool {
+			return (signUp.UserID != "" && raider.ID == signUp.UserID) || strings.EqualFold(raider.MainCharName, signUp.Name)
+		})
+		if raiderIndex != -1 {
+			raider := raiders[raiderIndex]
+			if mapOfRaidersSignedUp[raider.MainCharName] { //Raid-helper keeps a single sign-up per user, but a name might match twice
+				continue
+			}
+			mapOfRaidersSignedUp[raider.MainCharName] = true
+			name, mainCharName = raider.MainCharName, raider.MainCharName
+			inLog = IsRaiderInRaid(raider, raid)
+			for _, player := range raid.Players {
+				if _, ok := raider.MainSwitch[player.Name]; ok || player.Name == raider.MainCharName {
+					mapOfPlayersSeen[strings.ToLower(player.Name)] = true
+				}
+			}
+		} else {
+			for _, player := range raid.Players {
+				if strings.EqualFold(player.Name, signUp.Name) {
+					inLog = true
+					mapOfPlayersSeen[strings.ToLower(player.Name)] = true
+				}
+			}
+		}
+		outcome := signUpOutcomeNoShow
+		switch {
+		case status == signUpBench:
+			{
+				outcome = signUpOutcomeBenched
+			}
+		case inLog:
+			{
+				outcome = signUpOutcomeShowed
+			}
+		case status == signUpAbsence:
+			{
+				outcome = signUpOutcomeSignedAbsent
+			}
+		}
+		results = append(results, newResult(name, mainCharName, status, outcome))
+	}
+	for _, player := range raid.Players {
+		if mapOfPlayersSeen[strings.ToLower(player.Name)] {
+			continue
+		}
+		name, mainCharName := player.Name, ""
+		raiderIndex := slices.IndexFunc(raiders, func(raider raiderProfile) bool {
+			_, ok := raider.MainSwitch[player.Name]
+			return ok || raider.MainCharName == player.Name
+		})
+		if raiderIndex != -1 {
+			if mapOfRaidersSignedUp[raiders[raiderIndex].MainCharName] { //Played an alt the sign-up already covers
+				continue
+			}
+			mapOfRaidersSignedUp[raiders[raiderIndex].MainCharName] = true
+			name, mainCharName = raiders[raiderIndex].MainCharName, raiders[raiderIndex].MainCharName
+		}
+		results = append(results, newResult(name, mainCharName, "", signUpOutcomeUnsigned))
+	}
+	return results
+}
+
+func FormatSignUpReconciliation(results []signUpResult) string {
+	mapOfOutcomes := make(map[string][]string)
+	countOfSignUps := 0
+	countOfShowed := 0
+	for _, result := range results {
+		name := result.Name
+		if result.MainCharName == "" {
+			name += " (no raider profile)"
+		}
+		outcome := result.Record.Outcome
+		switch {
+		case outcome == signUpOutcomeNoShow && result.Record.SignUp == signUpTentative:
+			{
+				name += " (tentative)"
+			}
+		case outcome == signUpOutcomeShowed && result.Record.SignUp == signUpAbsence:
+			{
+				outcome = signUpAbsence //Listed on its own, the raider might have forgotten to change the sign-up
+			}
+		}
+		mapOfOutcomes[outcome] = append(mapOfOutcomes[outcome], name)
+		if result.Record.SignUp == signUpAccepted || result.Record.SignUp == signUpTentative {
+			countOfSignUps++
+			if result.Record.Outcome == signUpOutcomeShowed {
+				countOfShowed++
+			}
+		}
+	}
+	parts := []string{fmt.Sprintf("%d of %d sign-ups showed up", countOfShowed, countOfSignUps)}
+	for _, outcome := range []struct {
+		key   string
+		title string
+	}{
+		{signUpOutcomeNoShow, "Signed up but did not show"},
+		{signUpOutcomeUnsigned, "Showed up without signing up"},
+		{signUpOutcomeSignedAbsent, "Signed absent"},
+		{signUpAbsence, "Signed absent but showed up"},
+		{signUpOutcomeBenched, "Benched"},
+	} {
+		if names := mapOfOutcomes[outcome.key]; len(names) > 0 {
+			slices.Sort(names)
+			parts = append(parts, fmt.Sprintf("**%s (%d):**\n%s", outcome.title, len(names), strings.Join(names, ", ")))
+		}
+	}
+	return JoinWithinEmbedLimit(parts, "\n\n")
+}
+
+// Compares every main raid of the last week with the sign-ups of the raid-helper event starting closest to it, each log is only compared once
+func ReconcileGuildSignUps(guildID string, session discordSession) error {
+	guild := GetGuildConfig(guildID)
+	raids, err := storageCurrent.ReadRaids(guildID, time.Now().AddDate(0, 0, -signUpReconcileDays))
+	if err != nil {
+		return fmt.Errorf("the raids of server %s could not be read: %s", guildID, err.Error())
+	}
+	trackedRaids := ReadWriteRaidHelperCache(guildID)
+	mapOfReconciledLogs := make(map[string]bool)
+	for _, trackedRaid := range trackedRaids {
+		if trackedRaid.ReconciledLogCode != "" {
+			mapOfReconciledLogs[trackedRaid.ReconciledLogCode] = true
+		}
+	}
+	currentRaiders, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		return fmt.Errorf("the raider profiles of server %s could not be read: %s", guildID, err.Error())
+	}
+	failedRaids := []string{}
+	mapOfSignUpRecords := make(map[string][]signUpRecord) //Main char name to the records added by this run
+	mapOfReconciledEvents := make(map[string]string)      //Message ID of the raid-helper event to the code of the log it was compared with
+	for _, raid := range raids {
+		if !IsMainRaid(raid.RaidNames) || mapOfReconciledLogs[raid.MetaData.Code] {
+			continue
+		}
+		raidStart := time.UnixMilli(raid.RaidStartUnixTime)
+		messageID := ""
+		closest := signUpMatchWindow
+		for ID, trackedRaid := range trackedRaids {
+			if trackedRaid.RaidStartUnixTime == 0 || trackedRaid.ReconciledLogCode != "" {
+				continue
+			}
+			difference := raidStart.Sub(time.Unix(trackedRaid.RaidStartUnixTime, 0)).Abs()
+			if difference <= closest {
+				messageID, closest = ID, difference
+			}
+		}
+		if messageID == "" {
+			WriteInformationLog(fmt.Sprintf("No raid-helper event starts within %s of the raid %s, its sign-ups cannot be compared, during the function ReconcileGuildSignUps()", signUpMatchWindow, raid.RaidTitle), "No sign-ups found", LogField("guildID", guildID), LogField("logCode", raid.MetaData.Code))
+			continue
+		}
+		signUps, err := raidHelperCurrent.GetEventSignUps(messageID)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the sign-ups of the raid-helper event %s, during the function ReconcileGuildSignUps()", messageID), err.Error(), LogField("guildID", guildID))
+			failedRaids = append(failedRaids, raid.RaidTitle)
+			continue
+		}
+		results := ReconcileSignUps(guildID, currentRaiders.Raiders, raid, signUps)
+		for _, result := range results {
+			if result.MainCharName != "" {
+				mapOfSignUpRecords[result.MainCharName] = append(mapOfSignUpRecords[result.MainCharName], result.Record)
+			}
+		}
+		_, err = session.ChannelMessageSendEmbed(guild.Channels.Officer, &discordgo.MessageEmbed{
+			Title:       fmt.Sprintf("Sign-ups vs log: %s", raid.RaidTitle),
+			URL:         fmt.Sprintf("https://fresh.warcraftlogs.com/reports/%s", raid.MetaData.Code),
+			Description: FormatSignUpReconciliation(results),
+			Color:       blueColor,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to post the sign-ups of the raid %s to the officer channel %s, during the function ReconcileGuildSignUps()", raid.RaidTitle, guild.Channels.Officer), err.Error())
+		}
+		trackedRaid := trackedRaids[messageID]
+		trackedRaid.ReconciledLogCode = raid.MetaData.Code
+		trackedRaids[messageID] = trackedRaid
+		mapOfReconciledEvents[messageID] = raid.MetaData.Code
+		mapOfReconciledLogs[raid.MetaData.Code] = true
+	}
+	if len(mapOfReconciledEvents) > 0 {
+		//The profiles and the raid-helper cache are read again, as the slash commands might have changed them while the sign-ups were retrieved
+		err := UpdateRaiderProfiles(guildID, func(profiles *raiderProfiles) error {
+			for mainCharName, records := range mapOfSignUpRecords {
+				raiderIndex := slices.IndexFunc(profiles.Raiders, func(raider raiderProfile) bool {
+					return raider.MainCharName == mainCharName
+				})
+				if raiderIndex == -1 {
+					continue
+				}
+				for _, newRecord := range records {
+					if !slices.ContainsFunc(profiles.Raiders[raiderIndex].SignUpHistory, func(record signUpRecord) bool {
+						return record.LogCode == newRecord.LogCode
+					}) {
+						profiles.Raiders[raiderIndex].SignUpHistory = append(profiles.Raiders[raiderIndex].SignUpHistory, newRecord)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("the sign-up history of the raiders of server %s could not be written: %s", guildID, err.Error())
+		}
+		err = UpdateTrackedRaids(guildID, func(currentTrackedRaids map[string]trackRaid) error {
+			for messageID, logCode := range mapOfReconciledEvents {
+				if trackedRaid, ok := currentTrackedRaids[messageID]; ok {
+					trackedRaid.ReconciledLogCode = logCode
+					currentTrackedRaids[messageID] = trackedRaid
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("the reconciled raid-helper events of server %s could not be written: %s", guildID, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("The sign-ups of %d main raids have been compared with their logs, during the function ReconcileGuildSignUps()", len(mapOfReconciledEvents)), "Sign-ups reconciled", LogField("guildID", guildID))
+	}
+	if len(failedRaids) > 0 {
+		return fmt.Errorf("the sign-ups of the raids %s could not be retrieved", strings.Join(failedRaids, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReconcileSignUps(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	raidStart := time.Date(2025, time.March, 12, 20, 0, 0, 0, GetGuildLocation(guildID))
+	raiders := []raiderProfile{
+		{ID: "100000000000000001", MainCharName: "Frostbolt"},
+		{ID: "100000000000000002", MainCharName: "Healbot", MainSwitch: map[string]bool{"Healbotalt": true}},
+		{ID: "100000000000000003", MainCharName: "Shieldwall"},
+		{ID: "100000000000000004", MainCharName: "Backstab"},
+		{ID: "100000000000000005", MainCharName: "Moonfire"},
+		{ID: "100000000000000006", MainCharName: "Totemic"},
+		{ID: "100000000000000007", MainCharName: "Fireball", MainSwitch: map[string]bool{"Fireballalt": true}},
+		{ID: "100000000000000008", MainCharName: "Smite"},
+	}
+	raid := NewTestRaid(guildID, "raidA", raidStart, "Frostbolt", "Healbotalt", "Backstab", "Moonfire", "Fireballalt", "Pugwarrior", "Pugmage")
+	signUps := []raidHelperSignUp{
+		{Name: "Frostbolt", UserID: "100000000000000001", ClassName: "Mage"},
+		{Name: "Healbot", UserID: "100000000000000002", ClassName: "Priest"},       //Played an alt
+		{Name: "Shieldwall", UserID: "100000000000000003", ClassName: "Tentative"}, //Tentative and never showed
+		{Name: "Backstab", UserID: "100000000000000004", ClassName: "Absence"},     //Signed absent but showed
+		{Name: "Moony", UserID: "100000000000000005", ClassName: "Bench"},          //Matched by the user ID, benched while in the log
+		{Name: "Totemic", UserID: "100000000000000006", ClassName: "Absence"},      //Signed absent and stayed away
+		{Name: "Smite", UserID: "100000000000000008", ClassName: "Late"},           //Late counts as accepted
+		{Name: "Pugwarrior", UserID: "100000000000000009", ClassName: "Warrior"},   //No raider profile
+		{Name: "Pugpriest", UserID: "100000000000000010", ClassName: "Priest"},     //No raider profile and never showed
+		{Name: "Frostbolt", UserID: "100000000000000001", ClassName: "Mage"},       //Twice in the sign-ups
+	}
+
+	results := ReconcileSignUps(guildID, raiders, raid, signUps)
+
+	mapOfResults := make(map[string]signUpResult)
+	for _, result := range results {
+		if _, ok := mapOfResults[result.Name]; ok {
+			t.Errorf("expected a single result for %s", result.Name)
+		}
+		mapOfResults[result.Name] = result
+	}
+	for _, testCase := range []struct {
+		name         string
+		mainCharName string
+		signUp       string
+		outcome      string
+	}{
+		{"Frostbolt", "Frostbolt", signUpAccepted, signUpOutcomeShowed},
+		{"Healbot", "Healbot", signUpAccepted, signUpOutcomeShowed},
+		{"Shieldwall", "Shieldwall", signUpTentative, signUpOutcomeNoShow},
+		{"Backstab", "Backstab", signUpAbsence, signUpOutcomeShowed},
+		{"Moonfire", "Moonfire", signUpBench, signUpOutcomeBenched},
+		{"Totemic", "Totemic", signUpAbsence, signUpOutcomeSignedAbsent},
+		{"Smite", "Smite", signUpAccepted, signUpOutcomeNoShow},
+		{"Pugwarrior", "", signUpAccepted, signUpOutcomeShowed},
+		{"Pugpriest", "", signUpAccepted, signUpOutcomeNoShow},
+		{"Fireball", "Fireball", "", signUpOutcomeUnsigned}, //Played an alt without a sign-up
+		{"Pugmage", "", "", signUpOutcomeUnsigned},
+	} {
+		result, ok := mapOfResults[testCase.name]
+		if !ok {
+			t.Errorf("expected a result for %s", testCase.name)
+			continue
+		}
+		if result.MainCharName != testCase.mainCharName || result.Record.SignUp != testCase.signUp || result.Record.Outcome != testCase.outcome {
+			t.Errorf("expected %s to be %q/%q with the main char %q, got %q/%q with the main char %q", testCase.name, testCase.signUp, testCase.outcome, testCase.mainCharName, result.Record.SignUp, result.Record.Outcome, result.MainCharName)
+		}
+		if result.Record.LogCode != "raidA" || result.Record.RaidDate != raidStart.Format(timeLayOutShort) {
+			t.Errorf("expected the record of %s to point to the raid raidA on %s, got %+v", testCase.name, raidStart.Format(timeLayOutShort), result.Record)
+		}
+	}
+	if len(results) != 11 {
+		t.Errorf("expected 11 results, the alts and the double sign-up must not be counted again, got %d", len(results))
+	}
+}
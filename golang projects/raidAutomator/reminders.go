@@ -0,0 +1,407 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	reminderRepeatDaily  = "daily"
+	reminderRepeatWeekly = "weekly"
+
+	reminderSchedulerInterval  = 30 * time.Second
+	reminderTimesToNotify      = 5
+	reminderMaxPerUser         = 10
+	reminderMissedAfter        = 2 * time.Minute //Reminders fired later than this are marked as missed while the bot was offline
+	reminderWaitBeforeDeletion = 2 * time.Minute
+)
+
+var (
+	reminderMutex sync.Mutex //Held for every read-modify-write of the reminders, as both the scheduler and the slash commands change them
+
+	patternReminderClock     = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)(?::([0-5]\d))?$`)
+	patternReminderCountdown = regexp.MustCompile(`^(?:\d+h(?:\d+m(?:\d+s)?)?|\d+m(?:\d+s)?|\d+s)$`)
+)
+
+// Fires every reminder that is due, the first run fires the reminders missed while the bot was offline.
+// Should be started as a go-routine once the discord session is open
+func RunReminderScheduler(session discordSession, interval time.Duration) {
+	WriteInformationLog(fmt.Sprintf("The reminder scheduler has started and checks for due reminders every %s, during the function RunReminderScheduler()", interval.String()), "Reminder scheduler")
+	for {
+		for _, dueReminder := range PopDueReminders(time.Now()) {
+			go SendReminder(session, dueReminder)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// Removes the due reminders from storage before they are sent, so a restart never sends a reminder twice.
+// Recurring reminders are moved to their next time in the future instead, a missed recurrence is only sent once
+func PopDueReminders(now time.Time) []reminder {
+	reminderMutex.Lock()
+	defer reminderMutex.Unlock()
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		WriteErrorLog("An error occured while trying to read the reminders from storage, during the function PopDueReminders()", err.Error())
+		return nil
+	}
+	dueReminders := []reminder{}
+	keptReminders := []reminder{}
+	for _, currentReminder := range reminders {
+		if currentReminder.DueTime.After(now) {
+			keptReminders = append(keptReminders, currentReminder)
+			continue
+		}
+		dueReminders = append(dueReminders, currentReminder)
+		if currentReminder.Recurrence != "" {
+			nextReminder := currentReminder
+			for !nextReminder.DueTime.After(now) {
+				nextReminder.DueTime = GetNextReminderRecurrence(nextReminder.DueTime, nextReminder.Recurrence, nextReminder.TimeZone)
+			}
+			keptReminders = append(keptReminders, nextReminder)
+		}
+	}
+	if len(dueReminders) == 0 {
+		return nil
+	}
+	if err := storageCurrent.WriteReminders(keptReminders); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write the reminders to storage, the %d due reminders will be sent on the next run, during the function PopDueReminders()", len(dueReminders)), err.Error())
+		return nil
+	}
+	return dueReminders
+}
+
+// Counted in the time zone the reminder was set in, so a reminder at 19:00 stays at 19:00 when the clocks change
+func GetNextReminderRecurrence(dueTime time.Time, recurrence string, timeZone string) time.Time {
+	if location, err := LoadTimeZone(timeZone); err == nil {
+		dueTime = dueTime.In(location)
+	}
+	if recurrence == reminderRepeatWeekly {
+		return dueTime.AddDate(0, 0, 7)
+	}
+	return dueTime.AddDate(0, 0, 1)
+}
+
+// DM's the user, if the DM cannot be sent a private channel in the server is used and deleted again after 2min
+func SendReminder(session discordSession, currentReminder reminder) {
+	raiderName := ResolvePlayerID(currentReminder.GuildID, currentReminder.UserID, session)
+	timesToNotify := currentReminder.TimesToNotify
+	if timesToNotify <= 0 {
+		timesToNotify = reminderTimesToNotify
+	}
+	content := fmt.Sprintf("**%s** REMINDER!", currentReminder.Title)
+	if late := time.Since(currentReminder.DueTime); late > reminderMissedAfter {
+		content = fmt.Sprintf("%s\n\n(This reminder was due %s, while the bot was offline)", content, FormatDiscordTimestamp(currentReminder.DueTime))
+	}
+	WriteInformationLog(fmt.Sprintf("Sending the reminder %s with ID %s to user %s, during the function SendReminder()", currentReminder.Title, currentReminder.ID, raiderName), "Sending reminder", LogField("reminderID", currentReminder.ID), LogField("userID", currentReminder.UserID), LogField("guildID", currentReminder.GuildID))
+
+	failed := false
+	userChannel, err := session.UserChannelCreate(currentReminder.UserID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured when trying to create a DM channel to the user %s, during the function SendReminder()", raiderName), err.Error())
+		failed = true
+	}
+	if !failed {
+		for x := range timesToNotify {
+			x++
+			_, err := session.ChannelMessageSend(userChannel.ID, fmt.Sprintf("%s\n\nMESSAGE WILL REPEAT %d/%d more times!", content, x, timesToNotify))
+			if err != nil {
+				failed = true
+				break
+			}
+			time.Sleep(time.Second * 3)
+		}
+	}
+	if !failed {
+		return
+	}
+
+	newChannel, err := session.GuildChannelCreateComplex(currentReminder.GuildID, discordgo.GuildChannelCreateData{
+		Name:  fmt.Sprintf("alert-%s", currentReminder.ID),
+		Type:  discordgo.ChannelTypeGuildText,
+		Topic: "This channel will close in 2min",
+		PermissionOverwrites: []*discordgo.PermissionOverwrite{
+			{
+				ID:   currentReminder.GuildID,
+				Type: discordgo.PermissionOverwriteTypeMember,
+				Deny: permissionViewChannel,
+			},
+			{
+				ID:    currentReminder.UserID,
+				Type:  discordgo.PermissionOverwriteTypeMember,
+				Allow: permissionViewChannel,
+			},
+		},
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to create a channel for the reminder %s of user %s, during the function SendReminder()", currentReminder.ID, raiderName), err.Error())
+		return
+	}
+	for x := range timesToNotify {
+		x++
+		_, err = session.ChannelMessageSend(newChannel.ID, fmt.Sprintf("Hi <@%s> THIS IS YOUR REMINDER FOR: %s\n\nMESSAGE WILL REPEAT %d/%d more times!", currentReminder.UserID, content, x, timesToNotify))
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent a message in new channel created to alert user %s about the reminder %s, during the function SendReminder()", raiderName, currentReminder.ID), err.Error())
+			break
+		}
+		time.Sleep(time.Second * 3)
+	}
+	WriteInformationLog(fmt.Sprintf("Waiting 2min before deleting the channel that the bot had to create, because it could not create or sent a direct channel to the user %s, during the function SendReminder()", raiderName), "Sleeping thread")
+	time.Sleep(reminderWaitBeforeDeletion)
+	_, err = session.ChannelDelete(newChannel.ID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to delete channel with name %s and ID %s, during the function SendReminder()", newChannel.Name, newChannel.ID), err.Error())
+	}
+}
+
+// Accepts a countdown like "1h30m", a server time like "19:00" or "19:00:00" and a weekday with a server time like "thursday 19:00".
+// A server time that has already passed today is moved to tomorrow, or to next week when a weekday is given
+func ParseReminderTime(input string, now time.Time) (time.Time, error) {
+	input = strings.ToLower(strings.Join(strings.Fields(input), " "))
+	if patternReminderCountdown.MatchString(input) {
+		duration, err := time.ParseDuration(input)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("the countdown `%s` is not valid", input)
+		}
+		return now.Add(duration), nil
+	}
+
+	weekday, clock, hasWeekday := -1, input, false
+	if parts := strings.SplitN(input, " ", 2); len(parts) == 2 {
+		foundWeekday, ok := ParseWeekday(parts[0])
+		if !ok {
+			return time.Time{}, fmt.Errorf("`%s` is not a weekday", parts[0])
+		}
+		weekday, clock, hasWeekday = int(foundWeekday), parts[1], true
+	}
+	clockParts := patternReminderClock.FindStringSubmatch(clock)
+	if clockParts == nil {
+		return time.Time{}, fmt.Errorf("the time `%s` is not valid", input)
+	}
+	hours, _ := strconv.Atoi(clockParts[1])
+	minutes, _ := strconv.Atoi(clockParts[2])
+	seconds, _ := strconv.Atoi(clockParts[3]) //Empty when the seconds are left out
+	dueTime := time.Date(now.Year(), now.Month(), now.Day(), hours, minutes, seconds, 0, now.Location())
+	if hasWeekday {
+		dueTime = dueTime.AddDate(0, 0, (weekday-int(now.Weekday())+7)%7)
+	}
+	if !dueTime.After(now) {
+		if hasWeekday {
+			dueTime = dueTime.AddDate(0, 0, 7)
+		} else {
+			dueTime = dueTime.AddDate(0, 0, 1)
+		}
+	}
+	return dueTime, nil
+}
+
+// Accepts the full English name or the first 3 letters, e.g. "thursday" or "thu"
+func ParseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(name)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		fullName := strings.ToLower(weekday.String())
+		if name == fullName || name == fullName[:3] {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// Sorted by the time they are due, soonest first
+func GetUserReminders(guildID string, userID string) ([]reminder, error) {
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		return nil, err
+	}
+	userReminders := []reminder{}
+	for _, currentReminder := range reminders {
+		if currentReminder.UserID == userID && currentReminder.GuildID == guildID {
+			userReminders = append(userReminders, currentReminder)
+		}
+	}
+	sort.Slice(userReminders, func(x, y int) bool {
+		return userReminders[x].DueTime.Before(userReminders[y].DueTime)
+	})
+	return userReminders, nil
+}
+
+// Returns the reminder with its new ID
+func AddReminder(newReminder reminder) (reminder, error) {
+	reminderMutex.Lock()
+	defer reminderMutex.Unlock()
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		return reminder{}, err
+	}
+	countOfUserReminders := 0
+	for _, currentReminder := range reminders {
+		if currentReminder.UserID == newReminder.UserID {
+			countOfUserReminders++
+		}
+	}
+	if countOfUserReminders >= reminderMaxPerUser {
+		return reminder{}, fmt.Errorf("you already have %d reminders, please cancel one first", countOfUserReminders)
+	}
+	newReminder.ID = NewReminderID(reminders)
+	return newReminder, storageCurrent.WriteReminders(append(reminders, newReminder))
+}
+
+// Users can only cancel their own reminders
+func CancelReminder(guildID string, userID string, reminderID string) (reminder, error) {
+	reminderMutex.Lock()
+	defer reminderMutex.Unlock()
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		return reminder{}, err
+	}
+	for x, currentReminder := range reminders {
+		if currentReminder.ID == reminderID && currentReminder.UserID == userID && currentReminder.GuildID == guildID {
+			reminders = append(reminders[:x], reminders[x+1:]...)
+			return currentReminder, storageCurrent.WriteReminders(reminders)
+		}
+	}
+	return reminder{}, fmt.Errorf("you have no reminder with ID `%s`, use `/myreminder list` to see your reminders", reminderID)
+}
+
+// Short enough to type in /myreminder cancel, the time in milliseconds is counted up until it is not used by another reminder
+func NewReminderID(reminders []reminder) string {
+	mapOfUsedIDs := make(map[string]bool)
+	for _, currentReminder := range reminders {
+		mapOfUsedIDs[currentReminder.ID] = true
+	}
+	for milliseconds := time.Now().UnixMilli(); ; milliseconds++ {
+		if reminderID := strconv.FormatInt(milliseconds, 36); !mapOfUsedIDs[reminderID] {
+			return reminderID
+		}
+	}
+}
+
+func FormatReminder(currentReminder reminder) string {
+	repeat := ""
+	if currentReminder.Recurrence != "" {
+		repeat = fmt.Sprintf(" (repeats %s)", currentReminder.Recurrence)
+	}
+	return fmt.Sprintf("`%s` **%s** %s%s", currentReminder.ID, currentReminder.Title, FormatDiscordTimestamp(currentReminder.DueTime), repeat)
+}
+
+func HandleMyReminderSet(request *slashCommandRequest) {
+	title := request.StringOption("title")
+	raiderName := ResolvePlayerID(request.Guild.ServerID, request.UserID, request.Session)
+	location := GetUserLocation(request.Guild.ServerID, request.UserID)
+	dueTime, err := ParseReminderTime(request.StringOption("time"), time.Now().In(location))
+	if err != nil {
+		examples := slashCommandAllUsers["myreminder"].Responses["examples"].Response
+		interactionResponse := NewInteractionResponseToSpecificCommand(0, fmt.Sprintf("myreminder|%s. Please see the examples for help.", err.Error()))
+		interactionResponse.Data.Embeds = append(interactionResponse.Data.Embeds, examples.Data.Embeds...)
+		err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the time examples to user %s using slash command /myreminder set, during the function HandleMyReminderSet()", raiderName), err.Error())
+		}
+		return
+	}
+	newReminder := reminder{
+		GuildID:       request.Guild.ServerID,
+		UserID:        request.UserID,
+		Title:         title,
+		TimesToNotify: reminderTimesToNotify,
+		DueTime:       dueTime,
+		Recurrence:    request.StringOption("repeat"),
+		TimeZone:      location.String(),
+	}
+	newReminder, err = AddReminder(newReminder)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to save the reminder %s for user %s, during the function HandleMyReminderSet()", title, raiderName), err.Error())
+		RespondSlashCommandError(request, fmt.Sprintf("myreminder|The reminder could not be saved: %s", err.Error()))
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("User %s has set the reminder %s with ID %s for %s, during the function HandleMyReminderSet()", raiderName, title, newReminder.ID, dueTime.Format(timeLayoutLogs)), "Reminder set", LogField("reminderID", newReminder.ID), LogField("userID", request.UserID), LogField("guildID", request.Guild.ServerID))
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("myreminder|The bot will attempt to contact you %s\nUse `/myreminder cancel` with the ID `%s` to cancel it", FormatDiscordTimestamp(dueTime), newReminder.ID))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to notify the user %s about the reminder set using the slash command /myreminder set, during the function HandleMyReminderSet()", raiderName), err.Error())
+	}
+}
+
+func HandleMyReminderList(request *slashCommandRequest) {
+	userReminders, err := GetUserReminders(request.Guild.ServerID, request.UserID)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the reminders of user %s, during the function HandleMyReminderList()", request.UserID), err.Error())
+		RespondSlashCommandError(request, "myreminder|Your reminders could not be read, please try again later")
+		return
+	}
+	message := "You have no reminders, use `/myreminder set` to create one"
+	if len(userReminders) > 0 {
+		lines := []string{}
+		for _, currentReminder := range userReminders {
+			lines = append(lines, FormatReminder(currentReminder))
+		}
+		message = fmt.Sprintf("%s\n\nUse `/myreminder cancel` with the ID to cancel one", strings.Join(lines, "\n"))
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Your reminders %d/%d|%s", len(userReminders), reminderMaxPerUser, message))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the reminders to user %s using the slash command /myreminder list, during the function HandleMyReminderList()", request.UserID), err.Error())
+	}
+}
+
+func HandleMyReminderCancel(request *slashCommandRequest) {
+	cancelledReminder, err := CancelReminder(request.Guild.ServerID, request.UserID, strings.TrimSpace(request.StringOption("id")))
+	if err != nil {
+		RespondSlashCommandError(request, fmt.Sprintf("myreminder|%s", err.Error()))
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("User %s has cancelled the reminder %s with ID %s, during the function HandleMyReminderCancel()", request.UserID, cancelledReminder.Title, cancelledReminder.ID), "Reminder cancelled", LogField("reminderID", cancelledReminder.ID), LogField("userID", request.UserID), LogField("guildID", request.Guild.ServerID))
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("myreminder|The reminder \"%s\" with the ID `%s` has been cancelled", cancelledReminder.Title, cancelledReminder.ID))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to respond to user %s using the slash command /myreminder cancel, during the function HandleMyReminderCancel()", request.UserID), err.Error())
+	}
+}
+
+func HandleMyReminderTimezone(request *slashCommandRequest) {
+	timeZone := strings.TrimSpace(request.StringOption("zone"))
+	message := ""
+	switch {
+	case timeZone == "":
+		{
+			location := GetUserLocation(request.Guild.ServerID, request.UserID)
+			message = fmt.Sprintf("Your reminders use the time zone %s, where the time is %s now", location.String(), time.Now().In(location).Format(timeLayoutLogs))
+		}
+	case strings.EqualFold(timeZone, userTimeZoneReset):
+		{
+			if err := SetUserTimeZone(request.UserID, ""); err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to remove the time zone of user %s, during the function HandleMyReminderTimezone()", request.UserID), err.Error())
+				RespondSlashCommandError(request, "myreminder|Your time zone could not be saved, please try again later")
+				return
+			}
+			message = fmt.Sprintf("Your reminders use the time zone of the server again, which is %s", GetGuildLocation(request.Guild.ServerID).String())
+		}
+	default:
+		{
+			location, err := LoadTimeZone(timeZone)
+			if err != nil {
+				RespondSlashCommandError(request, fmt.Sprintf("myreminder|%s", err.Error()))
+				return
+			}
+			if err := SetUserTimeZone(request.UserID, location.String()); err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to save the time zone %s of user %s, during the function HandleMyReminderTimezone()", location.String(), request.UserID), err.Error())
+				RespondSlashCommandError(request, "myreminder|Your time zone could not be saved, please try again later")
+				return
+			}
+			WriteInformationLog(fmt.Sprintf("User %s has set the time zone %s for reminders, during the function HandleMyReminderTimezone()", request.UserID, location.String()), "Time zone set", LogField("userID", request.UserID), LogField("timeZone", location.String()))
+			message = fmt.Sprintf("New reminders use the time zone %s, where the time is %s now", location.String(), time.Now().In(location).Format(timeLayoutLogs))
+		}
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("myreminder|%s", message))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to respond to user %s using the slash command /myreminder timezone, during the function HandleMyReminderTimezone()", request.UserID), err.Error())
+	}
+}
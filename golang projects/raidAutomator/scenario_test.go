@@ -0,0 +1,254 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	scenarioBotUserID     = "900000000000000001"
+	scenarioOfficerUserID = "900000000000000002"
+	scenarioRaiderUserID  = "900000000000000003"
+	scenarioPuggieUserID  = "900000000000000004"
+	scenarioNewUserID     = "900000000000000005"
+)
+
+// A fake server shaped like the default guild config, with the bot channel, an officer, a raider and a puggie
+func SetUpFakeGuild(t *testing.T) (*fakeDiscordSession, guildConfig) {
+	t.Helper()
+	SetUpTestWorkDirectory(t)
+	guild := GetGuildConfig("")
+	session := NewFakeDiscordSession(scenarioBotUserID)
+	session.AddChannel(&discordgo.Channel{ID: guild.Channels.Bot, GuildID: guild.ServerID, Name: "bot-chat", Type: discordgo.ChannelTypeGuildText})
+	session.AddChannel(&discordgo.Channel{ID: guild.Channels.General, GuildID: guild.ServerID, Name: "general", Type: discordgo.ChannelTypeGuildText})
+	session.AddGuildMember(guild.ServerID, &discordgo.Member{User: &discordgo.User{ID: scenarioOfficerUserID, Username: "officer"}, Nick: "Officer", Roles: []string{guild.Roles.Officer, guild.Roles.Raider}})
+	session.AddGuildMember(guild.ServerID, &discordgo.Member{User: &discordgo.User{ID: scenarioRaiderUserID, Username: "raider"}, Nick: "Frostbolt", Roles: []string{guild.Roles.Raider}})
+	session.AddGuildMember(guild.ServerID, &discordgo.Member{User: &discordgo.User{ID: scenarioPuggieUserID, Username: "puggie"}, Nick: "Puggie", Roles: []string{guild.Roles.Puggie}})
+	return session, guild
+}
+
+// The title and description of the embeds, or the content when the message has no embeds
+func GetFakeMessageText(message *discordgo.Message) string {
+	if message == nil {
+		return ""
+	}
+	texts := []string{message.Content}
+	for _, embed := range message.Embeds {
+		texts = append(texts, embed.Title, embed.Description)
+	}
+	return strings.Join(texts, "\n")
+}
+
+func TestOnboardingNewMember(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	NewPlayerJoin(session)
+
+	session.InjectGuildMemberAdd(guild.ServerID, &discordgo.Member{User: &discordgo.User{ID: scenarioNewUserID, Username: "newcomer"}})
+
+	roleChanges := session.RoleChanges()
+	if len(roleChanges) != 1 || roleChanges[0].UserID != scenarioNewUserID || roleChanges[0].RoleID != guild.Roles.Temp || !roleChanges[0].Added {
+		t.Fatalf("expected the temp role to be given to the new member, got %+v", roleChanges)
+	}
+	createdChannels := session.CreatedChannels()
+	if len(createdChannels) != 1 {
+		t.Fatalf("expected 1 onboarding channel, got %d", len(createdChannels))
+	}
+	onboardingChannel := createdChannels[0]
+	if onboardingChannel.Name != "automatic-"+scenarioNewUserID || onboardingChannel.ParentID != guild.Categories.Bot {
+		t.Errorf("expected the channel automatic-%s in the bot category, got %s in %s", scenarioNewUserID, onboardingChannel.Name, onboardingChannel.ParentID)
+	}
+
+	visitSent := false
+	for _, message := range session.ChannelHistory(guild.Channels.Bot) {
+		if strings.Contains(message.Content, " VISIT ") && strings.Contains(message.Content, "<#"+onboardingChannel.ID+">") {
+			visitSent = true
+		}
+	}
+	if !visitSent {
+		t.Error("expected the bot channel to point the new member at the onboarding channel")
+	}
+	//The VISIT message starts the questionnaire in the onboarding channel
+	onboardingHistory := session.ChannelHistory(onboardingChannel.ID)
+	if len(onboardingHistory) == 0 || onboardingHistory[len(onboardingHistory)-1].Content != "What is your class? (Use reactions)" {
+		t.Errorf("expected the onboarding channel to end with the class question, got %d messages", len(onboardingHistory))
+	}
+
+	profiles, err := storageCurrent.ReadMemberProfiles(belowRaidersCachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, profile := range profiles {
+		if profile.ID == scenarioNewUserID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the new member to be stored in the cache of members below raider")
+	}
+}
+
+func TestSlashCommandRaiderSaysHi(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioRaiderUserID, "hi")
+
+	response := session.InteractionMessage(interaction.ID)
+	if response == nil {
+		t.Fatal("expected the bot to respond to /hi")
+	}
+	if response.Flags&discordgo.MessageFlagsEphemeral == 0 {
+		t.Error("expected the response to /hi to be ephemeral")
+	}
+	if !strings.Contains(response.Content, "Hi Frostbolt you damn pumper") {
+		t.Errorf("expected /hi to greet the raider by nickname, got %q", response.Content)
+	}
+}
+
+func TestSlashCommandPuggieMissingRank(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioPuggieUserID, "myattendance")
+
+	response := GetFakeMessageText(session.InteractionMessage(interaction.ID))
+	if !strings.Contains(response, "You need the raider rank") {
+		t.Errorf("expected /myattendance to be refused for a puggie, got %q", response)
+	}
+}
+
+func TestSlashCommandOfficerOnly(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+
+	raiderInteraction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioRaiderUserID, "schedules",
+		&discordgo.ApplicationCommandInteractionDataOption{Name: "list", Type: discordgo.ApplicationCommandOptionSubCommand})
+	if response := GetFakeMessageText(session.InteractionMessage(raiderInteraction.ID)); !strings.Contains(response, "You need the officer rank") {
+		t.Errorf("expected /schedules list to be refused for a raider, got %q", response)
+	}
+
+	officerInteraction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioOfficerUserID, "schedules",
+		&discordgo.ApplicationCommandInteractionDataOption{Name: "list", Type: discordgo.ApplicationCommandOptionSubCommand})
+	response := GetFakeMessageText(session.InteractionMessage(officerInteraction.ID))
+	if response == "" || strings.Contains(response, "You need the officer rank") {
+		t.Errorf("expected /schedules list to be answered for an officer, got %q", response)
+	}
+}
+
+func TestSlashCommandUnknown(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioRaiderUserID, "doesnotexist")
+
+	if response := GetFakeMessageText(session.InteractionMessage(interaction.ID)); !strings.Contains(response, "not known by the bot anymore") {
+		t.Errorf("expected an unknown command to be answered with an error, got %q", response)
+	}
+}
+
+func TestSlashCommandRequiredOption(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioOfficerUserID, "overrideattendance")
+
+	if response := GetFakeMessageText(session.InteractionMessage(interaction.ID)); !strings.Contains(response, "is required") {
+		t.Errorf("expected /overrideattendance without options to be refused, got %q", response)
+	}
+}
+
+// A button limited to officers must stay on its message when someone without the rank presses it
+func TestButtonMissingRankKeepsMessage(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+	buttonMessage := &discordgo.Message{ID: "900000000000000100", ChannelID: guild.Channels.Bot, GuildID: guild.ServerID, Author: &discordgo.User{ID: scenarioBotUserID}}
+	session.InjectMessageCreate(buttonMessage)
+
+	session.InjectInteractionCreate(&discordgo.Interaction{
+		Type:      discordgo.InteractionMessageComponent,
+		GuildID:   guild.ServerID,
+		ChannelID: guild.Channels.Bot,
+		Member:    &discordgo.Member{User: &discordgo.User{ID: scenarioRaiderUserID}},
+		Message:   buttonMessage,
+		Data:      discordgo.MessageComponentInteractionData{CustomID: "benchreason/" + buttonMessage.ID, ComponentType: discordgo.ButtonComponent},
+	})
+
+	if history := session.ChannelHistory(guild.Channels.Bot); len(history) != 1 || history[0].ID != buttonMessage.ID {
+		t.Error("expected the button message to be kept when the raider is missing the officer rank")
+	}
+}
+
+// The response is deferred before the profiles are changed, and the officers get the absence after the raider has the answer
+func TestSlashCommandAbsentRange(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	session.AddChannel(&discordgo.Channel{ID: guild.Channels.Officer, GuildID: guild.ServerID, Name: "officers", Type: discordgo.ChannelTypeGuildText})
+	UseSlashCommand(session, NewSlashCommandRegistry())
+	if err := storageCurrent.WriteRaiderProfiles(guild.ServerID, raiderProfiles{Raiders: []raiderProfile{{ID: scenarioRaiderUserID, MainCharName: "Frostbolt"}}}); err != nil {
+		t.Fatal(err)
+	}
+	firstDay := time.Now().In(GetGuildLocation(guild.ServerID)).AddDate(0, 0, 1).Format(timeLayOutShort)
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioRaiderUserID, "absent",
+		&discordgo.ApplicationCommandInteractionDataOption{Name: "range", Type: discordgo.ApplicationCommandOptionSubCommand, Options: []*discordgo.ApplicationCommandInteractionDataOption{
+			{Name: "from", Type: discordgo.ApplicationCommandOptionString, Value: firstDay},
+			{Name: "to", Type: discordgo.ApplicationCommandOptionString, Value: firstDay},
+			{Name: "reason", Type: discordgo.ApplicationCommandOptionString, Value: "Holiday"},
+		}})
+
+	if response := GetFakeMessageText(session.InteractionMessage(interaction.ID)); !strings.Contains(response, "Your command has completed: absent range") {
+		t.Errorf("expected the deferred response to be edited with the result, got %q", response)
+	}
+	if history := session.ChannelHistory(guild.Channels.Officer); len(history) != 1 || !strings.Contains(GetFakeMessageText(history[0]), "Frostbolt will be absent") {
+		t.Error("expected the absence to be posted to the officer channel")
+	}
+	profiles, err := storageCurrent.ReadRaiderProfiles(guild.ServerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles.Raiders) != 1 || len(profiles.Raiders[0].Absences) != 1 || profiles.Raiders[0].Absences[0].RaidDate != firstDay {
+		t.Errorf("expected the absence on %s to be stored on the profile, got %+v", firstDay, profiles.Raiders)
+	}
+}
+
+func TestSlashCommandRecentLogs(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	UseSlashCommand(session, NewSlashCommandRegistry())
+	WriteInformationLog("The scenario has started", "Scenario")
+	WriteErrorLog("The scenario has failed | on purpose", "Scenario error")
+
+	interaction := session.InjectSlashCommand(guild.ServerID, guild.Channels.General, scenarioOfficerUserID, "recentlogs",
+		&discordgo.ApplicationCommandInteractionDataOption{Name: "level", Type: discordgo.ApplicationCommandOptionString, Value: "error"})
+
+	response := GetFakeMessageText(session.InteractionMessage(interaction.ID))
+	if !strings.Contains(response, "The scenario has failed / on purpose - Error: Scenario error") {
+		t.Errorf("expected the error to be in the recent logs, got %q", response)
+	}
+	if strings.Contains(response, "The scenario has started") {
+		t.Errorf("expected the information entry to be left out at level error, got %q", response)
+	}
+}
+
+func TestRaidHelperEventDeleted(t *testing.T) {
+	session, guild := SetUpFakeGuild(t)
+	AutoTrackRaidEvents(session)
+	err := UpdateTrackedRaids(guild.ServerID, func(trackedRaids map[string]trackRaid) error {
+		trackedRaids["700000000000000001"] = trackRaid{DiscordMessageID: "700000000000000001", RaidDiscordTitle: "Molten Core"}
+		trackedRaids["700000000000000002"] = trackRaid{DiscordMessageID: "700000000000000002", RaidDiscordTitle: "Blackwing Lair"}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session.Dispatch(&discordgo.MessageDelete{Message: &discordgo.Message{ID: "700000000000000001", ChannelID: guild.Channels.SignUp, GuildID: guild.ServerID}})
+	session.Dispatch(&discordgo.MessageDelete{Message: &discordgo.Message{ID: "700000000000000003", ChannelID: guild.Channels.General, GuildID: guild.ServerID}})
+
+	trackedRaids := ReadWriteRaidHelperCache(guild.ServerID)
+	if _, ok := trackedRaids["700000000000000001"]; ok || len(trackedRaids) != 1 {
+		t.Errorf("expected only the deleted raid-helper event to be removed from the raid-helper cache, got %+v", trackedRaids)
+	}
+}
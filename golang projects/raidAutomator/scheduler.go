@@ -0,0 +1,590 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/robfig/cron/v3"
+)
+
+type scheduledJob struct {
+	Name        string
+	Description string
+	Cron        string //Standard cron expression with 5 fields in the time zone of the primary guild, e.g. "0 12 * * 5"
+	CatchUp     bool   //Runs the job once during start-up when a run was missed while the bot was offline
+	Paused      bool   //Paused in the custom schedules, the job can only be resumed by changing the file
+	Run         func(session discordSession) error
+	schedule    cron.Schedule
+	running     atomic.Bool
+}
+
+type jobSchedule struct {
+	Name   string `json:"name"`
+	Cron   string `json:"cron"`
+	Paused bool   `json:"paused"`
+
+	HourMinute string `json:"hour_minute,omitempty"` //Older versions of the bot only knew a weekday and a time, e.g. "12:00"
+	WeekdayInt int    `json:"weekday_int,omitempty"`
+}
+
+type jobScheduler struct {
+	session discordSession
+	cron    *cron.Cron
+	jobs    []*scheduledJob
+	mutex   sync.Mutex //Held for every read-modify-write of the job states
+}
+
+const (
+	jobUpdateWeeklyAttendance = "updateweeklyattendance"
+	jobSyncDiscordRoles       = "syncdiscordroles"
+	jobRefreshRaidCache       = "refreshraidcache"
+	jobSignUpNags             = "signupnags"
+	jobRotateSecondaryLogger  = "rotatesecondarylogger"
+	jobReconcileSignUps       = "reconcilesignups"
+	jobCreateRaidEvents       = "createraidevents"
+
+	jobTriggerSchedule = "schedule"
+	jobTriggerManual   = "manual"
+	jobTriggerMissed   = "missed"
+)
+
+var (
+	schedulerCurrent *jobScheduler //Set by the function StartJobScheduler() during start-up
+)
+
+func NewJobScheduler(session discordSession) *jobScheduler {
+	scheduler := &jobScheduler{
+		session: session,
+		cron:    cron.New(cron.WithLocation(GetGuildLocation(""))),
+	}
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobUpdateWeeklyAttendance,
+		Description: "Adds the attendance of the last week to every raider",
+		Cron:        "0 12 * * 5",
+		CatchUp:     true,
+		Run:         RunUpdateWeeklyAttendanceJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobSyncDiscordRoles,
+		Description: "Syncs all raiders into their category roles",
+		Cron:        "0 6 * * *",
+		CatchUp:     true,
+		Run:         RunSyncDiscordRolesJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobRefreshRaidCache,
+		Description: "Synchronizes the raid cache with warcraftlogs",
+		Cron:        "0 4 * * *",
+		CatchUp:     true,
+		Run:         RunRefreshRaidCacheJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobSignUpNags,
+		Description: "DMs the raiders missing from the sign-ups of the upcoming raids, see signUpNags in the guild config",
+		Cron:        "0 * * * *", //Hourly, so every lead time of the guild config is met within the hour
+		Run:         RunSignUpNagsJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobRotateSecondaryLogger,
+		Description: "Picks the logger of the next secondary raids, e.g. Onyxia and Zul'gurub",
+		Cron:        "0 10 * * 3",
+		CatchUp:     true,
+		Run:         RunRotateSecondaryLoggerJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobReconcileSignUps,
+		Description: "Compares the raid-helper sign-ups of the main raids with who showed up in the logs",
+		Cron:        "30 4 * * *", //After the raid cache is refreshed
+		CatchUp:     true,
+		Run:         RunReconcileSignUpsJob,
+	})
+	scheduler.RegisterJob(&scheduledJob{
+		Name:        jobCreateRaidEvents,
+		Description: "Creates the next raid-helper event of every event template with autoCreate",
+		Cron:        "0 9 * * *",
+		CatchUp:     true,
+		Run:         RunCreateRaidEventsJob,
+	})
+	return scheduler
+}
+
+// Jobs must be registered before the function Start() is called
+func (scheduler *jobScheduler) RegisterJob(job *scheduledJob) {
+	if scheduler.GetJob(job.Name) != nil {
+		WriteErrorLog(fmt.Sprintf("The scheduled job %s is already registered, during the function RegisterJob()", job.Name), "Duplicate scheduled job")
+		return
+	}
+	scheduler.jobs = append(scheduler.jobs, job)
+}
+
+func (scheduler *jobScheduler) GetJob(name string) *scheduledJob {
+	for _, job := range scheduler.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// Applies the custom schedules, detects the runs missed while the bot was offline and starts the cron loop
+func StartJobScheduler(session discordSession) {
+	scheduler := NewJobScheduler(session)
+	scheduler.ImportJobSchedules()
+	if err := scheduler.Start(); err != nil {
+		WriteErrorLog("An error occured while trying to start the job scheduler, no scheduled job will run, during the function StartJobScheduler()", err.Error())
+		return
+	}
+	schedulerCurrent = scheduler
+}
+
+// Writes the default schedule of every job to the file when it does not exist yet
+func (scheduler *jobScheduler) ImportJobSchedules() {
+	customSchedules := []jobSchedule{}
+	if customSchedulesBytes := CheckForExistingCache(customSchedulePath); len(customSchedulesBytes) == 0 {
+		for _, job := range scheduler.jobs {
+			customSchedules = append(customSchedules, jobSchedule{
+				Name:   job.Name,
+				Cron:   job.Cron,
+				Paused: job.Paused,
+			})
+		}
+		marshal, err := json.MarshalIndent(customSchedules, "", " ")
+		if err != nil {
+			WriteErrorLog("An error occured while trying to marshal the default custom schedules, during the function ImportJobSchedules()", err.Error())
+			return
+		}
+		if err := os.WriteFile(customSchedulePath, marshal, 0644); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to write the default custom schedules to path %s, during the function ImportJobSchedules()", customSchedulePath), err.Error())
+			return
+		}
+		WriteInformationLog(fmt.Sprintf("No custom schedules found on disc - The default schedules has been written to path %s, during the function ImportJobSchedules()", customSchedulePath), "No config found")
+		return
+	} else if err := json.Unmarshal(customSchedulesBytes, &customSchedules); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to unmarshal the custom schedules on path %s, the default schedules are used instead, during the function ImportJobSchedules()", customSchedulePath), err.Error())
+		return
+	}
+	for _, customSchedule := range customSchedules {
+		if customSchedule.Name == "empty" { //Placeholder written by older versions of the bot
+			continue
+		}
+		job := scheduler.GetJob(customSchedule.Name)
+		if job == nil {
+			WriteWarningLog(fmt.Sprintf("The custom schedule %s does not match any scheduled job and is ignored, during the function ImportJobSchedules()", customSchedule.Name), "Unknown scheduled job")
+			continue
+		}
+		expression := customSchedule.Cron
+		if expression == "" && customSchedule.HourMinute != "" {
+			converted, err := ConvertWeekdaySchedule(customSchedule.HourMinute, customSchedule.WeekdayInt)
+			if err != nil {
+				WriteErrorLog(fmt.Sprintf("The custom schedule %s could not be converted into a cron expression, the default schedule %s is used instead, during the function ImportJobSchedules()", job.Name, job.Cron), err.Error())
+				continue
+			}
+			expression = converted
+		}
+		if expression != "" {
+			if _, err := ParseJobCron(expression); err != nil {
+				WriteErrorLog(fmt.Sprintf("The cron expression %s of the custom schedule %s is not valid, the default schedule %s is used instead, during the function ImportJobSchedules()", expression, job.Name, job.Cron), err.Error())
+			} else {
+				job.Cron = expression
+			}
+		}
+		job.Paused = customSchedule.Paused
+	}
+}
+
+// Converts the weekday and time of the older custom schedules, e.g. "12:00" on weekday 5 becomes "0 12 * * 5"
+func ConvertWeekdaySchedule(hourMinute string, weekday int) (string, error) {
+	parts := strings.Split(hourMinute, ":")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("the time %s must be in the format HH:MM, e.g. 19:30", hourMinute)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return "", fmt.Errorf("the hour in the time %s is not valid", hourMinute)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return "", fmt.Errorf("the minute in the time %s is not valid", hourMinute)
+	}
+	if weekday < 0 || weekday > 6 {
+		return "", fmt.Errorf("the weekday %d must be between 0 (sunday) and 6 (saturday)", weekday)
+	}
+	return fmt.Sprintf("%d %d * * %d", minute, hour, weekday), nil
+}
+
+// The expression is read in the time zone of the primary guild unless it starts with its own CRON_TZ=
+func ParseJobCron(expression string) (cron.Schedule, error) {
+	if !strings.HasPrefix(expression, "CRON_TZ=") && !strings.HasPrefix(expression, "TZ=") {
+		expression = fmt.Sprintf("CRON_TZ=%s %s", GetGuildLocation("").String(), expression)
+	}
+	return cron.ParseStandard(expression)
+}
+
+func (scheduler *jobScheduler) Start() error {
+	states, err := storageCurrent.ReadJobStates()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	missedJobs := []*scheduledJob{}
+	for _, job := range scheduler.jobs {
+		job.schedule, err = ParseJobCron(job.Cron)
+		if err != nil {
+			return fmt.Errorf("the cron expression %s of the scheduled job %s is not valid: %s", job.Cron, job.Name, err.Error())
+		}
+		state := states[job.Name]
+		if !state.NextRun.IsZero() && state.NextRun.Before(now) {
+			countOfMissedRuns := CountMissedRuns(job.schedule, state.NextRun, now)
+			WriteWarningLog(fmt.Sprintf("The scheduled job %s missed %d run(s) since %s while the bot was offline, during the function Start()", job.Name, countOfMissedRuns, state.NextRun.In(GetGuildLocation("")).Format(timeLayoutLogs)), "Missed scheduled job", LogField("job", job.Name), LogField("missedRuns", countOfMissedRuns))
+			if job.CatchUp && !job.Paused && !state.Paused {
+				missedJobs = append(missedJobs, job)
+			}
+		}
+		state.NextRun = job.schedule.Next(now)
+		states[job.Name] = state
+		currentJob := job
+		scheduler.cron.Schedule(job.schedule, cron.FuncJob(func() {
+			scheduler.RunScheduledJob(currentJob)
+		}))
+	}
+	if err := scheduler.writeJobStates(states); err != nil {
+		return err
+	}
+	scheduler.cron.Start()
+	for _, job := range missedJobs {
+		if err := scheduler.TriggerJob(job.Name, jobTriggerMissed); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to catch up on the missed run of the scheduled job %s, during the function Start()", job.Name), err.Error())
+		}
+	}
+	WriteInformationLog(fmt.Sprintf("The job scheduler has started with %d scheduled jobs, during the function Start()", len(scheduler.jobs)), "Job scheduler")
+	return nil
+}
+
+// Counts at most 100 missed runs, a job that runs every minute would otherwise take long to count
+func CountMissedRuns(schedule cron.Schedule, firstMissedRun time.Time, now time.Time) int {
+	countOfMissedRuns := 0
+	for nextRun := firstMissedRun; nextRun.Before(now) && countOfMissedRuns < 100; nextRun = schedule.Next(nextRun) {
+		countOfMissedRuns++
+	}
+	return countOfMissedRuns
+}
+
+// Called by the cron loop, a paused job only has its next run moved forward
+func (scheduler *jobScheduler) RunScheduledJob(job *scheduledJob) {
+	if scheduler.IsJobPaused(job) {
+		WriteInformationLog(fmt.Sprintf("The scheduled job %s is paused and will not run, during the function RunScheduledJob()", job.Name), "Skipping scheduled job", LogField("job", job.Name))
+		scheduler.updateJobState(job.Name, func(state *jobState) {
+			state.NextRun = job.schedule.Next(time.Now())
+		})
+		return
+	}
+	if !job.running.CompareAndSwap(false, true) {
+		WriteWarningLog(fmt.Sprintf("The scheduled job %s is still running from an earlier run and will not run again, during the function RunScheduledJob()", job.Name), "Skipping scheduled job", LogField("job", job.Name))
+		return
+	}
+	scheduler.runJob(job, jobTriggerSchedule)
+}
+
+// Runs the job in the background, also when it is paused. Returns an error when the job is unknown or already running
+func (scheduler *jobScheduler) TriggerJob(name string, trigger string) error {
+	job := scheduler.GetJob(name)
+	if job == nil {
+		return fmt.Errorf("no scheduled job is named %s", name)
+	}
+	if !job.running.CompareAndSwap(false, true) {
+		return fmt.Errorf("the scheduled job %s is already running", name)
+	}
+	go scheduler.runJob(job, trigger)
+	return nil
+}
+
+// The running flag of the job must be set by the caller
+func (scheduler *jobScheduler) runJob(job *scheduledJob, trigger string) {
+	startTime := time.Now()
+	var err error
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			WriteErrorLog(fmt.Sprintf("The scheduled job %s stopped unexpectedly, during the function runJob()", job.Name), fmt.Sprintf("%v\n%s", recovered, debug.Stack()), LogField("job", job.Name))
+			err = fmt.Errorf("the job stopped unexpectedly: %v", recovered)
+		}
+		scheduler.updateJobState(job.Name, func(state *jobState) {
+			state.LastRun = startTime
+			state.NextRun = job.schedule.Next(time.Now())
+			state.LastTrigger = trigger
+			state.LastDuration = time.Since(startTime).Round(time.Second).String()
+			state.LastError = ""
+			if err != nil {
+				state.LastError = err.Error()
+			}
+		})
+		job.running.Store(false)
+	}()
+	WriteInformationLog(fmt.Sprintf("Executing the scheduled job %s, during the function runJob()", job.Name), "Scheduled task", LogField("job", job.Name), LogField("trigger", trigger))
+	err = job.Run(scheduler.session)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while running the scheduled job %s, during the function runJob()", job.Name), err.Error(), LogField("job", job.Name))
+	} else {
+		WriteInformationLog(fmt.Sprintf("The scheduled job %s finished in %s, during the function runJob()", job.Name, time.Since(startTime).Round(time.Second)), "Scheduled task", LogField("job", job.Name))
+	}
+}
+
+func (scheduler *jobScheduler) IsJobPaused(job *scheduledJob) bool {
+	if job.Paused {
+		return true
+	}
+	states, err := storageCurrent.ReadJobStates()
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the state of the scheduled job %s, during the function IsJobPaused()", job.Name), err.Error())
+		return false
+	}
+	return states[job.Name].Paused
+}
+
+func (scheduler *jobScheduler) PauseJob(name string, paused bool) error {
+	job := scheduler.GetJob(name)
+	if job == nil {
+		return fmt.Errorf("no scheduled job is named %s", name)
+	}
+	if !paused && job.Paused {
+		return fmt.Errorf("the scheduled job %s is paused in the custom schedules on path %s and can only be resumed there", name, customSchedulePath)
+	}
+	return scheduler.updateJobState(name, func(state *jobState) {
+		state.Paused = paused
+	})
+}
+
+func (scheduler *jobScheduler) GetJobStates() (map[string]jobState, error) {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	return storageCurrent.ReadJobStates()
+}
+
+func (scheduler *jobScheduler) updateJobState(name string, update func(state *jobState)) error {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	states, err := storageCurrent.ReadJobStates()
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the state of the scheduled job %s, during the function updateJobState()", name), err.Error())
+		return err
+	}
+	state := states[name]
+	update(&state)
+	states[name] = state
+	if err := storageCurrent.WriteJobStates(states); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to write the state of the scheduled job %s, during the function updateJobState()", name), err.Error())
+		return err
+	}
+	return nil
+}
+
+func (scheduler *jobScheduler) writeJobStates(states map[string]jobState) error {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	return storageCurrent.WriteJobStates(states)
+}
+
+func RunUpdateWeeklyAttendanceJob(session discordSession) error {
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		returnString := AddWeeklyRaiderAttendance(guildID)
+		WriteInformationLog(returnString, "Updating weekly attendance", LogField("guildID", guildID))
+		if strings.Contains(returnString, "error") || strings.Contains(returnString, "cannot") {
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the weekly attendance could not be updated for the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+func RunSyncDiscordRolesJob(session discordSession) error {
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		if returnAnswer := ManageMergedGroups(guildID, session, "full"); len(returnAnswer) == 1 { //A single answer is the error
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to sync the roles of server %s, during the function RunSyncDiscordRolesJob()", guildID), returnAnswer[0])
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the roles could not be synced for the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+func RunRefreshRaidCacheJob(session discordSession) error {
+	emptyGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		if raids := GetAllWarcraftLogsRaidData(guildID, false, false, ""); len(raids) == 0 {
+			emptyGuilds = append(emptyGuilds, guildID)
+		}
+	}
+	if len(emptyGuilds) > 0 {
+		return fmt.Errorf("no raids were found on warcraftlogs for the servers %s", strings.Join(emptyGuilds, ", "))
+	}
+	return nil
+}
+
+func RunSignUpNagsJob(session discordSession) error {
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		if err := NagMissingSignUps(guildID, session); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to nag the raiders missing from the sign-ups of server %s, during the function RunSignUpNagsJob()", guildID), err.Error())
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the sign-up nags could not be sent for the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+func RunRotateSecondaryLoggerJob(session discordSession) error {
+	for _, guildID := range GetGuildIDs() {
+		DetermineNextSecondaryRaid(guildID, session)
+	}
+	return nil
+}
+
+func RunReconcileSignUpsJob(session discordSession) error {
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		if err := ReconcileGuildSignUps(guildID, session); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to reconcile the sign-ups of server %s, during the function RunReconcileSignUpsJob()", guildID), err.Error())
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the sign-ups could not be reconciled for the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+func RunCreateRaidEventsJob(session discordSession) error {
+	failedGuilds := []string{}
+	for _, guildID := range GetGuildIDs() {
+		if err := CreateScheduledRaidEvents(guildID); err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to create the raid-helper events of server %s, during the function RunCreateRaidEventsJob()", guildID), err.Error())
+			failedGuilds = append(failedGuilds, guildID)
+		}
+	}
+	if len(failedGuilds) > 0 {
+		return fmt.Errorf("the raid-helper events could not be created for the servers %s", strings.Join(failedGuilds, ", "))
+	}
+	return nil
+}
+
+// Every job, its schedule and state sorted by the next run
+func FormatJobSchedules(scheduler *jobScheduler) (string, error) {
+	states, err := scheduler.GetJobStates()
+	if err != nil {
+		return "", err
+	}
+	jobs := append([]*scheduledJob{}, scheduler.jobs...)
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return states[jobs[i].Name].NextRun.Before(states[jobs[j].Name].NextRun)
+	})
+	lines := []string{}
+	for _, job := range jobs {
+		state := states[job.Name]
+		status := "active"
+		switch {
+		case job.running.Load():
+			{
+				status = "running"
+			}
+		case job.Paused || state.Paused:
+			{
+				status = "paused"
+			}
+		}
+		lastRun := "never"
+		if !state.LastRun.IsZero() {
+			lastRun = fmt.Sprintf("%s (%s, took %s)", FormatDiscordTimestamp(state.LastRun), state.LastTrigger, state.LastDuration)
+			if state.LastError != "" {
+				lastRun += fmt.Sprintf("\nLast error: %s", state.LastError)
+			}
+		}
+		nextRun := "unknown"
+		if !state.NextRun.IsZero() {
+			nextRun = FormatDiscordTimestamp(state.NextRun)
+		}
+		lines = append(lines, fmt.Sprintf("**%s** `%s` - %s\n%s\nLast run: %s\nNext run: %s", job.Name, job.Cron, status, job.Description, lastRun, nextRun))
+	}
+	return strings.Join(lines, "\n\n"), nil
+}
+
+func HandleSchedulesList(request *slashCommandRequest) {
+	if schedulerCurrent == nil {
+		RespondSlashCommandError(request, "schedules|The job scheduler is not running, see the error log")
+		return
+	}
+	message, err := FormatJobSchedules(schedulerCurrent)
+	if err != nil {
+		WriteErrorLog("An error occured while trying to read the states of the scheduled jobs, during the function HandleSchedulesList()", err.Error())
+		RespondSlashCommandError(request, "schedules|The scheduled jobs could not be read, please try again later")
+		return
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(3, fmt.Sprintf("Scheduled jobs (%s)|%s", GetGuildLocation("").String(), message))
+	err = request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent the scheduled jobs to user %s using the slash command /schedules list, during the function HandleSchedulesList()", request.UserID), err.Error())
+	}
+}
+
+func HandleSchedulesPause(request *slashCommandRequest) {
+	HandleSchedulesChange(request, "paused", "", func(name string) error {
+		return schedulerCurrent.PauseJob(name, true)
+	})
+}
+
+func HandleSchedulesResume(request *slashCommandRequest) {
+	HandleSchedulesChange(request, "resumed", "", func(name string) error {
+		return schedulerCurrent.PauseJob(name, false)
+	})
+}
+
+func HandleSchedulesTrigger(request *slashCommandRequest) {
+	HandleSchedulesChange(request, "started", " in the background, see `/schedules list` for the result", func(name string) error {
+		return schedulerCurrent.TriggerJob(name, jobTriggerManual)
+	})
+}
+
+func HandleSchedulesChange(request *slashCommandRequest, action string, note string, change func(name string) error) {
+	if schedulerCurrent == nil {
+		RespondSlashCommandError(request, "schedules|The job scheduler is not running, see the error log")
+		return
+	}
+	name := request.StringOption("job")
+	if err := change(name); err != nil {
+		RespondSlashCommandError(request, fmt.Sprintf("schedules|%s", err.Error()))
+		return
+	}
+	WriteInformationLog(fmt.Sprintf("User %s has %s the scheduled job %s, during the function HandleSchedulesChange()", request.UserID, action, name), "Scheduled job changed", LogField("job", name), LogField("userID", request.UserID))
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("schedules|The scheduled job %s has been %s%s", name, action, note))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to respond to user %s using the slash command /schedules, during the function HandleSchedulesChange()", request.UserID), err.Error())
+	}
+}
+
+// The choices of the job option of /schedules, the names are fixed as discord only accepts choices known when the commands are synced
+func GetJobChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, name := range []string{jobUpdateWeeklyAttendance, jobSyncDiscordRoles, jobRefreshRaidCache, jobSignUpNags, jobRotateSecondaryLogger, jobReconcileSignUps, jobCreateRaidEvents} {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  name,
+			Value: name,
+		})
+	}
+	return choices
+}
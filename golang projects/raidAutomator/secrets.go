@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+type secretProvider interface {
+	GetSecret(token keyvaultToken) (string, bool, error) //The bool is false when the provider does not know the secret
+	Name() string
+}
+
+type keyvaultSecretProvider struct {
+	client      *azsecrets.Client
+	unavailable error //Set on the first failure to reach the key vault, so the other secrets do not wait for the same timeout
+}
+
+type envSecretProvider struct{}
+
+type fileSecretProvider struct {
+	path    string
+	secrets map[string]string
+}
+
+const (
+	secretProviderKeyvault = "keyvault"
+	secretProviderEnv      = "env"
+	secretProviderFile     = "file"
+
+	secretsFileDefaultPath = baseCachePath + "secrets.json"
+	keyvaultSecretTimeout  = 30 * time.Second
+)
+
+var (
+	requiredSecretNames      = []string{"botToken", "raidHelperToken"} //Always resolved, even when missing from keyvault.json
+	errSecretVersionNotFound = errors.New("the pinned version of the secret was not found in the key vault")
+)
+
+// Resolves every token of the config into mapOfTokens, the first provider that knows a secret wins
+func ResolveSecrets(currentKeyvaultConfig keyvault) error {
+	providers := NewSecretProviders(currentKeyvaultConfig)
+	if len(providers) == 0 {
+		return fmt.Errorf("none of the secret providers %v could be used", currentKeyvaultConfig.Providers)
+	}
+
+	missingSecrets := []string{}
+	for _, token := range currentKeyvaultConfig.Tokens {
+		resolved := false
+		for _, provider := range providers {
+			value, found, err := provider.GetSecret(token)
+			if errors.Is(err, errSecretVersionNotFound) {
+				return fmt.Errorf("the secret %s is pinned to version %s, which the key vault %s does not have - the other providers are not tried, as they have no versions: %s", token.Name, token.VersionID, currentKeyvaultConfig.Name, err.Error())
+			} else if err != nil {
+				WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the secret %s from the %s provider, the next provider will be tried, during the function ResolveSecrets()", token.Name, provider.Name()), err.Error())
+				continue
+			}
+			if !found || value == "" {
+				continue
+			}
+			if token.VersionID != "" && provider.Name() != secretProviderKeyvault {
+				WriteWarningLog(fmt.Sprintf("The secret %s is pinned to version %s but was resolved by the %s provider, which has no versions, during the function ResolveSecrets()", token.Name, token.VersionID, provider.Name()), "Secret version ignored")
+			}
+			mapOfTokens[token.Name] = value
+			resolved = true
+			WriteInformationLog(fmt.Sprintf("The secret %s has been resolved by the %s provider during start-up", token.Name, provider.Name()), "Resolve secret", LogField("secret", token.Name), LogField("provider", provider.Name()))
+			break
+		}
+		if !resolved {
+			if token.Optional {
+				WriteWarningLog(fmt.Sprintf("The optional secret %s could not be resolved by any provider, during the function ResolveSecrets()", token.Name), "Secret not found")
+			} else {
+				missingSecrets = append(missingSecrets, token.Name)
+			}
+		}
+	}
+
+	if len(missingSecrets) > 0 {
+		providerNames := []string{}
+		for _, provider := range providers {
+			providerNames = append(providerNames, provider.Name())
+		}
+		return fmt.Errorf("the required secrets %s could not be resolved by any of the providers %s", strings.Join(missingSecrets, ", "), strings.Join(providerNames, " -> "))
+	}
+	return nil
+}
+
+// A provider that cannot be created is logged and left out of the chain
+func NewSecretProviders(currentKeyvaultConfig keyvault) []secretProvider {
+	providers := []secretProvider{}
+	for _, providerName := range currentKeyvaultConfig.Providers {
+		switch strings.ToLower(providerName) {
+		case secretProviderKeyvault:
+			{
+				if currentKeyvaultConfig.Name == "" {
+					WriteWarningLog("The keyvault provider is configured but no key vault name is set, the provider is skipped, during the function NewSecretProviders()", "Secret provider skipped")
+					continue
+				}
+				provider, err := NewKeyvaultSecretProvider(currentKeyvaultConfig.Name)
+				if err != nil {
+					WriteErrorLog(fmt.Sprintf("An error occured while trying to start a new key vault client to: %s, the provider is skipped", currentKeyvaultConfig.Name), err.Error())
+					continue
+				}
+				providers = append(providers, provider)
+			}
+		case secretProviderEnv:
+			{
+				providers = append(providers, &envSecretProvider{})
+			}
+		case secretProviderFile:
+			{
+				provider, err := NewFileSecretProvider(currentKeyvaultConfig.SecretsFilePath)
+				if err != nil {
+					WriteErrorLog(fmt.Sprintf("An error occured while trying to read the secrets file %s, the provider is skipped", currentKeyvaultConfig.SecretsFilePath), err.Error())
+					continue
+				}
+				if provider == nil {
+					continue //No secrets file, which is the normal case when running in Azure
+				}
+				providers = append(providers, provider)
+			}
+		default:
+			{
+				WriteWarningLog(fmt.Sprintf("The secret provider %s is unknown, use one of %s, %s or %s, during the function NewSecretProviders()", providerName, secretProviderKeyvault, secretProviderEnv, secretProviderFile), "Secret provider skipped")
+			}
+		}
+	}
+	return providers
+}
+
+func NewKeyvaultSecretProvider(keyvaultURI string) (*keyvaultSecretProvider, error) {
+	azCred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, err
+	}
+	azKeyvaultClient, err := azsecrets.NewClient(keyvaultURI, azCred, nil)
+	if err != nil {
+		return nil, err
+	}
+	WriteInformationLog("Keyvault client successfully established during start-up", "Import Keyvault client")
+	return &keyvaultSecretProvider{client: azKeyvaultClient}, nil
+}
+
+// Returns nil without an error when the file does not exist
+func NewFileSecretProvider(path string) (*fileSecretProvider, error) {
+	secretBytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]string)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(secretBytes, &secrets); err != nil {
+			return nil, fmt.Errorf("the secrets file must be a JSON object of names to values: %s", err.Error())
+		}
+	} else {
+		secrets, err = ParseDotenv(string(secretBytes))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &fileSecretProvider{path: path, secrets: secrets}, nil
+}
+
+// Lines of KEY=VALUE, blank lines and lines starting with # are skipped, values may be quoted
+func ParseDotenv(content string) (map[string]string, error) {
+	secrets := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d of the dotenv file is not in the format KEY=VALUE", lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		secrets[strings.TrimSpace(key)] = value
+	}
+	return secrets, scanner.Err()
+}
+
+// botToken becomes BOT_TOKEN and raidHelperToken becomes RAID_HELPER_TOKEN
+func GetSecretEnvName(token keyvaultToken) string {
+	if token.EnvName != "" {
+		return token.EnvName
+	}
+	envName := strings.Builder{}
+	for x, character := range token.Name {
+		if unicode.IsUpper(character) && x > 0 {
+			envName.WriteRune('_')
+		}
+		if character == '-' {
+			character = '_'
+		}
+		envName.WriteRune(unicode.ToUpper(character))
+	}
+	return envName.String()
+}
+
+func (provider *keyvaultSecretProvider) GetSecret(token keyvaultToken) (string, bool, error) {
+	if provider.unavailable != nil {
+		return "", false, nil
+	}
+	secretContext, cancel := context.WithTimeout(context.Background(), keyvaultSecretTimeout)
+	defer cancel()
+	secret, err := provider.client.GetSecret(secretContext, token.Name, token.VersionID, nil)
+	if err != nil {
+		var responseErr *azcore.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			if token.VersionID != "" {
+				return "", false, fmt.Errorf("%w: %s", errSecretVersionNotFound, err.Error())
+			}
+			return "", false, nil
+		} else if !errors.As(err, &responseErr) {
+			//No response from the key vault at all, which means the identity could not get a token or the vault cannot be reached
+			provider.unavailable = err
+			return "", false, fmt.Errorf("the key vault could not be reached with the managed identity, the provider is skipped for the remaining secrets: %s", err.Error())
+		}
+		return "", false, err
+	}
+	if secret.Value == nil {
+		return "", false, nil
+	}
+	return *secret.Value, true, nil
+}
+
+func (provider *keyvaultSecretProvider) Name() string {
+	return secretProviderKeyvault
+}
+
+func (provider *envSecretProvider) GetSecret(token keyvaultToken) (string, bool, error) {
+	value, found := os.LookupEnv(GetSecretEnvName(token))
+	return value, found, nil
+}
+
+func (provider *envSecretProvider) Name() string {
+	return secretProviderEnv
+}
+
+// Looks up the secret name first and the env name second, so a .env file can be shared with the env provider
+func (provider *fileSecretProvider) GetSecret(token keyvaultToken) (string, bool, error) {
+	if value, found := provider.secrets[token.Name]; found {
+		return value, true, nil
+	}
+	value, found := provider.secrets[GetSecretEnvName(token)]
+	return value, found, nil
+}
+
+func (provider *fileSecretProvider) Name() string {
+	return secretProviderFile
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	signUpNagOptOutMutex sync.Mutex //Held for every read-modify-write of the opt-outs, as several users can use /mysignupnags at once
+)
+
+// Returns the lead time to nag at, or 0 when none is due, and every lead time that has passed. When the bot missed several lead times only the shortest is nagged
+func GetDueNagLeadHours(leadHours []int, naggedLeadHours []int, raidStart time.Time, now time.Time) (int, []int) {
+	dueLeadHours := 0
+	passedLeadHours := []int{}
+	for _, leadHour := range leadHours {
+		if now.Before(raidStart.Add(-time.Duration(leadHour) * time.Hour)) {
+			continue
+		}
+		passedLeadHours = append(passedLeadHours, leadHour)
+		if !slices.Contains(naggedLeadHours, leadHour) && (dueLeadHours == 0 || leadHour < dueLeadHours) {
+			dueLeadHours = leadHour
+		}
+	}
+	return dueLeadHours, passedLeadHours
+}
+
+// DMs the raiders and trials missing from the sign-ups of the upcoming raid-helper events once per lead time of the guild config, the officers get a summary of who was nagged
+func NagMissingSignUps(guildID string, session discordSession) error {
+	guild := GetGuildConfig(guildID)
+	if len(guild.SignUpNags.LeadHours) == 0 {
+		return nil
+	}
+	now := time.Now()
+	raidHelperEvents := RetriveRaidHelperEvent(guildID, now)
+	if raidHelperEvents == nil {
+		return fmt.Errorf("the raid-helper events of server %s could not be retrieved", guildID)
+	}
+	optOuts, err := storageCurrent.ReadSignUpNagOptOuts()
+	if err != nil {
+		return fmt.Errorf("the sign-up nag opt-outs could not be read: %s", err.Error())
+	}
+	trackedRaids := ReadWriteRaidHelperCache(guildID)
+	messageIDs := []string{}
+	mapOfRaidStarts := make(map[string]time.Time)
+	for messageID, raidHelperEvent := range raidHelperEvents {
+		mapOfRaidStarts[messageID] = time.Unix(raidHelperEvent.StartTime, 0)
+		messageIDs = append(messageIDs, messageID)
+	}
+	sort.Slice(messageIDs, func(i, j int) bool {
+		return mapOfRaidStarts[messageIDs[i]].Before(mapOfRaidStarts[messageIDs[j]])
+	})
+
+	var raidersAndTrials []string //Only retrieved once an event is due
+	summaryLines := []string{}
+	failedEvents := []string{}
+	mapOfNaggedRaids := make(map[string]trackRaid) //Message ID to the tracked raid with the lead hours nagged by this run
+	for _, messageID := range messageIDs {
+		raidHelperEvent := raidHelperEvents[messageID]
+		raidStart := mapOfRaidStarts[messageID]
+		trackedRaid := trackedRaids[messageID]
+		dueLeadHours, passedLeadHours := GetDueNagLeadHours(guild.SignUpNags.LeadHours, trackedRaid.NaggedLeadHours, raidStart, now)
+		if dueLeadHours == 0 {
+			continue
+		}
+		title := raidHelperEvent.Title
+		channelID := raidHelperEvent.ChannelID
+		signUps, err := raidHelperCurrent.GetEventSignUps(messageID)
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to retrieve the sign-ups of the raid-helper event %s, during the function NagMissingSignUps()", messageID), err.Error(), LogField("guildID", guildID))
+			failedEvents = append(failedEvents, title)
+			continue
+		}
+		if raidersAndTrials == nil {
+			raidersAndTrials = RetrieveUsersInRole(guildID, []string{guild.Roles.Raider, guild.Roles.Trial}, session)
+		}
+		mapOfSignedUp := make(map[string]bool)
+		for _, signUp := range signUps { //An absence is also an answer
+			mapOfSignedUp[signUp.UserID] = true
+		}
+		eventLink := fmt.Sprintf("https://discord.com/channels/%s/%s/%s", guild.ServerID, channelID, messageID)
+		naggedUsers := []string{}
+		countOfOptOuts := 0
+		for _, userID := range raidersAndTrials {
+			if mapOfSignedUp[userID] {
+				continue
+			}
+			if optOuts[userID] {
+				countOfOptOuts++
+				continue
+			}
+			InformPlayerDirectly(fmt.Sprintf("You have not signed up for **%s** starting %s yet, please sign up or mark yourself absent here: %s\nUse `/mysignupnags` to stop these messages", title, FormatDiscordTimestamp(raidStart), eventLink), userID, session)
+			naggedUsers = append(naggedUsers, fmt.Sprintf("<@%s>", userID))
+		}
+		WriteInformationLog(fmt.Sprintf("%d raiders missing from the sign-ups of the raid %s has been nagged %d hours before the raid, during the function NagMissingSignUps()", len(naggedUsers), title, dueLeadHours), "Sign-up nags", LogField("guildID", guildID), LogField("messageID", messageID))
+		summaryLine := fmt.Sprintf("**[%s](%s)** starting %s, %d hours before:\n", title, eventLink, FormatDiscordTimestamp(raidStart), dueLeadHours)
+		if len(naggedUsers) == 0 {
+			summaryLine += "Everyone has signed up"
+		} else {
+			summaryLine += strings.Join(naggedUsers, ", ")
+		}
+		if countOfOptOuts > 0 {
+			summaryLine += fmt.Sprintf("\n%d missing raiders have turned the nags off", countOfOptOuts)
+		}
+		summaryLines = append(summaryLines, summaryLine)
+
+		if trackedRaid.DiscordMessageID == "" { //The event has not been edited since it was posted, so it is not tracked yet
+			trackedRaid.DiscordMessageID = messageID
+			trackedRaid.ChannelID = channelID
+			trackedRaid.RaidDiscordTitle = title
+			trackedRaid.RaidStartUnixTime = raidStart.Unix()
+		}
+		trackedRaid.NaggedLeadHours = passedLeadHours
+		mapOfNaggedRaids[messageID] = trackedRaid
+	}
+	if len(summaryLines) > 0 {
+		//The cache is read again, the raid-helper events might have been tracked or benched while the sign-ups were retrieved
+		err = UpdateTrackedRaids(guildID, func(currentTrackedRaids map[string]trackRaid) error {
+			for messageID, naggedRaid := range mapOfNaggedRaids {
+				trackedRaid := currentTrackedRaids[messageID]
+				if trackedRaid.DiscordMessageID == "" {
+					trackedRaid.DiscordMessageID = naggedRaid.DiscordMessageID
+					trackedRaid.ChannelID = naggedRaid.ChannelID
+					trackedRaid.RaidDiscordTitle = naggedRaid.RaidDiscordTitle
+					trackedRaid.RaidStartUnixTime = naggedRaid.RaidStartUnixTime
+				}
+				trackedRaid.NaggedLeadHours = naggedRaid.NaggedLeadHours
+				currentTrackedRaids[messageID] = trackedRaid
+			}
+			return nil
+		})
+		if err != nil { //The same lead hours are nagged again on the next run
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to write the nagged lead hours of server %s, during the function NagMissingSignUps()", guildID), err.Error(), LogField("guildID", guildID))
+		}
+		_, err = session.ChannelMessageSendEmbed(guild.Channels.Officer, &discordgo.MessageEmbed{
+			Title:       "Raiders nagged about the sign-ups",
+			Description: JoinWithinEmbedLimit(summaryLines, "\n\n"),
+			Color:       blueColor,
+		})
+		if err != nil {
+			WriteErrorLog(fmt.Sprintf("An error occured while trying to post the sign-up nags to the officer channel %s, during the function NagMissingSignUps()", guild.Channels.Officer), err.Error())
+		}
+	}
+	if len(failedEvents) > 0 {
+		return fmt.Errorf("the sign-ups of the raids %s could not be retrieved", strings.Join(failedEvents, ", "))
+	}
+	return nil
+}
+
+func HandleMySignUpNags(request *slashCommandRequest) {
+	enabled := request.BoolOption("enabled")
+	if err := SetSignUpNagOptOut(request.UserID, !enabled); err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to save the sign-up nag opt-out for user %s, during the function HandleMySignUpNags()", request.UserID), err.Error())
+		RespondSlashCommandError(request, "mysignupnags|Your choice could not be saved, please try again later")
+		return
+	}
+	message := "You will no longer get a DM when you are missing from the sign-ups of a raid"
+	if enabled {
+		message = "You will get a DM when you are missing from the sign-ups of a raid"
+	}
+	interactionResponse := NewInteractionResponseToSpecificCommand(2, fmt.Sprintf("mysignupnags|%s", message))
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to respond to user %s using the slash command /mysignupnags, during the function HandleMySignUpNags()", request.UserID), err.Error())
+	}
+}
+
+func SetSignUpNagOptOut(userID string, optOut bool) error {
+	signUpNagOptOutMutex.Lock()
+	defer signUpNagOptOutMutex.Unlock()
+	optOuts, err := storageCurrent.ReadSignUpNagOptOuts()
+	if err != nil {
+		return fmt.Errorf("the sign-up nag opt-outs could not be read: %s", err.Error())
+	}
+	if optOut {
+		optOuts[userID] = true
+	} else {
+		delete(optOuts, userID)
+	}
+	return storageCurrent.WriteSignUpNagOptOuts(optOuts)
+}
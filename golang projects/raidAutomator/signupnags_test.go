@@ -0,0 +1,37 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetDueNagLeadHours(t *testing.T) {
+	raidStart := time.Date(2025, time.March, 12, 20, 0, 0, 0, time.UTC)
+	leadHours := []int{72, 24, 4}
+	for _, testCase := range []struct {
+		name            string
+		now             time.Time
+		naggedLeadHours []int
+		expectedDue     int
+		expectedPassed  []int
+	}{
+		{"before every lead time", raidStart.Add(-73 * time.Hour), nil, 0, []int{}},
+		{"first lead time", raidStart.Add(-72 * time.Hour), nil, 72, []int{72}},
+		{"first lead time already nagged", raidStart.Add(-30 * time.Hour), []int{72}, 0, []int{72}},
+		{"second lead time", raidStart.Add(-20 * time.Hour), []int{72}, 24, []int{72, 24}},
+		{"offline during every lead time only nags the shortest", raidStart.Add(-1 * time.Hour), nil, 4, []int{72, 24, 4}},
+		{"offline during the second lead time", raidStart.Add(-2 * time.Hour), []int{72}, 4, []int{72, 24, 4}},
+		{"every lead time nagged", raidStart.Add(-2 * time.Hour), []int{72, 24, 4}, 0, []int{72, 24, 4}},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			dueLeadHours, passedLeadHours := GetDueNagLeadHours(leadHours, testCase.naggedLeadHours, raidStart, testCase.now)
+			if dueLeadHours != testCase.expectedDue {
+				t.Errorf("expected the lead time %d to be due, got %d", testCase.expectedDue, dueLeadHours)
+			}
+			if !slices.Equal(passedLeadHours, testCase.expectedPassed) {
+				t.Errorf("expected the lead times %v to have passed, got %v", testCase.expectedPassed, passedLeadHours)
+			}
+		})
+	}
+}
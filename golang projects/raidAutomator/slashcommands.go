@@ -0,0 +1,354 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type slashCommandHandler func(request *slashCommandRequest)
+
+// Everything a handler needs to answer one interaction, built by the function Dispatch()
+type slashCommandRequest struct {
+	Session       discordSession
+	Event         *discordgo.InteractionCreate
+	Guild         guildConfig
+	UserID        string
+	Data          discordgo.ApplicationCommandInteractionData                   //Empty for buttons and modals
+	Subcommand    string                                                        //E.g. "month" for /raidsummary month, "group/name" for subcommand groups
+	Options       map[string]*discordgo.ApplicationCommandInteractionDataOption //The options of the command or of the routed subcommand
+	CustomID      string                                                        //Buttons and modals only
+	CustomIDParts []string                                                      //The custom ID split on "/", the first part is the prefix the handler is registered with
+}
+
+type slashCommandRoute struct {
+	Command     applicationCommand
+	Handler     slashCommandHandler            //Used when the command has no subcommands or none of them matched
+	Subcommands map[string]slashCommandHandler //Routed on the name of the subcommand, see slashCommandRequest.Subcommand
+}
+
+type customIDRoute struct {
+	Prefix             string
+	Handler            slashCommandHandler
+	RequiresPriviledge bool
+	DeleteMessage      bool //Removes the message holding the button once it has been pressed
+}
+
+type slashCommandRegistry struct {
+	commands   map[string]*slashCommandRoute
+	components []customIDRoute
+	modals     []customIDRoute
+}
+
+const (
+	customIDSeparator = "/" //CustomID convention: <prefix>/<value>/<value>...
+)
+
+func NewSlashCommandRegistry() *slashCommandRegistry {
+	registry := &slashCommandRegistry{
+		commands: make(map[string]*slashCommandRoute),
+	}
+	registry.RegisterCommand(slashCommandAdminCenter["announcebot"], HandleAnnounceBot)
+	registry.RegisterCommand(slashCommandAdminCenter["closefeedback"], HandleCloseFeedback)
+	registry.RegisterCommand(slashCommandAdminCenter["deletebotchannel"], HandleDeleteBotChannel)
+	registry.RegisterCommand(slashCommandAdminCenter["benchreason"], HandleBenchReason)
+	registry.RegisterCommand(slashCommandAdminCenter["resetraidcache"], HandleResetRaidCache)
+	registry.RegisterCommand(slashCommandAdminCenter["raidsummary"], nil)
+	registry.RegisterSubcommand("raidsummary", "month", HandleRaidSummaryMonth)
+	registry.RegisterSubcommand("raidsummary", "daysorweeks", HandleRaidSummaryDaysOrWeeks)
+	registry.RegisterSubcommand("raidsummary", "boss", HandleRaidSummaryBoss)
+	registry.RegisterCommand(slashCommandAdminCenter["simplemessage"], HandleSimpleMessage)
+	registry.RegisterCommand(slashCommandAdminCenter["deletechannelcontent"], HandleDeleteChannelContent)
+	registry.RegisterCommand(slashCommandAdminCenter["seeraiderattendance"], HandleSeeRaiderAttendance)
+	registry.RegisterCommand(slashCommandAdminCenter["overrideattendance"], HandleOverrideAttendance)
+	registry.RegisterCommand(slashCommandAdminCenter["seeraidermissedraids"], HandleSeeRaiderMissedRaids)
+	registry.RegisterCommand(slashCommandAdminCenter["seebench"], HandleSeeBench)
+	registry.RegisterCommand(slashCommandAdminCenter["updateweeklyattendance"], HandleUpdateWeeklyAttendance)
+	registry.RegisterCommand(slashCommandAdminCenter["reloadguildconfig"], HandleReloadGuildConfig)
+	registry.RegisterCommand(slashCommandAdminCenter["recentlogs"], HandleRecentLogs)
+	registry.RegisterCommand(slashCommandAdminCenter["promotetrial"], HandlePromoteTrial)
+	registry.RegisterCommand(slashCommandAdminCenter["syncdiscordroles"], HandleSyncDiscordRoles)
+	registry.RegisterCommand(slashCommandAdminCenter["schedules"], nil)
+	registry.RegisterSubcommand("schedules", "list", HandleSchedulesList)
+	registry.RegisterSubcommand("schedules", "pause", HandleSchedulesPause)
+	registry.RegisterSubcommand("schedules", "resume", HandleSchedulesResume)
+	registry.RegisterSubcommand("schedules", "trigger", HandleSchedulesTrigger)
+	registry.RegisterCommand(slashCommandAdminCenter["createraidevent"], HandleCreateRaidEvent)
+	registry.RegisterCommand(slashCommandAdminCenter["benchrecommend"], HandleBenchRecommend)
+
+	registry.RegisterCommand(slashCommandAllUsers["aboutme"], nil)
+	registry.RegisterCommand(slashCommandAllUsers["howto"], HandleHowTo)
+	registry.RegisterCommand(slashCommandAllUsers["myattendance"], HandleMyAttendance)
+	registry.RegisterCommand(slashCommandAllUsers["mymissedraids"], HandleMyMissedRaids)
+	registry.RegisterCommand(slashCommandAllUsers["myreminder"], nil)
+	registry.RegisterSubcommand("myreminder", "set", HandleMyReminderSet)
+	registry.RegisterSubcommand("myreminder", "list", HandleMyReminderList)
+	registry.RegisterSubcommand("myreminder", "cancel", HandleMyReminderCancel)
+	registry.RegisterSubcommand("myreminder", "timezone", HandleMyReminderTimezone)
+	registry.RegisterCommand(slashCommandAllUsers["myraiderperformance"], HandleMyRaiderPerformance)
+	registry.RegisterCommand(slashCommandAllUsers["hi"], HandleHi)
+	registry.RegisterCommand(slashCommandAllUsers["joke"], HandleJoke)
+	registry.RegisterCommand(slashCommandAllUsers["feedback"], HandleFeedback)
+	registry.RegisterCommand(slashCommandAllUsers["mysignupnags"], HandleMySignUpNags)
+	registry.RegisterCommand(slashCommandAllUsers["absent"], nil)
+	registry.RegisterSubcommand("absent", "raids", HandleAbsentRaids)
+	registry.RegisterSubcommand("absent", "range", HandleAbsentRange)
+
+	registry.RegisterComponent(customIDRoute{Prefix: "general", Handler: HandleGeneralButton, DeleteMessage: true})
+	registry.RegisterComponent(customIDRoute{Prefix: "stats", Handler: HandleStatsButton, DeleteMessage: true})
+	registry.RegisterComponent(customIDRoute{Prefix: "benchreason", Handler: HandleBenchReasonButton, RequiresPriviledge: true, DeleteMessage: true})
+	registry.RegisterComponent(customIDRoute{Prefix: "absent", Handler: HandleAbsentSelect})
+	registry.RegisterComponent(customIDRoute{Prefix: "benchrecommend", Handler: HandleBenchRecommendSelect, RequiresPriviledge: true})
+	registry.RegisterModal(customIDRoute{Prefix: "feedback_modal", Handler: HandleFeedbackModal})
+	registry.RegisterModal(customIDRoute{Prefix: "bench_modal", Handler: HandleBenchModal, RequiresPriviledge: true})
+	registry.RegisterModal(customIDRoute{Prefix: "absent_modal", Handler: HandleAbsentModal})
+	return registry
+}
+
+// The template of the command must be set, the name of the command is taken from it
+func (registry *slashCommandRegistry) RegisterCommand(command applicationCommand, handler slashCommandHandler) {
+	if command.Template == nil {
+		WriteErrorLog(fmt.Sprintf("The slash command %s has no template and cannot be registered, during the function RegisterCommand()", command.Name), "Missing template")
+		return
+	}
+	command.Name = command.Template.Name
+	registry.commands[command.Name] = &slashCommandRoute{
+		Command:     command,
+		Handler:     handler,
+		Subcommands: make(map[string]slashCommandHandler),
+	}
+}
+
+// The command must be registered first, use "group/name" for a subcommand inside a subcommand group
+func (registry *slashCommandRegistry) RegisterSubcommand(commandName string, subcommandName string, handler slashCommandHandler) {
+	route, ok := registry.commands[commandName]
+	if !ok {
+		WriteErrorLog(fmt.Sprintf("The subcommand %s cannot be registered because the slash command %s is not registered, during the function RegisterSubcommand()", subcommandName, commandName), "Unknown slash command")
+		return
+	}
+	route.Subcommands[subcommandName] = handler
+}
+
+func (registry *slashCommandRegistry) RegisterComponent(route customIDRoute) {
+	registry.components = append(registry.components, route)
+	SortCustomIDRoutes(registry.components)
+}
+
+func (registry *slashCommandRegistry) RegisterModal(route customIDRoute) {
+	registry.modals = append(registry.modals, route)
+	SortCustomIDRoutes(registry.modals)
+}
+
+// Longest prefix first, so "bench_modal" is tried before "bench" would be
+func SortCustomIDRoutes(routes []customIDRoute) {
+	sort.SliceStable(routes, func(x, y int) bool {
+		return len(routes[x].Prefix) > len(routes[y].Prefix)
+	})
+}
+
+// The templates of every registered command, sorted by name
+func (registry *slashCommandRegistry) Commands() []applicationCommand {
+	commands := []applicationCommand{}
+	for _, route := range registry.commands {
+		commands = append(commands, route.Command)
+	}
+	sort.Slice(commands, func(x, y int) bool {
+		return commands[x].Name < commands[y].Name
+	})
+	return commands
+}
+
+// Routes the interaction to its handler, the privilege of the route is checked before the handler is called
+func (registry *slashCommandRegistry) Dispatch(session discordSession, event *discordgo.InteractionCreate) {
+	user := GetDiscordUser(event)
+	if user == nil || user.ID == session.BotUserID() {
+		return
+	}
+	request := &slashCommandRequest{
+		Session: session,
+		Event:   event,
+		Guild:   GetGuildConfig(event.GuildID),
+		UserID:  user.ID,
+		Options: make(map[string]*discordgo.ApplicationCommandInteractionDataOption),
+	}
+	if request.Guild.ServerID == "" {
+		return //The interaction came from a server that is not part of the guild config
+	}
+
+	handlerName := ""
+	var handler slashCommandHandler
+	requiresPriviledge := false
+	deleteMessage := false //Only once the privilege is checked, so a user without the rank cannot remove the button
+	switch event.Type {
+	case discordgo.InteractionApplicationCommand:
+		{
+			request.Data = event.ApplicationCommandData()
+			route, ok := registry.commands[request.Data.Name]
+			if !ok {
+				RespondSlashCommandError(request, fmt.Sprintf("%s|The command `/%s` is not known by the bot anymore, please give Discord a moment to update the list of commands", request.Data.Name, request.Data.Name))
+				return
+			}
+			handlerName = "/" + route.Command.Name
+			requiresPriviledge = route.Command.RequiresPriviledge
+			handler = route.Handler
+			options, templateOptions := request.Data.Options, route.Command.Template.Options
+			for len(options) > 0 && (options[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup || options[0].Type == discordgo.ApplicationCommandOptionSubCommand) {
+				request.Subcommand = strings.TrimPrefix(request.Subcommand+"/"+options[0].Name, "/")
+				templateOptions = GetTemplateSubOptions(templateOptions, options[0].Name)
+				options = options[0].Options
+			}
+			if request.Subcommand != "" {
+				handlerName = fmt.Sprintf("%s %s", handlerName, strings.ReplaceAll(request.Subcommand, "/", " "))
+				if subcommandHandler, ok := route.Subcommands[request.Subcommand]; ok {
+					handler = subcommandHandler
+				}
+			}
+			for _, option := range options {
+				request.Options[option.Name] = option
+			}
+			for _, templateOption := range templateOptions {
+				if _, ok := request.Options[templateOption.Name]; templateOption.Required && !ok {
+					RespondSlashCommandError(request, fmt.Sprintf("%s|The option `%s` is required", route.Command.Name, templateOption.Name))
+					return
+				}
+			}
+			WriteInformationLog(fmt.Sprintf("The slash command %s was used", handlerName), "Use slash command", LogField("command", handlerName), LogField("userID", request.UserID), LogField("guildID", request.Guild.ServerID))
+		}
+	case discordgo.InteractionMessageComponent:
+		{
+			request.CustomID = event.MessageComponentData().CustomID
+			route, ok := FindCustomIDRoute(registry.components, request.CustomID)
+			if !ok {
+				WriteErrorLog(fmt.Sprintf("No handler is registered for the button %s, expected <command>%s<value>, during the function Dispatch()", request.CustomID, customIDSeparator), "Unknown button")
+				RespondSlashCommandError(request, fmt.Sprintf("button %s|This button is not yet supported... Please contact %s", request.CustomID, GetGuildMaster(request.Guild.ServerID).Name))
+				return
+			}
+			handlerName, handler, requiresPriviledge = "button "+route.Prefix, route.Handler, route.RequiresPriviledge
+			deleteMessage = route.DeleteMessage && event.Message != nil
+			request.CustomIDParts = strings.Split(request.CustomID, customIDSeparator)
+		}
+	case discordgo.InteractionModalSubmit:
+		{
+			request.CustomID = event.ModalSubmitData().CustomID
+			route, ok := FindCustomIDRoute(registry.modals, request.CustomID)
+			if !ok {
+				WriteErrorLog(fmt.Sprintf("No handler is registered for the modal %s, during the function Dispatch()", request.CustomID), "Unknown modal")
+				RespondSlashCommandError(request, fmt.Sprintf("modal %s|This form is not yet supported... Please contact %s", request.CustomID, GetGuildMaster(request.Guild.ServerID).Name))
+				return
+			}
+			handlerName, handler, requiresPriviledge = "modal "+route.Prefix, route.Handler, route.RequiresPriviledge
+			request.CustomIDParts = strings.Split(request.CustomID, customIDSeparator)
+		}
+	default:
+		{
+			return
+		}
+	}
+
+	if !CheckSlashCommandPriviledge(request, requiresPriviledge) {
+		WriteWarningLog(fmt.Sprintf("The user %s is missing the rank needed to use %s, during the function Dispatch()", request.UserID, handlerName), "Missing rank", LogField("handler", handlerName), LogField("userID", request.UserID), LogField("guildID", request.Guild.ServerID))
+		rank := "raider"
+		if requiresPriviledge {
+			rank = "officer"
+		}
+		RespondSlashCommandError(request, fmt.Sprintf("%s|You need the %s rank to use this %s", handlerName, rank, crackedBuiltin))
+		return
+	}
+	if deleteMessage {
+		session.ChannelMessageDelete(event.ChannelID, event.Message.ID)
+	}
+	if handler == nil {
+		RespondSlashCommandError(request, fmt.Sprintf("%s|This feature is not out yet 🚧 please contact <@%s> for more information", handlerName, GetGuildMaster(request.Guild.ServerID).ID))
+		return
+	}
+	RunSlashCommandHandler(request, handlerName, handler)
+}
+
+// A panic inside a handler is logged with the name of the handler and answered, so the user is not left waiting
+func RunSlashCommandHandler(request *slashCommandRequest, handlerName string, handler slashCommandHandler) {
+	startTime := time.Now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			WriteErrorLog(fmt.Sprintf("The handler of %s stopped unexpectedly for user %s, during the function RunSlashCommandHandler()", handlerName, request.UserID), fmt.Sprintf("%v\n%s", recovered, debug.Stack()), LogField("handler", handlerName), LogField("userID", request.UserID), LogField("guildID", request.Guild.ServerID))
+			RespondSlashCommandError(request, fmt.Sprintf("%s|An error inside the bot, please report this error to %s", handlerName, GetGuildMaster(request.Guild.ServerID).Name))
+		}
+	}()
+	handler(request)
+	WriteDebugLog(fmt.Sprintf("The handler of %s finished in %s", handlerName, time.Since(startTime).Round(time.Millisecond)), "Slash command handled", LogField("handler", handlerName))
+}
+
+// Buttons and modals are public to anyone who can see them, RequiresPriviledge limits them to officers
+func CheckSlashCommandPriviledge(request *slashCommandRequest, requiresPriviledge bool) bool {
+	if CheckForOfficerRank(request.Guild.ServerID, request.UserID, request.Session) {
+		return true
+	}
+	if requiresPriviledge {
+		return false
+	}
+	return request.Event.Type != discordgo.InteractionApplicationCommand || CheckForRaiderRank(request.Guild.ServerID, request.UserID, request.Session)
+}
+
+// Matches the whole first part of the custom ID, so "bench" never matches "benchreason/..."
+func FindCustomIDRoute(routes []customIDRoute, customID string) (customIDRoute, bool) {
+	for _, route := range routes {
+		if customID == route.Prefix || strings.HasPrefix(customID, route.Prefix+customIDSeparator) {
+			return route, true
+		}
+	}
+	return customIDRoute{}, false
+}
+
+func GetTemplateSubOptions(templateOptions []*discordgo.ApplicationCommandOption, name string) []*discordgo.ApplicationCommandOption {
+	for _, templateOption := range templateOptions {
+		if templateOption.Name == name {
+			return templateOption.Options
+		}
+	}
+	return nil
+}
+
+// Responds with an error embed, as a followup when the interaction has already been answered by the handler
+func RespondSlashCommandError(request *slashCommandRequest, message string) {
+	interactionResponse := NewInteractionResponseToSpecificCommand(0, message)
+	err := request.Session.InteractionRespond(request.Event.Interaction, &interactionResponse)
+	if err == nil {
+		return
+	}
+	_, err = request.Session.FollowupMessageCreate(request.Event.Interaction, true, &discordgo.WebhookParams{
+		Embeds: interactionResponse.Data.Embeds,
+		Flags:  discordgo.MessageFlagsEphemeral,
+	})
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to sent an error response to user %s, during the function RespondSlashCommandError()", request.UserID), err.Error())
+	}
+}
+
+func (request *slashCommandRequest) StringOption(name string) string {
+	if option, ok := request.Options[name]; ok && option.Type == discordgo.ApplicationCommandOptionString {
+		return option.StringValue()
+	}
+	return ""
+}
+
+func (request *slashCommandRequest) BoolOption(name string) bool {
+	if option, ok := request.Options[name]; ok && option.Type == discordgo.ApplicationCommandOptionBoolean {
+		return option.BoolValue()
+	}
+	return false
+}
+
+func (request *slashCommandRequest) IntOption(name string) int64 {
+	if option, ok := request.Options[name]; ok && option.Type == discordgo.ApplicationCommandOptionInteger {
+		return option.IntValue()
+	}
+	return 0
+}
+
+func (request *slashCommandRequest) HasOption(name string) bool {
+	_, ok := request.Options[name]
+	return ok
+}
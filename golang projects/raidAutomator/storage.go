@@ -0,0 +1,813 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type storage interface {
+	ReadRaiderProfiles(guildID string) (raiderProfiles, error)
+	WriteRaiderProfiles(guildID string, profiles raiderProfiles) error
+	ReadMemberProfiles(cachePath string) ([]raiderProfile, error) //cachePath is one of the member caches, e.g. belowRaidersCachePath
+	WriteMemberProfiles(cachePath string, profiles []raiderProfile) error
+	ReadRaids(guildID string, since time.Time) ([]logAllData, error)         //Newest raid first
+	WriteRaids(guildID string, raids []logAllData) error                     //Replaces every raid of the guild
+	AddRaids(guildID string, raids []logAllData) error                       //Adds or replaces the raids with the same log code
+	ReadReportWatermarks(guildID string) (map[string]reportWatermark, error) //Log code to what was stored of the report
+	WriteReportWatermarks(guildID string, watermarks map[string]reportWatermark) error
+	ReadBenches(guildID string) (map[string]trackRaid, error)
+	WriteBenches(guildID string, benches map[string]trackRaid) error
+	ReadSecondaryRaids(guildID string) ([]commingRaid, error) //The rotation of the secondary raids, see the function DetermineNextSecondaryRaid()
+	WriteSecondaryRaids(guildID string, raids []commingRaid) error
+	ReadFeedbackChannels() ([]playerChannel, error)
+	WriteFeedbackChannels(channels []playerChannel) error
+	ReadTrackPosts() (map[string]trackPost, error)
+	WriteTrackPosts(posts map[string]trackPost) error
+	ReadReminders() ([]reminder, error)
+	WriteReminders(reminders []reminder) error
+	ReadUserTimeZones() (map[string]string, error) //User ID to the name of the time zone, e.g. "Europe/London"
+	WriteUserTimeZones(timeZones map[string]string) error
+	ReadSignUpNagOptOuts() (map[string]bool, error) //User ID of every user that does not want sign-up nags
+	WriteSignUpNagOptOuts(optOuts map[string]bool) error
+	ReadJobStates() (map[string]jobState, error) //Name of the scheduled job to its state
+	WriteJobStates(states map[string]jobState) error
+	Close() error
+}
+
+type storageConfig struct {
+	Backend    string `json:"backend"`    //Either "json" or "sqlite"
+	SQLitePath string `json:"sqlitePath"` //Only used by the sqlite backend
+}
+
+type reminder struct {
+	ID            string    `json:"id"`
+	GuildID       string    `json:"guildID"`
+	UserID        string    `json:"userID"`
+	Title         string    `json:"title"`
+	TimesToNotify int       `json:"timesToNotify"`
+	DueTime       time.Time `json:"dueTime"`
+	Recurrence    string    `json:"recurrence,omitempty"` //Empty for a single reminder, otherwise reminderRepeatDaily or reminderRepeatWeekly
+	TimeZone      string    `json:"timeZone,omitempty"`   //The time zone of the user when the reminder was set
+}
+
+type reportWatermark struct {
+	EndTime    time.Time `json:"endTime"` //Grows while the log is still being uploaded
+	IngestedAt time.Time `json:"ingestedAt"`
+	Failed     bool      `json:"failed,omitempty"` //The last fetch failed, the listing goes on until the report is found again, even on older pages
+}
+
+type jobState struct {
+	Paused       bool      `json:"paused"` //Set with /schedules pause, a job can also be paused in the custom schedules
+	LastRun      time.Time `json:"lastRun"`
+	NextRun      time.Time `json:"nextRun"` //A next run in the past during start-up means the bot was offline at that time
+	LastTrigger  string    `json:"lastTrigger,omitempty"`
+	LastDuration string    `json:"lastDuration,omitempty"`
+	LastError    string    `json:"lastError,omitempty"` //Empty when the last run succeeded
+}
+
+type jsonStorage struct {
+	mutex sync.Mutex
+}
+
+type sqliteStorage struct {
+	db *sql.DB
+}
+
+const (
+	storageBackendJSON   = "json"
+	storageBackendSQLite = "sqlite"
+
+	collectionRaiderProfiles   = "raiderProfiles"
+	collectionBenches          = "benches"
+	collectionSecondaryRaids   = "secondaryRaids"
+	collectionReportWatermarks = "reportWatermarks"
+	collectionFeedbackChannels = "feedbackChannels"
+	collectionTrackPosts       = "trackPosts"
+	collectionReminders        = "reminders"
+	collectionUserTimeZones    = "userTimeZones"
+	collectionSignUpNagOptOuts = "signUpNagOptOuts"
+	collectionJobStates        = "jobStates"
+	collectionMemberProfiles   = "memberProfiles/" //Followed by the file name of the member cache
+
+	metaKeyJSONMigration = "jsonMigrationTime"
+)
+
+var (
+	storagePath    = baseCachePath + "config_storage.json"
+	storageCurrent storage //Set by the function ImportStorageConfig() during start-up
+
+	storageConfigDefault = storageConfig{
+		Backend:    storageBackendJSON,
+		SQLitePath: baseCachePath + "raid_automator.db",
+	}
+
+	sqliteSchema = []string{
+		`CREATE TABLE IF NOT EXISTS documents (
+			collection TEXT NOT NULL,
+			guild_id   TEXT NOT NULL,
+			data       TEXT NOT NULL,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (collection, guild_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS raids (
+			guild_id   TEXT NOT NULL,
+			code       TEXT NOT NULL,
+			start_time INTEGER NOT NULL,
+			data       TEXT NOT NULL,
+			PRIMARY KEY (guild_id, code)
+		)`,
+		`CREATE INDEX IF NOT EXISTS raids_by_start_time ON raids (guild_id, start_time)`,
+		`CREATE TABLE IF NOT EXISTS meta (
+			key   TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`,
+	}
+)
+
+func ImportStorageConfig() error {
+	currentStorageConfig := storageConfigDefault
+	if storageConfigBytes := CheckForExistingCache(storagePath); len(storageConfigBytes) == 0 {
+		marshal, err := json.MarshalIndent(storageConfigDefault, "", " ")
+		if err != nil {
+			return fmt.Errorf("the default storage config could not be marshaled: %s", err.Error())
+		}
+		err = os.WriteFile(storagePath, marshal, 0644)
+		if err != nil {
+			return fmt.Errorf("the default storage config could not be written to path %s: %s", storagePath, err.Error())
+		}
+		WriteInformationLog(fmt.Sprintf("No storage config found on disc - The default storage config has been written to path %s, during the function ImportStorageConfig()", storagePath), "No config found")
+	} else if err := json.Unmarshal(storageConfigBytes, &currentStorageConfig); err != nil {
+		return fmt.Errorf("the storage config on path %s could not be unmarshaled: %s", storagePath, err.Error())
+	}
+
+	newStorage, err := NewStorage(currentStorageConfig)
+	if err != nil {
+		return err
+	}
+	if sqliteStore, ok := newStorage.(*sqliteStorage); ok {
+		if err := MigrateJSONCacheToSQLite(sqliteStore); err != nil {
+			sqliteStore.Close()
+			return err
+		}
+	}
+	storageCurrent = newStorage
+	WriteInformationLog(fmt.Sprintf("Storage backend %s has been selected from the config on path %s, during the function ImportStorageConfig()", currentStorageConfig.Backend, storagePath), "Import successful")
+	return nil
+}
+
+func NewStorage(currentStorageConfig storageConfig) (storage, error) {
+	switch currentStorageConfig.Backend {
+	case "", storageBackendJSON:
+		{
+			return &jsonStorage{}, nil
+		}
+	case storageBackendSQLite:
+		{
+			return NewSQLiteStorage(currentStorageConfig.SQLitePath)
+		}
+	}
+	return nil, fmt.Errorf("the storage backend %s is not supported, use either %s or %s", currentStorageConfig.Backend, storageBackendJSON, storageBackendSQLite)
+}
+
+func NewSQLiteStorage(databasePath string) (*sqliteStorage, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_busy_timeout=5000&_journal_mode=WAL", databasePath))
+	if err != nil {
+		return nil, fmt.Errorf("the sqlite database on path %s could not be opened: %s", databasePath, err.Error())
+	}
+	for _, statement := range sqliteSchema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("the sqlite schema could not be created in the database on path %s: %s", databasePath, err.Error())
+		}
+	}
+	return &sqliteStorage{db: db}, nil
+}
+
+// Imports every JSON cache into the sqlite database the first time the sqlite backend is used, later start-ups skip this
+func MigrateJSONCacheToSQLite(sqliteStore *sqliteStorage) error {
+	migrationTime := ""
+	err := sqliteStore.db.QueryRow(`SELECT value FROM meta WHERE key = ?`, metaKeyJSONMigration).Scan(&migrationTime)
+	if err == nil {
+		return nil
+	} else if err != sql.ErrNoRows {
+		return fmt.Errorf("the migration state could not be read from the sqlite database: %s", err.Error())
+	}
+
+	jsonStore := &jsonStorage{}
+	countOfRaids := 0
+	for _, guildID := range GetGuildIDs() {
+		profiles, err := jsonStore.ReadRaiderProfiles(guildID)
+		if err != nil {
+			return fmt.Errorf("the raider profiles of server %s could not be migrated: %s", guildID, err.Error())
+		}
+		if len(profiles.Raiders) > 0 {
+			if err := sqliteStore.WriteRaiderProfiles(guildID, profiles); err != nil {
+				return err
+			}
+		}
+		raids, err := jsonStore.ReadRaids(guildID, time.Time{})
+		if err != nil {
+			return fmt.Errorf("the raids of server %s could not be migrated: %s", guildID, err.Error())
+		}
+		if err := sqliteStore.WriteRaids(guildID, raids); err != nil {
+			return err
+		}
+		countOfRaids += len(raids)
+		benches, err := jsonStore.ReadBenches(guildID)
+		if err != nil {
+			return fmt.Errorf("the raid-helper benches of server %s could not be migrated: %s", guildID, err.Error())
+		}
+		if err := sqliteStore.WriteBenches(guildID, benches); err != nil {
+			return err
+		}
+		secondaryRaids, err := jsonStore.ReadSecondaryRaids(guildID)
+		if err != nil {
+			return fmt.Errorf("the secondary raids of server %s could not be migrated: %s", guildID, err.Error())
+		}
+		if err := sqliteStore.WriteSecondaryRaids(guildID, secondaryRaids); err != nil {
+			return err
+		}
+	}
+	for _, guildID := range GetGuildIDs() {
+		watermarks, err := jsonStore.ReadReportWatermarks(guildID)
+		if err != nil {
+			return fmt.Errorf("the report watermarks of server %s could not be migrated: %s", guildID, err.Error())
+		}
+		if err := sqliteStore.WriteReportWatermarks(guildID, watermarks); err != nil {
+			return err
+		}
+	}
+	for _, cachePath := range []string{belowRaidersCachePath, raidersCachePath} {
+		profiles, err := jsonStore.ReadMemberProfiles(cachePath)
+		if err != nil {
+			return fmt.Errorf("the member profiles on path %s could not be migrated: %s", cachePath, err.Error())
+		}
+		if err := sqliteStore.WriteMemberProfiles(cachePath, profiles); err != nil {
+			return err
+		}
+	}
+	feedbackChannels, err := jsonStore.ReadFeedbackChannels()
+	if err != nil {
+		return fmt.Errorf("the feedback channels could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteFeedbackChannels(feedbackChannels); err != nil {
+		return err
+	}
+	posts, err := jsonStore.ReadTrackPosts()
+	if err != nil {
+		return fmt.Errorf("the tracked posts could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteTrackPosts(posts); err != nil {
+		return err
+	}
+	reminders, err := jsonStore.ReadReminders()
+	if err != nil {
+		return fmt.Errorf("the reminders could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteReminders(reminders); err != nil {
+		return err
+	}
+	timeZones, err := jsonStore.ReadUserTimeZones()
+	if err != nil {
+		return fmt.Errorf("the time zones of the users could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteUserTimeZones(timeZones); err != nil {
+		return err
+	}
+	optOuts, err := jsonStore.ReadSignUpNagOptOuts()
+	if err != nil {
+		return fmt.Errorf("the sign-up nag opt-outs could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteSignUpNagOptOuts(optOuts); err != nil {
+		return err
+	}
+	jobStates, err := jsonStore.ReadJobStates()
+	if err != nil {
+		return fmt.Errorf("the states of the scheduled jobs could not be migrated: %s", err.Error())
+	}
+	if err := sqliteStore.WriteJobStates(jobStates); err != nil {
+		return err
+	}
+
+	_, err = sqliteStore.db.Exec(`INSERT INTO meta (key, value) VALUES (?, ?)`, metaKeyJSONMigration, GetTimeString())
+	if err != nil {
+		return fmt.Errorf("the migration state could not be saved in the sqlite database: %s", err.Error())
+	}
+	WriteInformationLog(fmt.Sprintf("The JSON caches has been imported into the sqlite database for %d servers with a total of %d raids, during the function MigrateJSONCacheToSQLite()", len(GetGuildIDs()), countOfRaids), "Migrated cache")
+	return nil
+}
+
+func GetRaidCode(raid logAllData) string {
+	if raid.MetaData.Code != "" {
+		return raid.MetaData.Code
+	}
+	return raid.UniqueID
+}
+
+func SortRaidsNewestFirst(raids []logAllData) {
+	sort.SliceStable(raids, func(i, j int) bool {
+		timeI, _ := ParseGuildTime(timeLayout, raids[i].RaidStartTimeString)
+		timeJ, _ := ParseGuildTime(timeLayout, raids[j].RaidStartTimeString)
+		return timeI.After(timeJ)
+	})
+}
+
+/*
+	JSON backend - The original cache files on disc
+*/
+
+func readJSONCache(cachePath string, target any) error {
+	if cachePath == "" {
+		return fmt.Errorf("no cache path found, the server is not part of the guild config on path %s", guildConfigPath)
+	}
+	if cacheBytes := CheckForExistingCache(cachePath); len(cacheBytes) > 0 {
+		if err := json.Unmarshal(cacheBytes, target); err != nil {
+			return fmt.Errorf("the cache on path %s could not be unmarshaled: %s", cachePath, err.Error())
+		}
+	}
+	return nil
+}
+
+func writeJSONCache(cachePath string, source any) error {
+	if cachePath == "" {
+		return fmt.Errorf("no cache path found, the server is not part of the guild config on path %s", guildConfigPath)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", " ")
+	if err := encoder.Encode(source); err != nil {
+		return fmt.Errorf("the cache for path %s could not be marshaled: %s", cachePath, err.Error())
+	}
+	if err := os.WriteFile(cachePath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("the cache could not be written to path %s: %s", cachePath, err.Error())
+	}
+	return nil
+}
+
+func (store *jsonStorage) ReadRaiderProfiles(guildID string) (raiderProfiles, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	profiles := raiderProfiles{}
+	cachePath := GetGuildCachePath(guildID, raiderProfilesCachePath)
+	err := readJSONCache(cachePath, &profiles)
+	if err != nil {
+		raiders := []raiderProfile{} //Older versions of the bot reinstated the cache as a plain list of raiders
+		if readJSONCache(cachePath, &raiders) == nil {
+			return raiderProfiles{GuildName: guildName, Raiders: raiders}, nil
+		}
+	}
+	return profiles, err
+}
+
+func (store *jsonStorage) WriteRaiderProfiles(guildID string, profiles raiderProfiles) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(GetGuildCachePath(guildID, raiderProfilesCachePath), profiles)
+}
+
+func (store *jsonStorage) ReadMemberProfiles(cachePath string) ([]raiderProfile, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	profiles := []raiderProfile{}
+	err := readJSONCache(cachePath, &profiles)
+	return profiles, err
+}
+
+func (store *jsonStorage) WriteMemberProfiles(cachePath string, profiles []raiderProfile) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cachePath, profiles)
+}
+
+func (store *jsonStorage) ReadRaids(guildID string, since time.Time) ([]logAllData, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	raids := []logAllData{}
+	if err := readJSONCache(GetGuildCachePath(guildID, raidAllDataPath), &raids); err != nil {
+		return nil, err
+	}
+	returnRaids := []logAllData{}
+	for _, raid := range raids {
+		timeOfRaid, _ := ParseGuildTime(timeLayout, raid.RaidStartTimeString)
+		if !timeOfRaid.Before(since) {
+			returnRaids = append(returnRaids, raid)
+		}
+	}
+	return returnRaids, nil
+}
+
+func (store *jsonStorage) WriteRaids(guildID string, raids []logAllData) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	SortRaidsNewestFirst(raids)
+	return writeJSONCache(GetGuildCachePath(guildID, raidAllDataPath), raids)
+}
+
+func (store *jsonStorage) AddRaids(guildID string, raids []logAllData) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	cachePath := GetGuildCachePath(guildID, raidAllDataPath)
+	existingRaids := []logAllData{}
+	if err := readJSONCache(cachePath, &existingRaids); err != nil {
+		return err
+	}
+	mapOfNewRaids := make(map[string]bool)
+	for _, raid := range raids {
+		mapOfNewRaids[GetRaidCode(raid)] = true
+	}
+	for _, raid := range existingRaids {
+		if !mapOfNewRaids[GetRaidCode(raid)] {
+			raids = append(raids, raid)
+		}
+	}
+	SortRaidsNewestFirst(raids)
+	return writeJSONCache(cachePath, raids)
+}
+
+func (store *jsonStorage) ReadReportWatermarks(guildID string) (map[string]reportWatermark, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	watermarks := make(map[string]reportWatermark)
+	err := readJSONCache(GetGuildCachePath(guildID, raidWatermarksCachePath), &watermarks)
+	return watermarks, err
+}
+
+func (store *jsonStorage) WriteReportWatermarks(guildID string, watermarks map[string]reportWatermark) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(GetGuildCachePath(guildID, raidWatermarksCachePath), watermarks)
+}
+
+func (store *jsonStorage) ReadBenches(guildID string) (map[string]trackRaid, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	benches := make(map[string]trackRaid)
+	err := readJSONCache(GetGuildCachePath(guildID, raidHelperCachePath), &benches)
+	return benches, err
+}
+
+func (store *jsonStorage) WriteBenches(guildID string, benches map[string]trackRaid) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(GetGuildCachePath(guildID, raidHelperCachePath), benches)
+}
+
+func (store *jsonStorage) ReadSecondaryRaids(guildID string) ([]commingRaid, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	raids := []commingRaid{}
+	err := readJSONCache(GetGuildCachePath(guildID, raidCachePath), &raids)
+	return raids, err
+}
+
+func (store *jsonStorage) WriteSecondaryRaids(guildID string, raids []commingRaid) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(GetGuildCachePath(guildID, raidCachePath), raids)
+}
+
+func (store *jsonStorage) ReadFeedbackChannels() ([]playerChannel, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	channels := []playerChannel{}
+	err := readJSONCache(cachePlayerFeedbackChannels, &channels)
+	return channels, err
+}
+
+func (store *jsonStorage) WriteFeedbackChannels(channels []playerChannel) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cachePlayerFeedbackChannels, channels)
+}
+
+func (store *jsonStorage) ReadTrackPosts() (map[string]trackPost, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	posts := make(map[string]trackPost)
+	err := readJSONCache(cacheTrackedPostsCache, &posts)
+	return posts, err
+}
+
+func (store *jsonStorage) WriteTrackPosts(posts map[string]trackPost) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cacheTrackedPostsCache, posts)
+}
+
+func (store *jsonStorage) ReadReminders() ([]reminder, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	reminders := []reminder{}
+	err := readJSONCache(cachePlayerAlerts, &reminders)
+	return reminders, err
+}
+
+func (store *jsonStorage) WriteReminders(reminders []reminder) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cachePlayerAlerts, reminders)
+}
+
+func (store *jsonStorage) ReadUserTimeZones() (map[string]string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	timeZones := make(map[string]string)
+	err := readJSONCache(cacheUserTimeZones, &timeZones)
+	return timeZones, err
+}
+
+func (store *jsonStorage) WriteUserTimeZones(timeZones map[string]string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cacheUserTimeZones, timeZones)
+}
+
+func (store *jsonStorage) ReadSignUpNagOptOuts() (map[string]bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	optOuts := make(map[string]bool)
+	err := readJSONCache(cacheSignUpNagOptOuts, &optOuts)
+	return optOuts, err
+}
+
+func (store *jsonStorage) WriteSignUpNagOptOuts(optOuts map[string]bool) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cacheSignUpNagOptOuts, optOuts)
+}
+
+func (store *jsonStorage) ReadJobStates() (map[string]jobState, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	states := make(map[string]jobState)
+	err := readJSONCache(cacheJobStates, &states)
+	return states, err
+}
+
+func (store *jsonStorage) WriteJobStates(states map[string]jobState) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return writeJSONCache(cacheJobStates, states)
+}
+
+func (store *jsonStorage) Close() error {
+	return nil
+}
+
+func resolveStorageGuildID(guildID string) (string, error) {
+	serverID := GetGuildConfig(guildID).ServerID
+	if serverID == "" {
+		return "", fmt.Errorf("the server %s is not part of the guild config on path %s", guildID, guildConfigPath)
+	}
+	return serverID, nil
+}
+
+/*
+	SQLite backend - Raids get a row each, as that cache grows with every raid, the rest are stored as 1 document per collection
+*/
+
+func (store *sqliteStorage) readDocument(collection string, guildID string, target any) error {
+	data := ""
+	err := store.db.QueryRow(`SELECT data FROM documents WHERE collection = ? AND guild_id = ?`, collection, guildID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("the collection %s for server %s could not be read from the sqlite database: %s", collection, guildID, err.Error())
+	}
+	if err := json.Unmarshal([]byte(data), target); err != nil {
+		return fmt.Errorf("the collection %s for server %s could not be unmarshaled: %s", collection, guildID, err.Error())
+	}
+	return nil
+}
+
+func (store *sqliteStorage) writeDocument(collection string, guildID string, source any) error {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return fmt.Errorf("the collection %s for server %s could not be marshaled: %s", collection, guildID, err.Error())
+	}
+	_, err = store.db.Exec(`INSERT INTO documents (collection, guild_id, data, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (collection, guild_id) DO UPDATE SET data = excluded.data, updated_at = excluded.updated_at`, collection, guildID, string(data), time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("the collection %s for server %s could not be written to the sqlite database: %s", collection, guildID, err.Error())
+	}
+	return nil
+}
+
+func (store *sqliteStorage) ReadRaiderProfiles(guildID string) (raiderProfiles, error) {
+	profiles := raiderProfiles{}
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return profiles, err
+	}
+	err = store.readDocument(collectionRaiderProfiles, serverID, &profiles)
+	return profiles, err
+}
+
+func (store *sqliteStorage) WriteRaiderProfiles(guildID string, profiles raiderProfiles) error {
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return err
+	}
+	return store.writeDocument(collectionRaiderProfiles, serverID, profiles)
+}
+
+func (store *sqliteStorage) ReadMemberProfiles(cachePath string) ([]raiderProfile, error) {
+	profiles := []raiderProfile{}
+	err := store.readDocument(collectionMemberProfiles+filepath.Base(cachePath), "", &profiles)
+	return profiles, err
+}
+
+func (store *sqliteStorage) WriteMemberProfiles(cachePath string, profiles []raiderProfile) error {
+	return store.writeDocument(collectionMemberProfiles+filepath.Base(cachePath), "", profiles)
+}
+
+func (store *sqliteStorage) ReadRaids(guildID string, since time.Time) ([]logAllData, error) {
+	guildID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := store.db.Query(`SELECT data FROM raids WHERE guild_id = ? AND start_time >= ? ORDER BY start_time DESC`, guildID, since.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("the raids for server %s could not be read from the sqlite database: %s", guildID, err.Error())
+	}
+	defer rows.Close()
+	raids := []logAllData{}
+	for rows.Next() {
+		data := ""
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("a raid for server %s could not be read from the sqlite database: %s", guildID, err.Error())
+		}
+		raid := logAllData{}
+		if err := json.Unmarshal([]byte(data), &raid); err != nil {
+			return nil, fmt.Errorf("a raid for server %s could not be unmarshaled: %s", guildID, err.Error())
+		}
+		raids = append(raids, raid)
+	}
+	return raids, rows.Err()
+}
+
+func (store *sqliteStorage) writeRaids(guildID string, raids []logAllData, replaceAll bool) error {
+	guildID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return err
+	}
+	transaction, err := store.db.Begin()
+	if err != nil {
+		return fmt.Errorf("a transaction could not be started in the sqlite database: %s", err.Error())
+	}
+	defer transaction.Rollback()
+	if replaceAll {
+		if _, err := transaction.Exec(`DELETE FROM raids WHERE guild_id = ?`, guildID); err != nil {
+			return fmt.Errorf("the raids for server %s could not be deleted from the sqlite database: %s", guildID, err.Error())
+		}
+	}
+	for _, raid := range raids {
+		data, err := json.Marshal(raid)
+		if err != nil {
+			return fmt.Errorf("the raid %s for server %s could not be marshaled: %s", GetRaidCode(raid), guildID, err.Error())
+		}
+		timeOfRaid, _ := ParseGuildTime(timeLayout, raid.RaidStartTimeString)
+		_, err = transaction.Exec(`INSERT INTO raids (guild_id, code, start_time, data) VALUES (?, ?, ?, ?)
+			ON CONFLICT (guild_id, code) DO UPDATE SET start_time = excluded.start_time, data = excluded.data`, guildID, GetRaidCode(raid), timeOfRaid.Unix(), string(data))
+		if err != nil {
+			return fmt.Errorf("the raid %s for server %s could not be written to the sqlite database: %s", GetRaidCode(raid), guildID, err.Error())
+		}
+	}
+	return transaction.Commit()
+}
+
+func (store *sqliteStorage) WriteRaids(guildID string, raids []logAllData) error {
+	return store.writeRaids(guildID, raids, true)
+}
+
+func (store *sqliteStorage) AddRaids(guildID string, raids []logAllData) error {
+	return store.writeRaids(guildID, raids, false)
+}
+
+func (store *sqliteStorage) ReadReportWatermarks(guildID string) (map[string]reportWatermark, error) {
+	watermarks := make(map[string]reportWatermark)
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return watermarks, err
+	}
+	err = store.readDocument(collectionReportWatermarks, serverID, &watermarks)
+	return watermarks, err
+}
+
+func (store *sqliteStorage) WriteReportWatermarks(guildID string, watermarks map[string]reportWatermark) error {
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return err
+	}
+	return store.writeDocument(collectionReportWatermarks, serverID, watermarks)
+}
+
+func (store *sqliteStorage) ReadBenches(guildID string) (map[string]trackRaid, error) {
+	benches := make(map[string]trackRaid)
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return benches, err
+	}
+	err = store.readDocument(collectionBenches, serverID, &benches)
+	return benches, err
+}
+
+func (store *sqliteStorage) WriteBenches(guildID string, benches map[string]trackRaid) error {
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return err
+	}
+	return store.writeDocument(collectionBenches, serverID, benches)
+}
+
+func (store *sqliteStorage) ReadSecondaryRaids(guildID string) ([]commingRaid, error) {
+	raids := []commingRaid{}
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return raids, err
+	}
+	err = store.readDocument(collectionSecondaryRaids, serverID, &raids)
+	return raids, err
+}
+
+func (store *sqliteStorage) WriteSecondaryRaids(guildID string, raids []commingRaid) error {
+	serverID, err := resolveStorageGuildID(guildID)
+	if err != nil {
+		return err
+	}
+	return store.writeDocument(collectionSecondaryRaids, serverID, raids)
+}
+
+func (store *sqliteStorage) ReadFeedbackChannels() ([]playerChannel, error) {
+	channels := []playerChannel{}
+	err := store.readDocument(collectionFeedbackChannels, "", &channels)
+	return channels, err
+}
+
+func (store *sqliteStorage) WriteFeedbackChannels(channels []playerChannel) error {
+	return store.writeDocument(collectionFeedbackChannels, "", channels)
+}
+
+func (store *sqliteStorage) ReadTrackPosts() (map[string]trackPost, error) {
+	posts := make(map[string]trackPost)
+	err := store.readDocument(collectionTrackPosts, "", &posts)
+	return posts, err
+}
+
+func (store *sqliteStorage) WriteTrackPosts(posts map[string]trackPost) error {
+	return store.writeDocument(collectionTrackPosts, "", posts)
+}
+
+func (store *sqliteStorage) ReadReminders() ([]reminder, error) {
+	reminders := []reminder{}
+	err := store.readDocument(collectionReminders, "", &reminders)
+	return reminders, err
+}
+
+func (store *sqliteStorage) WriteReminders(reminders []reminder) error {
+	return store.writeDocument(collectionReminders, "", reminders)
+}
+
+func (store *sqliteStorage) ReadUserTimeZones() (map[string]string, error) {
+	timeZones := make(map[string]string)
+	err := store.readDocument(collectionUserTimeZones, "", &timeZones)
+	return timeZones, err
+}
+
+func (store *sqliteStorage) WriteUserTimeZones(timeZones map[string]string) error {
+	return store.writeDocument(collectionUserTimeZones, "", timeZones)
+}
+
+func (store *sqliteStorage) ReadSignUpNagOptOuts() (map[string]bool, error) {
+	optOuts := make(map[string]bool)
+	err := store.readDocument(collectionSignUpNagOptOuts, "", &optOuts)
+	return optOuts, err
+}
+
+func (store *sqliteStorage) WriteSignUpNagOptOuts(optOuts map[string]bool) error {
+	return store.writeDocument(collectionSignUpNagOptOuts, "", optOuts)
+}
+
+func (store *sqliteStorage) ReadJobStates() (map[string]jobState, error) {
+	states := make(map[string]jobState)
+	err := store.readDocument(collectionJobStates, "", &states)
+	return states, err
+}
+
+func (store *sqliteStorage) WriteJobStates(states map[string]jobState) error {
+	return store.writeDocument(collectionJobStates, "", states)
+}
+
+func (store *sqliteStorage) Close() error {
+	return store.db.Close()
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	_ "time/tzdata" //The host might not have the time zone database installed
+)
+
+const (
+	guildTimeZoneDefault = "Europe/Paris" //Server time of the EU realms, used when the guild config has no time zone
+	userTimeZoneReset    = "default"      //Given to /myreminder timezone to use the time zone of the guild again
+)
+
+// Written by the function MigrateLegacyTimeStrings() once the cached times have been converted
+type timeZoneMigration struct {
+	MigratedAt string `json:"migratedAt"`
+	TimeZone   string `json:"timeZone"` //The time zone of the primary guild the cached times were converted to
+}
+
+var (
+	timeZoneMutex      sync.Mutex
+	userTimeZoneMutex  sync.Mutex //Held for every read-modify-write of the time zones set by the users
+	mapOfTimeZoneCache = make(map[string]*time.Location)
+
+	primaryGuildLocation atomic.Pointer[time.Location] //Read without the lock of the guild config, as every log line is stamped with it
+)
+
+func LoadTimeZone(name string) (*time.Location, error) {
+	timeZoneMutex.Lock()
+	defer timeZoneMutex.Unlock()
+	if location, ok := mapOfTimeZoneCache[name]; ok {
+		return location, nil
+	}
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("the time zone must be a name from the IANA database, e.g. %s", guildTimeZoneDefault)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("the time zone %s is not known, use a name from the IANA database, e.g. %s", name, guildTimeZoneDefault)
+	}
+	mapOfTimeZoneCache[name] = location
+	return location, nil
+}
+
+// Called by the function ImportGuildConfig() with the time zone of the primary guild
+func SetPrimaryGuildLocation(timeZone string) {
+	if timeZone == "" {
+		timeZone = guildTimeZoneDefault
+	}
+	location, err := LoadTimeZone(timeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	primaryGuildLocation.Store(location)
+}
+
+// An empty guildID returns the time zone of the primary guild, used by every scheduler, parser and formatter
+func GetGuildLocation(guildID string) *time.Location {
+	timeZone := ""
+	if guildID == "" {
+		if location := primaryGuildLocation.Load(); location != nil {
+			return location
+		}
+	} else {
+		timeZone = GetGuildConfig(guildID).TimeZone
+	}
+	if timeZone == "" {
+		timeZone = guildTimeZoneDefault
+	}
+	location, err := LoadTimeZone(timeZone)
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("The time zone %s of server %s could not be loaded, UTC is used instead, during the function GetGuildLocation()", timeZone, guildID), err.Error())
+		return time.UTC
+	}
+	return location
+}
+
+// Falls back to the time zone of the guild when the user has not set one with /myreminder timezone
+func GetUserLocation(guildID string, userID string) *time.Location {
+	timeZones, err := storageCurrent.ReadUserTimeZones()
+	if err != nil {
+		WriteErrorLog(fmt.Sprintf("An error occured while trying to read the time zone of user %s, during the function GetUserLocation()", userID), err.Error())
+		return GetGuildLocation(guildID)
+	}
+	if timeZone, ok := timeZones[userID]; ok {
+		if location, err := LoadTimeZone(timeZone); err == nil {
+			return location
+		}
+	}
+	return GetGuildLocation(guildID)
+}
+
+// An empty timeZone removes the time zone of the user
+func SetUserTimeZone(userID string, timeZone string) error {
+	userTimeZoneMutex.Lock()
+	defer userTimeZoneMutex.Unlock()
+	timeZones, err := storageCurrent.ReadUserTimeZones()
+	if err != nil {
+		return err
+	}
+	if timeZone == "" {
+		delete(timeZones, userID)
+	} else {
+		timeZones[userID] = timeZone
+	}
+	return storageCurrent.WriteUserTimeZones(timeZones)
+}
+
+// The current time in the time zone of the primary guild
+func GetGuildTime() time.Time {
+	return time.Now().In(GetGuildLocation(""))
+}
+
+// The times stored in the caches carry no offset, they are written and read in the time zone of the primary guild
+func ParseGuildTime(layout string, value string) (time.Time, error) {
+	return time.ParseInLocation(layout, value, GetGuildLocation(""))
+}
+
+// Discord shows the time in the local time zone of every reader, e.g. "Thursday, 23 October 2026 19:00"
+func FormatDiscordTimestamp(timeToFormat time.Time) string {
+	return fmt.Sprintf("<t:%d:F>", timeToFormat.Unix())
+}
+
+// Before the guild config had a time zone, every time without an offset was written in the local time of the host, which
+// is UTC on the PaaS service. They are converted once to the time zone of the primary guild, later start-ups skip this
+func MigrateLegacyTimeStrings() error {
+	migration := timeZoneMigration{}
+	if err := readJSONCache(cacheTimeZoneMigration, &migration); err != nil {
+		return err
+	}
+	if migration.MigratedAt != "" {
+		return nil
+	}
+	location := GetGuildLocation("")
+	convertLegacyTime := func(value string) string {
+		legacyTime, err := time.ParseInLocation(timeLayout, value, time.UTC)
+		if err != nil {
+			return value //Empty or already in another layout, e.g. a date without a time
+		}
+		return legacyTime.In(location).Format(timeLayout)
+	}
+
+	//Everything is read and converted before anything is written, so a failed read leaves the caches untouched
+	mapOfRaids := make(map[string][]logAllData)
+	mapOfRaiderProfiles := make(map[string]raiderProfiles)
+	mapOfSecondaryRaids := make(map[string][]commingRaid)
+	for _, guildID := range GetGuildIDs() {
+		raids, err := storageCurrent.ReadRaids(guildID, time.Time{})
+		if err != nil {
+			return fmt.Errorf("the raids of server %s could not be read: %s", guildID, err.Error())
+		}
+		for x, raid := range raids {
+			if raid.RaidStartUnixTime != 0 {
+				raids[x].RaidStartTimeString = time.UnixMilli(raid.RaidStartUnixTime).In(location).Format(timeLayout)
+			}
+		}
+		mapOfRaids[guildID] = raids
+		profiles, err := storageCurrent.ReadRaiderProfiles(guildID)
+		if err != nil {
+			return fmt.Errorf("the raider profiles of server %s could not be read: %s", guildID, err.Error())
+		}
+		for x, raider := range profiles.Raiders {
+			profiles.Raiders[x].LastTimeChangedString = convertLegacyTime(raider.LastTimeChangedString)
+			profiles.Raiders[x].DateJoinedGuild = convertLegacyTime(raider.DateJoinedGuild)
+		}
+		mapOfRaiderProfiles[guildID] = profiles
+		secondaryRaids, err := storageCurrent.ReadSecondaryRaids(guildID)
+		if err != nil {
+			return fmt.Errorf("the secondary raids of server %s could not be read: %s", guildID, err.Error())
+		}
+		for x, cachedRaid := range secondaryRaids {
+			secondaryRaids[x].NextReset = convertLegacyTime(cachedRaid.NextReset)
+		}
+		mapOfSecondaryRaids[guildID] = secondaryRaids
+	}
+	mapOfMemberProfiles := make(map[string][]raiderProfile)
+	for _, cachePath := range []string{belowRaidersCachePath, raidersCachePath} {
+		profiles, err := storageCurrent.ReadMemberProfiles(cachePath)
+		if err != nil {
+			return fmt.Errorf("the member profiles on path %s could not be read: %s", cachePath, err.Error())
+		}
+		for x, profile := range profiles {
+			profiles[x].LastTimeChangedString = convertLegacyTime(profile.LastTimeChangedString)
+		}
+		mapOfMemberProfiles[cachePath] = profiles
+	}
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		return fmt.Errorf("the reminders could not be read: %s", err.Error())
+	}
+	for x, currentReminder := range reminders {
+		if currentReminder.TimeZone == "" {
+			reminders[x].TimeZone = time.UTC.String() //Repeats keep the clock they were set with
+		}
+	}
+
+	for _, guildID := range GetGuildIDs() {
+		if len(mapOfRaids[guildID]) > 0 {
+			if err := storageCurrent.WriteRaids(guildID, mapOfRaids[guildID]); err != nil {
+				return err
+			}
+		}
+		if len(mapOfRaiderProfiles[guildID].Raiders) > 0 {
+			if err := storageCurrent.WriteRaiderProfiles(guildID, mapOfRaiderProfiles[guildID]); err != nil {
+				return err
+			}
+		}
+		if len(mapOfSecondaryRaids[guildID]) > 0 {
+			if err := storageCurrent.WriteSecondaryRaids(guildID, mapOfSecondaryRaids[guildID]); err != nil {
+				return err
+			}
+		}
+	}
+	for cachePath, profiles := range mapOfMemberProfiles {
+		if len(profiles) > 0 {
+			if err := storageCurrent.WriteMemberProfiles(cachePath, profiles); err != nil {
+				return err
+			}
+		}
+	}
+	if len(reminders) > 0 {
+		if err := storageCurrent.WriteReminders(reminders); err != nil {
+			return err
+		}
+	}
+	migration = timeZoneMigration{
+		MigratedAt: GetTimeString(),
+		TimeZone:   location.String(),
+	}
+	if err := writeJSONCache(cacheTimeZoneMigration, migration); err != nil {
+		return fmt.Errorf("the time zone migration state could not be saved: %s", err.Error())
+	}
+	WriteInformationLog(fmt.Sprintf("The cached times have been converted from UTC to the time zone %s, during the function MigrateLegacyTimeStrings()", location.String()), "Migrated cache")
+	return nil
+}
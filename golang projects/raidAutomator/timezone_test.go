@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// The default guild config uses Europe/Paris, which is UTC+1 in January
+func TestMigrateLegacyTimeStrings(t *testing.T) {
+	SetUpTestWorkDirectory(t)
+	guildID := GetGuildConfig("").ServerID
+	raidStart := time.Date(2025, time.January, 9, 19, 30, 0, 0, time.UTC)
+	legacyRaid := logAllData{
+		RaidTitle:           "Molten Core",
+		RaidStartUnixTime:   raidStart.UnixMilli(),
+		RaidStartTimeString: raidStart.Format(timeLayout),
+		MetaData:            logsBase{Code: "legacyRaid"},
+	}
+	if err := storageCurrent.WriteRaids(guildID, []logAllData{legacyRaid}); err != nil {
+		t.Fatal(err)
+	}
+	legacyProfile := raiderProfile{ID: "1", MainCharName: "Frostbolt", DateJoinedGuild: "January 2, 2025 18:00:00", LastTimeChangedString: "January 3, 2025 23:30:00"}
+	if err := storageCurrent.WriteRaiderProfiles(guildID, raiderProfiles{Raiders: []raiderProfile{legacyProfile}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storageCurrent.WriteMemberProfiles(belowRaidersCachePath, []raiderProfile{legacyProfile}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storageCurrent.WriteReminders([]reminder{{ID: "1", Title: "Flasks", DueTime: raidStart, Recurrence: reminderRepeatWeekly}}); err != nil {
+		t.Fatal(err)
+	}
+
+	//Run twice, the second run must leave the converted times alone
+	for x := 0; x < 2; x++ {
+		if err := MigrateLegacyTimeStrings(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	raids, err := storageCurrent.ReadRaids(guildID, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raids) != 1 || raids[0].RaidStartTimeString != "January 9, 2025 20:30:00" {
+		t.Errorf("expected the raid to start at January 9, 2025 20:30:00 guild time, got %+v", raids)
+	}
+	profiles, err := storageCurrent.ReadRaiderProfiles(guildID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(profiles.Raiders) != 1 || profiles.Raiders[0].DateJoinedGuild != "January 2, 2025 19:00:00" || profiles.Raiders[0].LastTimeChangedString != "January 4, 2025 00:30:00" {
+		t.Errorf("expected the raider profile to be converted to guild time, got %+v", profiles.Raiders)
+	}
+	members, err := storageCurrent.ReadMemberProfiles(belowRaidersCachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].LastTimeChangedString != "January 4, 2025 00:30:00" {
+		t.Errorf("expected the member profile to be converted to guild time, got %+v", members)
+	}
+	reminders, err := storageCurrent.ReadReminders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reminders) != 1 || reminders[0].TimeZone != "UTC" || !reminders[0].DueTime.Equal(raidStart) {
+		t.Errorf("expected the reminder to keep its due time and repeat in UTC, got %+v", reminders)
+	}
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type warcraftLogsClient struct {
+	httpClient   *http.Client
+	apiURL       string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	maxRetries   int
+
+	mutex            sync.Mutex //Guards the token and the rate limit below
+	accessToken      string
+	tokenExpiry      time.Time
+	rateLimitedUntil time.Time //Set from the rate-limit headers, no request is sent before this time
+}
+
+type warcraftLogsError struct {
+	QueryName  string
+	StatusCode int //0 when the error is from the GraphQL body and not the HTTP status
+	Messages   []string
+}
+
+type warcraftLogsResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Error  string          `json:"error"` //Set by the API itself, e.g. when the token is invalid
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"` //Set by GraphQL, e.g. when a variable has the wrong type
+}
+
+type warcraftLogsToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"` //Seconds
+}
+
+type warcraftLogsFight struct {
+	ID              int64   `json:"id"`
+	EncounterID     int64   `json:"encounterID"` //0 for trash
+	StartTime       int64   `json:"startTime"`   //Milliseconds since the start of the report
+	EndTime         int64   `json:"endTime"`
+	Kill            *bool   `json:"kill"`            //Nil for trash
+	FightPercentage float64 `json:"fightPercentage"` //Health left on the boss, e.g. 35.5 for a wipe at 35.5%
+}
+
+type warcraftLogsReportFights struct {
+	EndTime int64               `json:"endTime"` //Unix milliseconds, grows while the log is still being uploaded
+	Fights  []warcraftLogsFight `json:"fights"`
+}
+
+type warcraftLogsZone struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type warcraftLogsEncounter struct {
+	ID   int              `json:"id"`
+	Name string           `json:"name"`
+	Zone warcraftLogsZone `json:"zone"`
+}
+
+const (
+	warcraftLogsAPIURL        = "https://fresh.warcraftlogs.com/api/v2/client"
+	warcraftLogsTokenURL      = "https://www.warcraftlogs.com/oauth/token"
+	warcraftLogsMaxRetries    = 5
+	warcraftLogsMaxBackoff    = 60 * time.Second
+	warcraftLogsTokenMargin   = 5 * time.Minute         //The token is refreshed this long before it expires
+	warcraftLogsClientTimeout = 2 * time.Minute         //The full report query can take a while for long raids
+	warcraftLogsBaseURLEnv    = "WARCRAFTLOGS_BASE_URL" //Points the bot at another API, e.g. a local copy of it
+)
+
+var warcraftLogsCurrent *warcraftLogsClient //Set by the function CheckRuntime() during start-up
+
+func NewWarcraftLogsClient(clientID string, clientSecret string) *warcraftLogsClient {
+	return &warcraftLogsClient{
+		httpClient:   &http.Client{Timeout: warcraftLogsClientTimeout},
+		apiURL:       warcraftLogsAPIURL,
+		tokenURL:     warcraftLogsTokenURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		maxRetries:   warcraftLogsMaxRetries,
+	}
+}
+
+// Serves both the API and the token endpoint from the same host, the paths are the ones of the real API
+func (client *warcraftLogsClient) SetBaseURL(baseURL string) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.apiURL = baseURL + "/api/v2/client"
+	client.tokenURL = baseURL + "/oauth/token"
+	client.accessToken = ""
+}
+
+func (err *warcraftLogsError) Error() string {
+	if err.StatusCode != 0 {
+		return fmt.Sprintf("the warcraftlogs query %s failed with status %d: %s", err.QueryName, err.StatusCode, strings.Join(err.Messages, ", "))
+	}
+	return fmt.Sprintf("the warcraftlogs query %s returned errors: %s", err.QueryName, strings.Join(err.Messages, ", "))
+}
+
+// Returned in the same shape as the API response, which is what the function UnwrapWarcraftLogRaiderRanking() reads, e.g. {"data": {"characterData": ...}}
+func (client *warcraftLogsClient) GetCharacterRankings(characterName string) (map[string]any, error) {
+	return client.Query("playerRankings", map[string]any{"name": characterName})
+}
+
+// Only the reports of the known loggers are returned, see the function UnwrapBaseWarcraftLogRaids()
+func (client *warcraftLogsClient) GetGuildReports(warcraftLogsGuildID int, page int) ([]logsBase, error) {
+	response, err := client.Query("guildLogsRaidIDs", map[string]any{"guildID": warcraftLogsGuildID, "page": page})
+	if err != nil {
+		return nil, err
+	}
+	return UnwrapBaseWarcraftLogRaids(response), nil
+}
+
+func (client *warcraftLogsClient) GetReportFights(code string) (warcraftLogsReportFights, error) {
+	data := struct {
+		ReportData struct {
+			Report *warcraftLogsReportFights `json:"report"`
+		} `json:"reportData"`
+	}{}
+	if err := client.QueryInto("allFightIDsForRaid", map[string]any{"code": code}, &data); err != nil {
+		return warcraftLogsReportFights{}, err
+	}
+	if data.ReportData.Report == nil {
+		return warcraftLogsReportFights{}, &warcraftLogsError{QueryName: "allFightIDsForRaid", Messages: []string{fmt.Sprintf("no report found with code %s", code)}}
+	}
+	return *data.ReportData.Report, nil
+}
+
+func (client *warcraftLogsClient) GetReportFightIDs(code string) ([]int64, error) {
+	report, err := client.GetReportFights(code)
+	if err != nil {
+		return nil, err
+	}
+	fightIDs := []int64{}
+	for _, fight := range report.Fights {
+		fightIDs = append(fightIDs, fight.ID)
+	}
+	return fightIDs, nil
+}
+
+// Returned in the same shape as the API response, which is what the function UnwrapFullWarcraftLogRaid() reads.
+// The tables of every boss in the fights are added to the report as encounterTables, keyed by the encounter ID
+func (client *warcraftLogsClient) GetReport(code string, fightIDs []int64) (map[string]any, error) {
+	response, err := client.Query("logsByOwnerAndCode", map[string]any{"code": code, "fightIDs": fightIDs})
+	if err != nil {
+		return nil, err
+	}
+	data, _ := response["data"].(map[string]any)
+	reportData, _ := data["reportData"].(map[string]any)
+	report, ok := reportData["report"].(map[string]any)
+	if !ok { //Left to the function VerifyWarcraftLogData()
+		return response, nil
+	}
+	mapOfEncounterFightIDs := make(map[int64][]int64)
+	sliceOfFights, _ := report["fights"].([]any)
+	for _, sliceOfFight := range sliceOfFights {
+		mapOfFight, ok := sliceOfFight.(map[string]any)
+		if !ok {
+			continue
+		}
+		encounterID, _ := mapOfFight["encounterID"].(float64)
+		fightID, _ := mapOfFight["id"].(float64)
+		if encounterID == 0 || !slices.Contains(fightIDs, int64(fightID)) { //The fights of the report are not limited to the fight IDs
+			continue
+		}
+		mapOfEncounterFightIDs[int64(encounterID)] = append(mapOfEncounterFightIDs[int64(encounterID)], int64(fightID))
+	}
+	encounterTables := map[string]any{}
+	for encounterID, encounterFightIDs := range mapOfEncounterFightIDs {
+		tables, err := client.GetReportEncounterTables(code, encounterFightIDs, encounterID)
+		if err != nil {
+			return nil, err
+		}
+		encounterTables[strconv.FormatInt(encounterID, 10)] = tables
+	}
+	report["encounterTables"] = encounterTables
+	return response, nil
+}
+
+// The damage done, healing done and deaths of one boss, which is what the function UnwrapWarcraftLogEncounters() reads.
+// The tables are summed by warcraftlogs, unlike the events of a report they are not cut off after one page
+func (client *warcraftLogsClient) GetReportEncounterTables(code string, fightIDs []int64, encounterID int64) (map[string]any, error) {
+	data := struct {
+		ReportData struct {
+			Report map[string]any `json:"report"`
+		} `json:"reportData"`
+	}{}
+	if err := client.QueryInto("encounterTables", map[string]any{"code": code, "fightIDs": fightIDs, "encounterID": encounterID}, &data); err != nil {
+		return nil, err
+	}
+	if data.ReportData.Report == nil {
+		return nil, &warcraftLogsError{QueryName: "encounterTables", Messages: []string{fmt.Sprintf("no report found with code %s", code)}}
+	}
+	return data.ReportData.Report, nil
+}
+
+func (client *warcraftLogsClient) GetReportActorBuffs(code string, fightIDs []int64, actorID int) (map[string]any, error) {
+	return client.Query("reportActorBuffs", map[string]any{"code": code, "fightIDs": fightIDs, "actorID": actorID})
+}
+
+func (client *warcraftLogsClient) GetEncounter(encounterID int64) (warcraftLogsEncounter, error) {
+	data := struct {
+		WorldData struct {
+			Encounter *warcraftLogsEncounter `json:"encounter"`
+		} `json:"worldData"`
+	}{}
+	if err := client.QueryInto("logsByEncounterID", map[string]any{"encounterID": encounterID}, &data); err != nil {
+		return warcraftLogsEncounter{}, err
+	}
+	if data.WorldData.Encounter == nil {
+		return warcraftLogsEncounter{}, &warcraftLogsError{QueryName: "logsByEncounterID", Messages: []string{fmt.Sprintf("no encounter found with id %d", encounterID)}}
+	}
+	return *data.WorldData.Encounter, nil
+}
+
+// Runs one of the queries in mapOfWarcaftLogsQueries, the variables given are added on top of the defaults of the query
+func (client *warcraftLogsClient) Query(queryName string, variables map[string]any) (map[string]any, error) {
+	data, err := client.Execute(queryName, variables)
+	if err != nil {
+		return nil, err
+	}
+	returnMap := map[string]any{}
+	var dataMap map[string]any
+	if err := json.Unmarshal(data, &dataMap); err != nil {
+		return nil, fmt.Errorf("the data of the warcraftlogs query %s could not be unmarshaled: %s", queryName, err.Error())
+	}
+	returnMap["data"] = dataMap
+	return returnMap, nil
+}
+
+func (client *warcraftLogsClient) QueryInto(queryName string, variables map[string]any, target any) error {
+	data, err := client.Execute(queryName, variables)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("the data of the warcraftlogs query %s could not be unmarshaled: %s", queryName, err.Error())
+	}
+	return nil
+}
+
+// Returns the data attribute of the response, retrying network errors, 429 and 5xx with exponential backoff
+func (client *warcraftLogsClient) Execute(queryName string, variables map[string]any) (json.RawMessage, error) {
+	queryTemplate, ok := mapOfWarcaftLogsQueries[queryName]
+	if !ok {
+		return nil, fmt.Errorf("no warcraftlogs query exists with the name %s", queryName)
+	}
+	mergedVariables := map[string]any{}
+	switch defaultVariables := queryTemplate["variables"].(type) {
+	case map[string]any:
+		{
+			for key, value := range defaultVariables {
+				mergedVariables[key] = value
+			}
+		}
+	case map[string]string:
+		{
+			for key, value := range defaultVariables {
+				mergedVariables[key] = value
+			}
+		}
+	}
+	for key, value := range variables {
+		mergedVariables[key] = value
+	}
+	requestBody, err := json.Marshal(map[string]any{"query": queryTemplate["query"], "variables": mergedVariables})
+	if err != nil {
+		return nil, fmt.Errorf("the warcraftlogs query %s could not be marshaled: %s", queryName, err.Error())
+	}
+
+	tokenRefreshed := false
+	var lastErr error
+	for attempt := 0; attempt <= client.maxRetries; attempt++ {
+		client.WaitForRateLimit()
+		token, err := client.GetAccessToken(false)
+		if err != nil {
+			return nil, err
+		}
+
+		request, err := http.NewRequest("POST", client.apiURL, bytes.NewReader(requestBody))
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", "Bearer "+token)
+
+		response, err := client.httpClient.Do(request)
+		if err != nil {
+			lastErr = err
+			WriteWarningLog(fmt.Sprintf("The warcraftlogs query %s failed on attempt %d and will be retried: %s, during the function Execute()", queryName, attempt+1, err.Error()), "Warcraftlogs retry", LogField("query", queryName))
+			time.Sleep(GetWarcraftLogsBackoff(attempt, 0))
+			continue
+		}
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		retryAfter := client.UpdateRateLimit(response.Header)
+		if err != nil {
+			lastErr = err
+			time.Sleep(GetWarcraftLogsBackoff(attempt, retryAfter))
+			continue
+		}
+
+		switch {
+		case response.StatusCode == http.StatusUnauthorized && !tokenRefreshed:
+			{
+				tokenRefreshed = true
+				attempt-- //A refreshed token does not count as a retry
+				if _, err := client.GetAccessToken(true); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		case response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500:
+			{
+				lastErr = &warcraftLogsError{QueryName: queryName, StatusCode: response.StatusCode, Messages: []string{strings.TrimSpace(string(body))}}
+				WriteWarningLog(fmt.Sprintf("The warcraftlogs query %s got status %d on attempt %d and will be retried, during the function Execute()", queryName, response.StatusCode, attempt+1), "Warcraftlogs retry", LogField("query", queryName))
+				time.Sleep(GetWarcraftLogsBackoff(attempt, retryAfter))
+				continue
+			}
+		case response.StatusCode != http.StatusOK:
+			{
+				return nil, &warcraftLogsError{QueryName: queryName, StatusCode: response.StatusCode, Messages: []string{strings.TrimSpace(string(body))}}
+			}
+		}
+
+		graphQLResponse := warcraftLogsResponse{}
+		if err := json.Unmarshal(body, &graphQLResponse); err != nil {
+			return nil, fmt.Errorf("the response of the warcraftlogs query %s could not be unmarshaled: %s", queryName, err.Error())
+		}
+		if graphQLResponse.Error != "" || len(graphQLResponse.Errors) > 0 {
+			queryErr := &warcraftLogsError{QueryName: queryName}
+			if graphQLResponse.Error != "" {
+				queryErr.Messages = append(queryErr.Messages, graphQLResponse.Error)
+			}
+			for _, graphQLError := range graphQLResponse.Errors {
+				queryErr.Messages = append(queryErr.Messages, graphQLError.Message)
+			}
+			return nil, queryErr
+		}
+		if len(graphQLResponse.Data) == 0 || string(graphQLResponse.Data) == "null" {
+			return nil, &warcraftLogsError{QueryName: queryName, Messages: []string{"the response has no data"}}
+		}
+		return graphQLResponse.Data, nil
+	}
+	return nil, fmt.Errorf("the warcraftlogs query %s failed after %d attempts: %w", queryName, client.maxRetries+1, lastErr)
+}
+
+// Uses the client credentials flow, a cached token is returned until it is about to expire
+func (client *warcraftLogsClient) GetAccessToken(forceRefresh bool) (string, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if !forceRefresh && client.accessToken != "" && time.Now().Before(client.tokenExpiry) {
+		return client.accessToken, nil
+	}
+
+	OAuth2Body := url.Values{}
+	OAuth2Body.Add("grant_type", "client_credentials")
+	request, err := http.NewRequest("POST", client.tokenURL, strings.NewReader(OAuth2Body.Encode()))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(client.clientID, client.clientSecret)
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("the warcraftlogs token could not be requested: %s", err.Error())
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("the warcraftlogs token response could not be read: %s", err.Error())
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", &warcraftLogsError{QueryName: "oauth token", StatusCode: response.StatusCode, Messages: []string{strings.TrimSpace(string(body))}}
+	}
+	token := warcraftLogsToken{}
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("the warcraftlogs token response has no access_token")
+	}
+
+	client.accessToken = token.AccessToken
+	client.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - warcraftLogsTokenMargin)
+	WriteInformationLog(fmt.Sprintf("A new warcraftlogs token has been obtained, it expires at %s", client.tokenExpiry.Add(warcraftLogsTokenMargin).Format(timeLayout)), "Warcraftlogs token")
+	return client.accessToken, nil
+}
+
+// Reads the rate-limit headers of a response, returns how long the API asked us to wait, if at all
+func (client *warcraftLogsClient) UpdateRateLimit(header http.Header) time.Duration {
+	retryAfter := time.Duration(0)
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			retryAfter = time.Until(date)
+		}
+	}
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			if reset > 1000000000 { //A unix time and not a number of seconds
+				retryAfter = max(retryAfter, time.Until(time.Unix(reset, 0)))
+			} else {
+				retryAfter = max(retryAfter, time.Duration(reset)*time.Second)
+			}
+		}
+	}
+	if retryAfter > 0 {
+		client.mutex.Lock()
+		client.rateLimitedUntil = time.Now().Add(retryAfter)
+		client.mutex.Unlock()
+		WriteWarningLog(fmt.Sprintf("The warcraftlogs rate limit has been reached, no queries are sent for the next %s", retryAfter.Round(time.Second)), "Warcraftlogs rate limit")
+	}
+	return retryAfter
+}
+
+func (client *warcraftLogsClient) WaitForRateLimit() {
+	client.mutex.Lock()
+	wait := time.Until(client.rateLimitedUntil)
+	client.mutex.Unlock()
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// 1s, 2s, 4s... with jitter, capped at warcraftLogsMaxBackoff - a Retry-After from the API is used when it is longer
+func GetWarcraftLogsBackoff(attempt int, retryAfter time.Duration) time.Duration {
+	backoff := time.Second << attempt
+	if backoff > warcraftLogsMaxBackoff || backoff <= 0 {
+		backoff = warcraftLogsMaxBackoff
+	}
+	backoff = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	if retryAfter > backoff {
+		return 0 //WaitForRateLimit() already sleeps until the time given by the API
+	}
+	return backoff
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// An offline stand-in for the Warcraft Logs v2 API. It serves the OAuth token endpoint and the GraphQL endpoint
+// from the recorded responses in testdata/warcraftlogs/fixtures, so the tests run without network access
+type fakeWarcraftLogsServer struct {
+	*httptest.Server
+	fixtures fs.FS
+
+	mutex     sync.Mutex
+	overrides map[string][]byte //Fixture name to the response, takes priority over the recorded fixtures
+	requests  []fakeWarcraftLogsRequest
+	failures  []int //Status codes returned by the next GraphQL requests, one per request
+}
+
+// One GraphQL query the fake has answered, in the order they were received
+type fakeWarcraftLogsRequest struct {
+	Operation string
+	Variables map[string]any
+}
+
+const (
+	fakeWarcraftLogsTokenPath   = "/oauth/token"
+	fakeWarcraftLogsAPIPath     = "/api/v2/client"
+	fakeWarcraftLogsAccessToken = "fake-warcraftlogs-token"
+)
+
+var (
+	//go:embed testdata/warcraftlogs/fixtures/*.json
+	recordedWarcraftLogsFixtures embed.FS
+
+	patternFakeOperation = regexp.MustCompile(`query\s+(\w+)`)
+)
+
+// Starts a server that answers from the recorded fixtures
+func NewFakeWarcraftLogsServer() *fakeWarcraftLogsServer {
+	fixtures, _ := fs.Sub(recordedWarcraftLogsFixtures, "testdata/warcraftlogs/fixtures")
+	server := &fakeWarcraftLogsServer{
+		fixtures:  fixtures,
+		overrides: make(map[string][]byte),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(fakeWarcraftLogsTokenPath, server.handleToken)
+	mux.HandleFunc(fakeWarcraftLogsAPIPath, server.handleQuery)
+	server.Server = httptest.NewServer(mux)
+	return server
+}
+
+// The name of the fixture answering a query, e.g. report_<code>.json for GetLogByCode
+func GetFakeFixtureName(operation string, variables map[string]any) string {
+	switch operation {
+	case "GuildLogs":
+		{
+			return fmt.Sprintf("guild_logs_page_%v.json", variables["page"])
+		}
+	case "GetFights":
+		{
+			return fmt.Sprintf("fights_%v.json", variables["code"])
+		}
+	case "GetLogByCode":
+		{
+			return fmt.Sprintf("report_%v.json", variables["code"])
+		}
+	case "GetCharacter":
+		{
+			return fmt.Sprintf("character_%s.json", strings.ToLower(fmt.Sprint(variables["name"])))
+		}
+	case "GetEncounterInfo":
+		{
+			return fmt.Sprintf("encounter_%v.json", variables["encounterID"])
+		}
+	case "GetActorBuffs":
+		{
+			return fmt.Sprintf("actor_buffs_%v_%v.json", variables["code"], variables["actorID"])
+		}
+	case "GetEncounterTables":
+		{
+			return fmt.Sprintf("encounter_tables_%v_%v.json", variables["code"], variables["encounterID"])
+		}
+	}
+	return ""
+}
+
+// Replaces the response of a fixture for the rest of the life of the server
+func (server *fakeWarcraftLogsServer) SetFixture(name string, response []byte) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.overrides[name] = response
+}
+
+// The next len(statusCodes) GraphQL requests are answered with these status codes, e.g. 429 or 503 to test retries
+func (server *fakeWarcraftLogsServer) FailNext(statusCodes ...int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.failures = append(server.failures, statusCodes...)
+}
+
+func (server *fakeWarcraftLogsServer) Requests() []fakeWarcraftLogsRequest {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]fakeWarcraftLogsRequest{}, server.requests...)
+}
+
+func (server *fakeWarcraftLogsServer) handleToken(writer http.ResponseWriter, request *http.Request) {
+	if _, _, ok := request.BasicAuth(); !ok || request.Method != http.MethodPost {
+		http.Error(writer, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+	writeFakeJSON(writer, http.StatusOK, map[string]any{
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"access_token": fakeWarcraftLogsAccessToken,
+	})
+}
+
+func (server *fakeWarcraftLogsServer) handleQuery(writer http.ResponseWriter, request *http.Request) {
+	if request.Header.Get("Authorization") != "Bearer "+fakeWarcraftLogsAccessToken {
+		writeFakeJSON(writer, http.StatusUnauthorized, map[string]any{"error": "Unauthenticated."})
+		return
+	}
+	body := struct {
+		Query     string         `json:"query"`
+		Variables map[string]any `json:"variables"`
+	}{}
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		writeFakeJSON(writer, http.StatusBadRequest, map[string]any{"errors": []map[string]any{{"message": err.Error()}}})
+		return
+	}
+	operation := ""
+	if match := patternFakeOperation.FindStringSubmatch(body.Query); match != nil {
+		operation = match[1]
+	}
+
+	server.mutex.Lock()
+	server.requests = append(server.requests, fakeWarcraftLogsRequest{Operation: operation, Variables: body.Variables})
+	failure := 0
+	if len(server.failures) > 0 {
+		failure = server.failures[0]
+		server.failures = server.failures[1:]
+	}
+	server.mutex.Unlock()
+	if failure != 0 {
+		if failure == http.StatusTooManyRequests {
+			writer.Header().Set("Retry-After", "1")
+			writer.Header().Set("X-RateLimit-Remaining", "0")
+		}
+		writeFakeJSON(writer, failure, map[string]any{"error": http.StatusText(failure)})
+		return
+	}
+
+	name := GetFakeFixtureName(operation, body.Variables)
+	if name == "" {
+		writeFakeJSON(writer, http.StatusOK, map[string]any{"errors": []map[string]any{{"message": fmt.Sprintf("the fake has no support for the query %s", operation)}}})
+		return
+	}
+	server.mutex.Lock()
+	response, ok := server.overrides[name]
+	server.mutex.Unlock()
+	if !ok {
+		var err error
+		response, err = fs.ReadFile(server.fixtures, name)
+		if err != nil {
+			response = GetFakeEmptyResponse(operation)
+		}
+	}
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("X-RateLimit-Limit", "3600")
+	writer.Header().Set("X-RateLimit-Remaining", "3599")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(response)
+}
+
+// What the real API answers when nothing is found, e.g. a page after the last page of guild logs
+func GetFakeEmptyResponse(operation string) []byte {
+	switch operation {
+	case "GuildLogs":
+		{
+			return []byte(`{"data":{"reportData":{"reports":{"data":[]}}}}`)
+		}
+	case "GetFights", "GetLogByCode", "GetActorBuffs", "GetEncounterTables":
+		{
+			return []byte(`{"data":{"reportData":{"report":null}}}`)
+		}
+	case "GetCharacter":
+		{
+			return []byte(`{"data":{"characterData":{"character":null}}}`)
+		}
+	case "GetEncounterInfo":
+		{
+			return []byte(`{"data":{"worldData":{"encounter":null}}}`)
+		}
+	}
+	return []byte(`{"data":null}`)
+}
+
+func writeFakeJSON(writer http.ResponseWriter, statusCode int, source any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	json.NewEncoder(writer).Encode(source)
+}